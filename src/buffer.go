@@ -0,0 +1,366 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Structure representing a single file opened in the editor. Every buffer has its own text, history, syntax highlighter
+// and the stored cursor position, which is restored after switching back to the buffer
+type Buffer struct {
+	filePath    string
+	fileName    string
+	fileExists  bool
+	writable    bool
+	readOnly    bool
+	highlight   bool
+	text        *Text
+	history     *History
+	highlighter *Highlighter
+	loader      *FileLoader
+	loadErr     error
+	source      *LineSource
+	xCursor     int
+	yCursor     int
+}
+
+// Buffer structure initialization function. The content of the file is loaded if the file exists. Only the beginning of the
+// files larger than the configured threshold is loaded, the remaining lines are loaded after the loading is started
+func (buffer *Buffer) Init(filePath string, config *Config) error {
+	if len(filePath) <= 0 {
+		return errors.New("buffer: invalid path passed to buffer")
+	}
+
+	if config == nil {
+		return errors.New("buffer: invalid config reference")
+	}
+
+	buffer.filePath = filePath
+	buffer.fileName = filepath.Base(buffer.filePath)
+
+	if _, err := os.Stat(buffer.filePath); err == nil {
+		buffer.fileExists = true
+	} else if errors.Is(err, os.ErrNotExist) {
+		buffer.fileExists = false
+	} else {
+		return errors.New("buffer: can not determine if the file is accesable")
+	}
+
+	// NOTE: The permissions of the not existing file are unknown until the file is created
+	buffer.writable = !buffer.fileExists || isFileWritable(buffer.filePath)
+	buffer.readOnly = false
+
+	buffer.loader = nil
+	buffer.loadErr = nil
+	buffer.source = nil
+
+	fileTextContent := ""
+	if buffer.fileExists {
+		content, err := buffer.readFileContent(config.TextConfiguration.LargeFileThresholdMegabytes)
+		if err != nil {
+			return err
+		}
+
+		fileTextContent = content
+	}
+
+	buffer.text = new(Text)
+	if err := buffer.text.Init(fileTextContent, !buffer.fileExists, &config.TextConfiguration); err != nil {
+		return err
+	}
+
+	buffer.history = new(History)
+	if err := buffer.history.Init(&config.HistoryConfiguration); err != nil {
+		return err
+	}
+
+	if err := buffer.text.AttachHistory(buffer.history); err != nil {
+		return err
+	}
+
+	buffer.highlight = config.DisplayConfiguration.SyntaxHighlighting
+	buffer.highlighter = new(Highlighter)
+	if err := buffer.initHighlighter(); err != nil {
+		return err
+	}
+
+	buffer.xCursor = 0
+	buffer.yCursor = 0
+
+	return nil
+}
+
+// Helper function used to read the content of the file. The files larger than the given threshold (in megabytes) are read only
+// partially and the loader of the remaining lines is prepared
+func (buffer *Buffer) readFileContent(thresholdMegabytes int) (string, error) {
+	fileInfo, err := os.Stat(buffer.filePath)
+	if err != nil {
+		return "", err
+	}
+
+	if thresholdMegabytes <= 0 || fileInfo.Size() < int64(thresholdMegabytes)*1024*1024 {
+		fileData, err := os.ReadFile(buffer.filePath)
+		if err != nil {
+			return "", err
+		}
+
+		return string(fileData), nil
+	}
+
+	loader := new(FileLoader)
+	if err := loader.Init(buffer.filePath); err != nil {
+		return "", err
+	}
+
+	content, complete, err := loader.ReadInitialContent(loaderInitialSize)
+	if err != nil {
+		return "", err
+	}
+
+	if !complete {
+		buffer.loader = loader
+		buffer.source = loader.GetLineSource()
+	}
+
+	return content, nil
+}
+
+// Start loading the remaining lines of the large file in the background. The notify function is called (from a different
+// goroutine) when new lines are ready to be applied with the ApplyLoadedLines function
+func (buffer *Buffer) StartLoading(notify func()) error {
+	if buffer.loader == nil {
+		return nil
+	}
+
+	return buffer.loader.Start(notify)
+}
+
+// Append the lines loaded in the background since the previous call to the buffer text. The function returns a bool value
+// indicating if the text was changed. The error is returned if the file could not be loaded completely
+func (buffer *Buffer) ApplyLoadedLines() (bool, error) {
+	if buffer.loader == nil {
+		return false, nil
+	}
+
+	lines, done, loadErr := buffer.loader.TakeLines()
+	if err := buffer.text.AppendLines(lines); err != nil {
+		return false, err
+	}
+
+	if done {
+		buffer.loader = nil
+		buffer.loadErr = loadErr
+	}
+
+	return len(lines) > 0, loadErr
+}
+
+// Stop loading the remaining lines of the file. The lines loaded so far are applied, but the buffer is kept incomplete, so the
+// changes are not allowed
+func (buffer *Buffer) CancelLoading() error {
+	if buffer.loader == nil {
+		return nil
+	}
+
+	buffer.loader.Cancel()
+	if _, err := buffer.ApplyLoadedLines(); err != nil && !errors.Is(err, errLoaderCancelled) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop loading the file and close the file referenced by the loaded lines. The buffer text can not be accessed afterwards
+func (buffer *Buffer) Close() error {
+	if err := buffer.CancelLoading(); err != nil {
+		return err
+	}
+
+	if buffer.source == nil {
+		return nil
+	}
+
+	source := buffer.source
+	buffer.source = nil
+
+	return source.Close()
+}
+
+// Return a bool value indicating if the remaining lines of the file are still being loaded
+func (buffer *Buffer) IsLoading() bool {
+	return buffer.loader != nil
+}
+
+// Return the loading progress as the percentage of the file size
+func (buffer *Buffer) GetLoadingProgress() int {
+	if buffer.loader == nil {
+		return 100
+	}
+
+	return buffer.loader.GetProgress()
+}
+
+// Return a bool value indicating if the whole file was loaded. The buffer text is incomplete while the file is being loaded
+// or after the loading failed, so the changes (and saving) are not allowed
+func (buffer *Buffer) IsLoaded() bool {
+	return buffer.loader == nil && buffer.loadErr == nil
+}
+
+// Return the path of the file associated with the buffer
+func (buffer *Buffer) GetFilePath() string {
+	return buffer.filePath
+}
+
+// Return the name of the file associated with the buffer
+func (buffer *Buffer) GetFileName() string {
+	return buffer.fileName
+}
+
+// Return a bool value indicating if the file associated with the buffer exists
+func (buffer *Buffer) FileExists() bool {
+	return buffer.fileExists
+}
+
+// Set the read-only mode requested by the user. The buffer is also read-only if the file is not writable
+func (buffer *Buffer) SetReadOnly(readOnly bool) {
+	buffer.readOnly = readOnly
+}
+
+// Return a bool value indicating if the buffer is read-only, because the read-only mode was requested or the file is not writable.
+// The changes of the read-only buffer are not allowed and the buffer can only be saved as a different file
+func (buffer *Buffer) IsReadOnly() bool {
+	return buffer.readOnly || !buffer.writable
+}
+
+// Return the text of the buffer
+func (buffer *Buffer) GetText() *Text {
+	return buffer.text
+}
+
+// Return the history of the buffer
+func (buffer *Buffer) GetHistory() *History {
+	return buffer.history
+}
+
+// Return the syntax highlighter of the buffer
+func (buffer *Buffer) GetHighlighter() *Highlighter {
+	return buffer.highlighter
+}
+
+// Return a bool value indicating if the buffer text contains unsaved changes
+func (buffer *Buffer) IsModified() bool {
+	return buffer.text.IsModified()
+}
+
+// Store the given cursor position, which will be restored after switching back to the buffer
+func (buffer *Buffer) StoreCursorPosition(xOffset int, yOffset int) {
+	buffer.xCursor = xOffset
+	buffer.yCursor = yOffset
+}
+
+// Return the x (horizontal) and y (vertical) offsets of the stored cursor position
+func (buffer *Buffer) GetCursorPosition() (int, int) {
+	return buffer.xCursor, buffer.yCursor
+}
+
+// Generate string from the buffer text and replace the content of the target file atomically (see WriteFileAtomically). The
+// modification state is reset
+func (buffer *Buffer) Save() error {
+	if !buffer.IsLoaded() {
+		return errors.New("buffer: the file is not loaded completely")
+	}
+
+	if buffer.IsReadOnly() {
+		return errors.New("buffer: the file is read-only")
+	}
+
+	if err := buffer.releaseLineSource(); err != nil {
+		return err
+	}
+
+	textContent, err := buffer.text.GetTextAsString()
+	if err != nil {
+		return err
+	}
+
+	if err := WriteFileAtomically(buffer.filePath, *textContent); err != nil {
+		return err
+	}
+
+	buffer.fileExists = true
+
+	return buffer.text.ResetModificationState()
+}
+
+// Save the buffer text as the given file and associate the file with the buffer. The read-only state caused by the permissions
+// of the previous file is dropped, but the read-only mode requested by the user is kept. The end-of-line sequence and the
+// highlighted language are detected for the new file
+func (buffer *Buffer) SaveAs(filePath string) error {
+	if len(filePath) <= 0 {
+		return errors.New("buffer: invalid path passed to save the buffer")
+	}
+
+	if !buffer.IsLoaded() {
+		return errors.New("buffer: the file is not loaded completely")
+	}
+
+	if _, err := os.Stat(filePath); err == nil && !isFileWritable(filePath) {
+		return fmt.Errorf("buffer: the file %s is not writable", filePath)
+	}
+
+	// NOTE: The target can be the file referenced by the lines (or a link to it), so the source is released the same way as before the regular save
+	if err := buffer.releaseLineSource(); err != nil {
+		return err
+	}
+
+	textContent, err := buffer.text.GetTextAsString()
+	if err != nil {
+		return err
+	}
+
+	if err := WriteFileAtomically(filePath, *textContent); err != nil {
+		return err
+	}
+
+	buffer.filePath = filePath
+	buffer.fileName = filepath.Base(buffer.filePath)
+	buffer.fileExists = true
+	buffer.writable = true
+
+	buffer.text.DetectEndOfLineSequence(*textContent)
+
+	if err := buffer.initHighlighter(); err != nil {
+		return err
+	}
+
+	return buffer.text.ResetModificationState()
+}
+
+// Helper function used to decode the lines referencing the file and close the file before the file is replaced by the save. The
+// open file would prevent the replacement on Windows, where the files are opened without the permission to be deleted
+func (buffer *Buffer) releaseLineSource() error {
+	if buffer.source == nil {
+		return nil
+	}
+
+	buffer.text.MaterializeLines()
+
+	source := buffer.source
+	buffer.source = nil
+
+	return source.Close()
+}
+
+// Helper function used to (re)initialize the syntax highlighter for the buffer file name. The highlighter instance is kept, so
+// the panes presenting the buffer are using the updated highlighter
+func (buffer *Buffer) initHighlighter() error {
+	// NOTE: The language is not detected if the syntax highlighting is disabled, so the highlighter is not producing any tokens
+	highlightedFileName := ""
+	if buffer.highlight {
+		highlightedFileName = buffer.fileName
+	}
+
+	return buffer.highlighter.Init(buffer.text, highlightedFileName)
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBufferShouldInitForNotExistingFile(t *testing.T) {
+	config := createBufferTestConfig()
+	filePath := filepath.Join(t.TempDir(), "file.go")
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.FileExists() {
+		t.Fail()
+	}
+
+	if buffer.GetFileName() != "file.go" {
+		t.Fail()
+	}
+
+	if buffer.GetHighlighter().GetLanguageName() != "Go" {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldNotInitForInvalidPath(t *testing.T) {
+	config := createBufferTestConfig()
+
+	buffer := new(Buffer)
+	if err := buffer.Init("", &config); err == nil {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldNotDetectLanguageIfSyntaxHighlightingIsDisabled(t *testing.T) {
+	config := createBufferTestConfig()
+	config.DisplayConfiguration.SyntaxHighlighting = false
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filepath.Join(t.TempDir(), "file.go"), &config); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.GetHighlighter().GetLanguageName() != "" {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldLoadAndSaveFile(t *testing.T) {
+	config := createBufferTestConfig()
+	config.TextConfiguration.UsePlatformSpecificEndOfLineSequence = false
+	filePath := filepath.Join(t.TempDir(), "file.txt")
+
+	if err := os.WriteFile(filePath, []byte("Hello\nWorld"), 0644); err != nil {
+		t.FailNow()
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if !buffer.FileExists() || buffer.IsModified() {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(5, 1, CreateConsoleMockup(), nil); err != nil {
+		t.FailNow()
+	}
+
+	if err := buffer.GetText().InsertCharacter('!', cursor); err != nil {
+		t.FailNow()
+	}
+
+	if !buffer.IsModified() {
+		t.Fail()
+	}
+
+	if err := buffer.Save(); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.IsModified() {
+		t.Fail()
+	}
+
+	fileData, err := os.ReadFile(filePath)
+	if err != nil {
+		t.FailNow()
+	}
+
+	if string(fileData) != "Hello\nWorld!" {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldStoreCursorPosition(t *testing.T) {
+	config := createBufferTestConfig()
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filepath.Join(t.TempDir(), "file.txt"), &config); err != nil {
+		t.FailNow()
+	}
+
+	buffer.StoreCursorPosition(3, 7)
+
+	if xOffset, yOffset := buffer.GetCursorPosition(); xOffset != 3 || yOffset != 7 {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldLoadLargeFileInBackground(t *testing.T) {
+	config := createBufferTestConfig()
+	config.TextConfiguration.UsePlatformSpecificEndOfLineSequence = false
+	config.TextConfiguration.LargeFileThresholdMegabytes = 1
+	filePath := filepath.Join(t.TempDir(), "file.txt")
+
+	fileContent := strings.Repeat("The quick brown fox jumps over the lazy dog\n", 40000)
+	if err := os.WriteFile(filePath, []byte(fileContent), 0644); err != nil {
+		t.FailNow()
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if !buffer.IsLoading() || buffer.IsLoaded() {
+		t.FailNow()
+	}
+
+	if buffer.GetText().GetLineCount() >= 40001 {
+		t.Fail()
+	}
+
+	if err := buffer.Save(); err == nil {
+		t.Fail()
+	}
+
+	notified := make(chan struct{}, 16)
+	if err := buffer.StartLoading(func() { notified <- struct{}{} }); err != nil {
+		t.FailNow()
+	}
+
+	for buffer.IsLoading() {
+		select {
+		case <-notified:
+		case <-time.After(5 * time.Second):
+			t.FailNow()
+		}
+
+		if _, err := buffer.ApplyLoadedLines(); err != nil {
+			t.FailNow()
+		}
+	}
+
+	if !buffer.IsLoaded() || buffer.IsModified() || buffer.GetLoadingProgress() != 100 {
+		t.Fail()
+	}
+
+	textContent, err := buffer.GetText().GetTextAsString()
+	if err != nil || *textContent != fileContent {
+		t.Fail()
+	}
+
+	if err := buffer.Close(); err != nil {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldSaveFileLoadedInBackground(t *testing.T) {
+	config := createBufferTestConfig()
+	config.TextConfiguration.UsePlatformSpecificEndOfLineSequence = false
+	config.TextConfiguration.LargeFileThresholdMegabytes = 1
+
+	fileContent := strings.Repeat("The quick brown fox jumps over the lazy dog\n", 40000)
+	filePath := createTestFile(t, "file.txt", fileContent)
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if err := buffer.StartLoading(nil); err != nil {
+		t.FailNow()
+	}
+
+	for buffer.IsLoading() {
+		if _, err := buffer.ApplyLoadedLines(); err != nil {
+			t.FailNow()
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if buffer.source == nil {
+		t.FailNow()
+	}
+
+	if err := buffer.Save(); err != nil {
+		t.FailNow()
+	}
+
+	// NOTE: The lines are decoded and the file is closed, so the file could be replaced on all platforms
+	if buffer.source != nil {
+		t.Fail()
+	}
+
+	fileData, err := os.ReadFile(filePath)
+	if err != nil || string(fileData) != fileContent {
+		t.Fail()
+	}
+
+	textContent, err := buffer.GetText().GetTextAsString()
+	if err != nil || *textContent != fileContent {
+		t.Fail()
+	}
+
+	if err := buffer.Close(); err != nil {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldStopLoadingOnClose(t *testing.T) {
+	config := createBufferTestConfig()
+	config.TextConfiguration.LargeFileThresholdMegabytes = 1
+	filePath := filepath.Join(t.TempDir(), "file.txt")
+
+	if err := os.WriteFile(filePath, []byte(strings.Repeat("The quick brown fox jumps over the lazy dog\n", 40000)), 0644); err != nil {
+		t.FailNow()
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if err := buffer.StartLoading(nil); err != nil {
+		t.FailNow()
+	}
+
+	if err := buffer.Close(); err != nil {
+		t.Fail()
+	}
+
+	if buffer.IsLoading() {
+		t.Fail()
+	}
+
+	if err := buffer.Close(); err != nil {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldLoadFileBelowThresholdAtOnce(t *testing.T) {
+	config := createBufferTestConfig()
+	filePath := filepath.Join(t.TempDir(), "file.txt")
+
+	if err := os.WriteFile(filePath, []byte(strings.Repeat("line\n", 1000)), 0644); err != nil {
+		t.FailNow()
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.IsLoading() || !buffer.IsLoaded() {
+		t.Fail()
+	}
+
+	if buffer.GetText().GetLineCount() != 1001 {
+		t.Fail()
+	}
+
+	if err := buffer.StartLoading(nil); err != nil {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldBeReadOnlyForNotWritableFile(t *testing.T) {
+	config := createBufferTestConfig()
+	filePath := filepath.Join(t.TempDir(), "file.txt")
+
+	if err := os.WriteFile(filePath, []byte("Hello"), 0444); err != nil {
+		t.FailNow()
+	}
+
+	// NOTE: The permissions are not restricting the privileged users
+	if isFileWritable(filePath) {
+		t.Skip("the file permissions are not enforced for the current user")
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if !buffer.IsReadOnly() {
+		t.Fail()
+	}
+
+	if err := buffer.Save(); err == nil {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldSaveReadOnlyBufferAsDifferentFile(t *testing.T) {
+	config := createBufferTestConfig()
+	config.TextConfiguration.UsePlatformSpecificEndOfLineSequence = false
+	directoryPath := t.TempDir()
+	filePath := filepath.Join(directoryPath, "file.txt")
+
+	if err := os.WriteFile(filePath, []byte("Hello"), 0644); err != nil {
+		t.FailNow()
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.IsReadOnly() {
+		t.Fail()
+	}
+
+	buffer.SetReadOnly(true)
+	if !buffer.IsReadOnly() {
+		t.Fail()
+	}
+
+	if err := buffer.Save(); err == nil {
+		t.Fail()
+	}
+
+	copyPath := filepath.Join(directoryPath, "copy.txt")
+	if err := buffer.SaveAs(copyPath); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.GetFilePath() != copyPath || buffer.GetFileName() != "copy.txt" {
+		t.Fail()
+	}
+
+	// NOTE: The read-only mode requested by the user is kept for the new file
+	if !buffer.IsReadOnly() {
+		t.Fail()
+	}
+
+	fileData, err := os.ReadFile(copyPath)
+	if err != nil || string(fileData) != "Hello" {
+		t.Fail()
+	}
+}
+
+func TestBufferShouldDetectFileTypeAfterSaveAs(t *testing.T) {
+	config := createBufferTestConfig()
+	config.TextConfiguration.UsePlatformSpecificEndOfLineSequence = false
+	directoryPath := t.TempDir()
+	filePath := filepath.Join(directoryPath, "file.txt")
+
+	if err := os.WriteFile(filePath, []byte("package main\r\n"), 0644); err != nil {
+		t.FailNow()
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.GetText().GetEndOfLineSequenceName() != "CRLF" || buffer.GetHighlighter().GetLanguageName() != "" {
+		t.Fail()
+	}
+
+	if err := buffer.SaveAs(filepath.Join(directoryPath, "main.go")); err != nil {
+		t.FailNow()
+	}
+
+	if buffer.GetText().GetEndOfLineSequenceName() != "LF" || buffer.GetHighlighter().GetLanguageName() != "Go" {
+		t.Fail()
+	}
+
+	if buffer.IsModified() || !buffer.FileExists() {
+		t.Fail()
+	}
+}
+
+func createBufferTestConfig() Config {
+	return Config{
+		HistoryConfiguration: CreateDefaultHistoryConfig(),
+		CursorConfiguration:  CreateDefaultCursorConfig(),
+		TextConfiguration:    CreateDefaultTextConfig(),
+		DisplayConfiguration: CreateDefaultDisplayConfig(),
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Contract abstraction for the clipboard used by the copy, cut and paste operations
+type Clipboard interface {
+	// Replace the content of the clipboard with the given text
+	SetContent(content string) error
+
+	// Return the current content of the clipboard
+	GetContent() (string, error)
+}
+
+// Create a new instance of the clipboard according to the configuration. If the system clipboard is requested,
+// but no supported clipboard utility is available, the internal clipboard is used instead
+func CreateClipboard(clipboardConfig *ClipboardConfig) Clipboard {
+	if clipboardConfig == nil {
+		defaultConfig := CreateDefaultClipboardConfig()
+		clipboardConfig = &defaultConfig
+	}
+
+	if clipboardConfig.UseSystemClipboard {
+		if clipboard, err := CreateClipboardSystem(); err == nil {
+			return clipboard
+		}
+	}
+
+	return CreateClipboardInternal()
+}
+
+// Structure implementing the clipboard contract with an in-memory buffer, which is available only inside the editor
+type ClipboardInternal struct {
+	content string
+}
+
+// Create a new instance of the in-memory clipboard
+func CreateClipboardInternal() Clipboard {
+	return &ClipboardInternal{
+		content: "",
+	}
+}
+
+func (clipboard *ClipboardInternal) SetContent(content string) error {
+	clipboard.content = content
+	return nil
+}
+
+func (clipboard *ClipboardInternal) GetContent() (string, error) {
+	return clipboard.content, nil
+}
+
+// Structure implementing the clipboard contract by executing the operating system specific clipboard utilities
+type ClipboardSystem struct {
+	copyCommand  []string
+	pasteCommand []string
+}
+
+// Create a new instance of the system clipboard. The function returns an error if no supported clipboard utility is available
+func CreateClipboardSystem() (Clipboard, error) {
+	type clipboardCommands struct {
+		copyCommand  []string
+		pasteCommand []string
+	}
+
+	var candidates []clipboardCommands
+
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = []clipboardCommands{
+			{[]string{"pbcopy"}, []string{"pbpaste"}},
+		}
+	case "windows":
+		candidates = []clipboardCommands{
+			{[]string{"clip.exe"}, []string{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard -Raw"}},
+		}
+	default:
+		candidates = []clipboardCommands{
+			{[]string{"wl-copy"}, []string{"wl-paste", "--no-newline"}},
+			{[]string{"xclip", "-selection", "clipboard", "-in"}, []string{"xclip", "-selection", "clipboard", "-out"}},
+			{[]string{"xsel", "--clipboard", "--input"}, []string{"xsel", "--clipboard", "--output"}},
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate.copyCommand[0]); err != nil {
+			continue
+		}
+
+		if _, err := exec.LookPath(candidate.pasteCommand[0]); err != nil {
+			continue
+		}
+
+		return &ClipboardSystem{
+			copyCommand:  candidate.copyCommand,
+			pasteCommand: candidate.pasteCommand,
+		}, nil
+	}
+
+	return nil, errors.New("clipboard: no supported system clipboard utility found")
+}
+
+func (clipboard *ClipboardSystem) SetContent(content string) error {
+	command := exec.Command(clipboard.copyCommand[0], clipboard.copyCommand[1:]...)
+	command.Stdin = strings.NewReader(content)
+
+	if err := command.Run(); err != nil {
+		return errors.New("clipboard: failed to write to the system clipboard")
+	}
+
+	return nil
+}
+
+func (clipboard *ClipboardSystem) GetContent() (string, error) {
+	command := exec.Command(clipboard.pasteCommand[0], clipboard.pasteCommand[1:]...)
+
+	output, err := command.Output()
+	if err != nil {
+		return "", errors.New("clipboard: failed to read from the system clipboard")
+	}
+
+	return string(output), nil
+}
+
+// A structure containing the configuration for the clipboard
+type ClipboardConfig struct {
+	UseSystemClipboard bool `json:"use-system-clipboard"`
+}
+
+// Return a new isntance of the clipboard configuration with default values
+func CreateDefaultClipboardConfig() ClipboardConfig {
+	return ClipboardConfig{
+		UseSystemClipboard: false,
+	}
+}
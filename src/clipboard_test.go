@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestClipboardShouldCreateInternalClipboardForDefaultConfig(t *testing.T) {
+	clipboard := CreateClipboard(nil)
+
+	if _, ok := clipboard.(*ClipboardInternal); !ok {
+		t.Fail()
+	}
+}
+
+func TestClipboardInternalShouldStoreContent(t *testing.T) {
+	clipboard := CreateClipboardInternal()
+
+	content, err := clipboard.GetContent()
+	if err != nil || content != "" {
+		t.Fail()
+	}
+
+	if err := clipboard.SetContent("Hello\nWorld!"); err != nil {
+		t.Fail()
+	}
+
+	content, err = clipboard.GetContent()
+	if err != nil || content != "Hello\nWorld!" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Type representing the kind of a console color
+type ColorKind int16
+
+const (
+	ColorDefault ColorKind = iota
+	ColorIndexed
+	ColorRGB
+)
+
+// Structure representing a console color. The indexed colors are referring to the 256-color palette (the first 16 colors are
+// the named ANSI colors) and the RGB colors are representing the true colors
+type Color struct {
+	Kind  ColorKind
+	Index int
+	Red   uint8
+	Green uint8
+	Blue  uint8
+}
+
+// The named ANSI colors and their 256-color palette indexes
+var namedColorIndexes = map[string]int{
+	"black":   0,
+	"maroon":  1,
+	"green":   2,
+	"olive":   3,
+	"navy":    4,
+	"purple":  5,
+	"teal":    6,
+	"silver":  7,
+	"gray":    8,
+	"grey":    8,
+	"red":     9,
+	"lime":    10,
+	"yellow":  11,
+	"blue":    12,
+	"fuchsia": 13,
+	"magenta": 13,
+	"aqua":    14,
+	"cyan":    14,
+	"white":   15,
+}
+
+// The RGB values of the first 16 colors of the 256-color palette
+var ansiColorValues = [16][3]uint8{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0}, {0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// The intensity levels of the 6x6x6 color cube of the 256-color palette
+var colorCubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// Parse the color string. Supported formats are: empty string or "default" (terminal default color), named ANSI colors
+// (e.g. "navy"), 256-color palette indexes (e.g. "color208" or "208") and hex RGB values (e.g. "#ff8800" or "#f80")
+func ParseColor(value string) (Color, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if len(value) == 0 || value == "default" {
+		return Color{Kind: ColorDefault}, nil
+	}
+
+	if index, ok := namedColorIndexes[value]; ok {
+		return Color{Kind: ColorIndexed, Index: index}, nil
+	}
+
+	if strings.HasPrefix(value, "#") {
+		return parseHexColor(value[1:])
+	}
+
+	index, err := strconv.Atoi(strings.TrimPrefix(value, "color"))
+	if err != nil || index < 0 || index > 255 {
+		return Color{}, errors.New("color: invalid color value")
+	}
+
+	return Color{Kind: ColorIndexed, Index: index}, nil
+}
+
+// Parse the color string and convert it to the nearest color supported by a terminal which is able to display the given count
+// of colors. Colors are not used (default color) for terminals with less than 8 colors
+func ResolveColor(value string, colorCount int) (Color, error) {
+	color, err := ParseColor(value)
+	if err != nil {
+		return Color{}, err
+	}
+
+	return color.Downgrade(colorCount), nil
+}
+
+// Return the nearest color which can be displayed by a terminal supporting the given count of colors
+func (color Color) Downgrade(colorCount int) Color {
+	if color.Kind == ColorDefault {
+		return color
+	}
+
+	if colorCount < 8 {
+		return Color{Kind: ColorDefault}
+	}
+
+	if color.Kind == ColorIndexed && color.Index < colorCount {
+		return color
+	}
+
+	if color.Kind == ColorRGB && colorCount >= 1<<24 {
+		return color
+	}
+
+	red, green, blue := color.GetRGB()
+
+	paletteSize := 256
+	if colorCount < 256 {
+		paletteSize = 16
+	}
+
+	if colorCount < 16 {
+		paletteSize = 8
+	}
+
+	return Color{Kind: ColorIndexed, Index: nearestPaletteIndex(red, green, blue, paletteSize)}
+}
+
+// Return the RGB values of the color. The default color is represented as black
+func (color Color) GetRGB() (uint8, uint8, uint8) {
+	switch color.Kind {
+	case ColorRGB:
+		return color.Red, color.Green, color.Blue
+	case ColorIndexed:
+		return paletteColorValue(color.Index)
+	default:
+		return 0, 0, 0
+	}
+}
+
+// Helper function used to parse the hex RGB value in the long (rrggbb) or short (rgb) format
+func parseHexColor(value string) (Color, error) {
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+
+	if len(value) != 6 {
+		return Color{}, errors.New("color: invalid hex color value")
+	}
+
+	rgb, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return Color{}, errors.New("color: invalid hex color value")
+	}
+
+	return Color{
+		Kind:  ColorRGB,
+		Red:   uint8(rgb >> 16),
+		Green: uint8(rgb >> 8),
+		Blue:  uint8(rgb),
+	}, nil
+}
+
+// Helper function used to return the RGB values of the color with the given 256-color palette index
+func paletteColorValue(index int) (uint8, uint8, uint8) {
+	if index < 16 {
+		value := ansiColorValues[index]
+		return value[0], value[1], value[2]
+	}
+
+	if index < 232 {
+		cubeIndex := index - 16
+		return colorCubeLevels[cubeIndex/36], colorCubeLevels[(cubeIndex/6)%6], colorCubeLevels[cubeIndex%6]
+	}
+
+	gray := uint8(8 + (index-232)*10)
+	return gray, gray, gray
+}
+
+// Helper function used to find the palette color (within the given palette size) with the smallest distance to the given RGB value
+func nearestPaletteIndex(red uint8, green uint8, blue uint8, paletteSize int) int {
+	nearestIndex := 0
+	nearestDistance := -1
+
+	for index := 0; index < paletteSize; index += 1 {
+		pRed, pGreen, pBlue := paletteColorValue(index)
+
+		dRed := int(red) - int(pRed)
+		dGreen := int(green) - int(pGreen)
+		dBlue := int(blue) - int(pBlue)
+
+		distance := dRed*dRed + dGreen*dGreen + dBlue*dBlue
+		if nearestDistance < 0 || distance < nearestDistance {
+			nearestIndex = index
+			nearestDistance = distance
+		}
+	}
+
+	return nearestIndex
+}
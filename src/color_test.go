@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+func TestColorShouldParseSupportedFormats(t *testing.T) {
+	color, err := ParseColor("navy")
+	if err != nil || color.Kind != ColorIndexed || color.Index != 4 {
+		t.Fail()
+	}
+
+	color, err = ParseColor("color208")
+	if err != nil || color.Kind != ColorIndexed || color.Index != 208 {
+		t.Fail()
+	}
+
+	color, err = ParseColor("#ff8000")
+	if err != nil || color.Kind != ColorRGB || color.Red != 255 || color.Green != 128 || color.Blue != 0 {
+		t.Fail()
+	}
+
+	color, err = ParseColor("#f80")
+	if err != nil || color.Kind != ColorRGB || color.Red != 255 || color.Green != 136 || color.Blue != 0 {
+		t.Fail()
+	}
+
+	color, err = ParseColor("")
+	if err != nil || color.Kind != ColorDefault {
+		t.Fail()
+	}
+}
+
+func TestColorShouldNotParseInvalidFormats(t *testing.T) {
+	invalidValues := []string{"unknown", "#12345", "#gggggg", "color256", "-1"}
+
+	for _, value := range invalidValues {
+		if _, err := ParseColor(value); err == nil {
+			t.Fail()
+		}
+	}
+}
+
+func TestColorShouldNotDowngradeForTrueColorTerminal(t *testing.T) {
+	color, err := ResolveColor("#123456", 1<<24)
+	if err != nil || color.Kind != ColorRGB {
+		t.Fail()
+	}
+}
+
+func TestColorShouldDowngradeToNearestPaletteColor(t *testing.T) {
+	color, err := ResolveColor("#ff0000", 256)
+	if err != nil || color.Kind != ColorIndexed || color.Index != 9 {
+		t.Fail()
+	}
+
+	color, err = ResolveColor("#870000", 256)
+	if err != nil || color.Kind != ColorIndexed || color.Index != 88 {
+		t.Fail()
+	}
+
+	color, err = ResolveColor("color88", 16)
+	if err != nil || color.Kind != ColorIndexed || color.Index != 1 {
+		t.Fail()
+	}
+
+	color, err = ResolveColor("yellow", 8)
+	if err != nil || color.Kind != ColorIndexed || color.Index != 3 {
+		t.Fail()
+	}
+}
+
+func TestColorShouldUseDefaultColorForMonochromeTerminal(t *testing.T) {
+	color, err := ResolveColor("#ff0000", 1)
+	if err != nil || color.Kind != ColorDefault {
+		t.Fail()
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Structure representing a named editor action. The handler returns a bool value indicating if the editor loop should be broken
+type Command struct {
+	name      string
+	title     string
+	handler   func() (bool, error)
+	sequences [][]ConsoleEventKeyPress
+}
+
+// Return the unique name of the command
+func (command *Command) GetName() string {
+	return command.name
+}
+
+// Return the human readable title of the command
+func (command *Command) GetTitle() string {
+	return command.title
+}
+
+// Return the text representation of the key sequences bound to the command (e.g. ,,Ctrl+N, Ctrl+K Ctrl+N”) or an empty string
+// if no key is bound to the command
+func (command *Command) GetKeybindText() string {
+	keys := make([]string, 0, len(command.sequences))
+	for _, sequence := range command.sequences {
+		keys = append(keys, FormatKeySequence(sequence))
+	}
+
+	return strings.Join(keys, ", ")
+}
+
+// Execute the command handler. The function returns a bool value indicating if the editor loop should be broken
+func (command *Command) Execute() (bool, error) {
+	return command.handler()
+}
+
+// Type representing the result of the key press dispatching
+type DispatchResult int16
+
+const (
+	// NOTE: The key press is not bound to any command and no chord is pending
+	DispatchUnbound DispatchResult = iota
+	DispatchCommand
+	DispatchPending
+	// NOTE: The key press is not continuing the pending chord, the whole sequence is dropped
+	DispatchInvalidChord
+)
+
+// Structure representing a node of the key sequence tree. The node is either bound to a command or is a prefix of longer
+// key sequences (chords)
+type keymapNode struct {
+	command  *Command
+	children map[ConsoleEventKeyPress]*keymapNode
+}
+
+// Structure representing the registry of the editor commands and the key sequences bound to them. The commands are listed in
+// the order of registration. The key presses are dispatched one by one, so the chords (e.g. [Ctrl] + [K] [Ctrl] + [C]) are
+// tracked between the key presses
+type Commands struct {
+	commands []*Command
+	names    map[string]*Command
+	root     *keymapNode
+	pending  *keymapNode
+	sequence []ConsoleEventKeyPress
+}
+
+// Commands registry structure initialization function
+func (commands *Commands) Init() error {
+	commands.commands = make([]*Command, 0)
+	commands.names = make(map[string]*Command)
+	commands.root = &keymapNode{children: make(map[ConsoleEventKeyPress]*keymapNode)}
+	commands.pending = nil
+	commands.sequence = make([]ConsoleEventKeyPress, 0)
+
+	return nil
+}
+
+// Register a new command with the given unique name, title and handler
+func (commands *Commands) Register(name string, title string, handler func() (bool, error)) error {
+	if len(name) <= 0 || len(title) <= 0 {
+		return errors.New("commands: invalid command name or title specified")
+	}
+
+	if handler == nil {
+		return errors.New("commands: invalid command handler specified")
+	}
+
+	if _, exists := commands.names[name]; exists {
+		return errors.New("commands: command with the given name is already registered")
+	}
+
+	command := &Command{name: name, title: title, handler: handler}
+
+	commands.commands = append(commands.commands, command)
+	commands.names[name] = command
+
+	return nil
+}
+
+// Bind the given key sequence to the command specified by the name. Every key sequence can be bound to a single command and
+// the key sequence can not be a prefix of a different bound key sequence
+func (commands *Commands) Bind(sequence []ConsoleEventKeyPress, name string) error {
+	command, exists := commands.names[name]
+	if !exists {
+		return fmt.Errorf("commands: can not bind the key to the unknown command: %s", name)
+	}
+
+	if len(sequence) == 0 {
+		return errors.New("commands: can not bind the empty key sequence")
+	}
+
+	normalizedSequence := make([]ConsoleEventKeyPress, 0, len(sequence))
+	for _, keyPress := range sequence {
+		normalizedSequence = append(normalizedSequence, normalizeKeyPress(keyPress))
+	}
+
+	node := commands.root
+	for index, keyPress := range normalizedSequence {
+		if node.command != nil {
+			return fmt.Errorf("commands: the key %s conflicts with the bound key %s", FormatKeySequence(normalizedSequence), FormatKeySequence(normalizedSequence[:index]))
+		}
+
+		child, exists := node.children[keyPress]
+		if !exists {
+			child = &keymapNode{children: make(map[ConsoleEventKeyPress]*keymapNode)}
+			node.children[keyPress] = child
+		}
+
+		node = child
+	}
+
+	if node.command != nil || len(node.children) > 0 {
+		return fmt.Errorf("commands: the key %s is already bound", FormatKeySequence(normalizedSequence))
+	}
+
+	node.command = command
+	command.sequences = append(command.sequences, normalizedSequence)
+
+	return nil
+}
+
+// Return the command specified by the name and a bool value indicating if the command is registered
+func (commands *Commands) Get(name string) (*Command, bool) {
+	command, exists := commands.names[name]
+	return command, exists
+}
+
+// Dispatch the given key press. The function returns the command bound to the key sequence ending with the key press (if any)
+// and the result indicating if the command was found, the key press is continuing a chord or the key press is not bound
+func (commands *Commands) Dispatch(keyPress ConsoleEventKeyPress) (*Command, DispatchResult) {
+	keyPress = normalizeKeyPress(keyPress)
+
+	node := commands.root
+	if commands.pending != nil {
+		node = commands.pending
+	} else {
+		commands.sequence = commands.sequence[:0]
+	}
+
+	commands.sequence = append(commands.sequence, keyPress)
+	chordPending := commands.pending != nil
+	commands.pending = nil
+
+	child, exists := node.children[keyPress]
+	if !exists {
+		if chordPending {
+			return nil, DispatchInvalidChord
+		}
+
+		return nil, DispatchUnbound
+	}
+
+	if child.command != nil {
+		return child.command, DispatchCommand
+	}
+
+	commands.pending = child
+	return nil, DispatchPending
+}
+
+// Return the text representation of the dispatched key sequence (the pending chord or the latest dispatched key sequence)
+func (commands *Commands) GetSequenceText() string {
+	return FormatKeySequence(commands.sequence)
+}
+
+// Return all registered commands in the order of registration
+func (commands *Commands) GetCommands() []*Command {
+	return commands.commands
+}
+
+// Execute the command specified by the name. The function returns a bool value indicating if the editor loop should be broken
+func (commands *Commands) Execute(name string) (bool, error) {
+	command, exists := commands.names[name]
+	if !exists {
+		return false, fmt.Errorf("commands: can not execute the unknown command: %s", name)
+	}
+
+	return command.Execute()
+}
+
+// NOTE: Names of the named keys used to present the keybinds
+var namedKeyNames = map[NamedKey]string{
+	KeyUp:        "Up",
+	KeyDown:      "Down",
+	KeyRight:     "Right",
+	KeyLeft:      "Left",
+	KeyPgUp:      "PgUp",
+	KeyPgDn:      "PgDn",
+	KeyHome:      "Home",
+	KeyEnd:       "End",
+	KeyInsert:    "Insert",
+	KeyDelete:    "Delete",
+	KeyPause:     "Pause",
+	KeyBacktab:   "Backtab",
+	KeyEnter:     "Enter",
+	KeyTab:       "Tab",
+	KeyEscape:    "Esc",
+	KeyBackspace: "Backspace",
+	KeyF1:        "F1",
+	KeyF2:        "F2",
+	KeyF3:        "F3",
+	KeyF4:        "F4",
+	KeyF5:        "F5",
+	KeyF6:        "F6",
+	KeyF7:        "F7",
+	KeyF8:        "F8",
+	KeyF9:        "F9",
+	KeyF10:       "F10",
+	KeyF11:       "F11",
+	KeyF12:       "F12",
+}
+
+// Return the text representation of the key press (e.g. ,,Ctrl+Shift+P”, ,,Alt+Left” or ,,F1”)
+func FormatKeyPress(keyPress ConsoleEventKeyPress) string {
+	builder := strings.Builder{}
+
+	if keyPress.Modifier&ModifierCtrl != 0 {
+		builder.WriteString("Ctrl+")
+	}
+
+	if keyPress.Modifier&ModifierAlt != 0 {
+		builder.WriteString("Alt+")
+	}
+
+	if keyPress.Modifier&ModifierShift != 0 {
+		builder.WriteString("Shift+")
+	}
+
+	if keyPress.Key == KeyPrintable {
+		switch keyPress.Char {
+		case ' ':
+			builder.WriteString("Space")
+		default:
+			builder.WriteRune(unicode.ToUpper(keyPress.Char))
+		}
+	} else {
+		builder.WriteString(namedKeyNames[keyPress.Key])
+	}
+
+	return builder.String()
+}
+
+// Return the text representation of the key sequence, the key presses are separated by spaces (e.g. ,,Ctrl+K Ctrl+C”)
+func FormatKeySequence(sequence []ConsoleEventKeyPress) string {
+	keys := make([]string, 0, len(sequence))
+	for _, keyPress := range sequence {
+		keys = append(keys, FormatKeyPress(keyPress))
+	}
+
+	return strings.Join(keys, " ")
+}
+
+// Helper function used to bring the key press to the form used as the binding key. The character is only relevant for the
+// printable keys and the letters are compared case insensitive
+func normalizeKeyPress(keyPress ConsoleEventKeyPress) ConsoleEventKeyPress {
+	if keyPress.Key != KeyPrintable {
+		keyPress.Char = 0
+	} else {
+		keyPress.Char = unicode.ToLower(keyPress.Char)
+	}
+
+	return keyPress
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommandsShouldRegisterAndExecuteCommands(t *testing.T) {
+	commands := new(Commands)
+	if err := commands.Init(); err != nil {
+		t.FailNow()
+	}
+
+	executed := false
+	if err := commands.Register("save", "Save", func() (bool, error) {
+		executed = true
+		return true, nil
+	}); err != nil {
+		t.FailNow()
+	}
+
+	breakLoop, err := commands.Execute("save")
+	if err != nil || !breakLoop || !executed {
+		t.Fail()
+	}
+
+	if _, err := commands.Execute("missing"); err == nil {
+		t.Fail()
+	}
+
+	if command, exists := commands.Get("save"); !exists || command.GetTitle() != "Save" {
+		t.Fail()
+	}
+
+	if len(commands.GetCommands()) != 1 {
+		t.Fail()
+	}
+}
+
+func TestCommandsShouldNotRegisterInvalidCommands(t *testing.T) {
+	commands := new(Commands)
+	if err := commands.Init(); err != nil {
+		t.FailNow()
+	}
+
+	handler := func() (bool, error) { return false, errors.New("handler") }
+
+	if err := commands.Register("", "Title", handler); err == nil {
+		t.Fail()
+	}
+
+	if err := commands.Register("name", "Title", nil); err == nil {
+		t.Fail()
+	}
+
+	if err := commands.Register("name", "Title", handler); err != nil {
+		t.FailNow()
+	}
+
+	if err := commands.Register("name", "Other", handler); err == nil {
+		t.Fail()
+	}
+}
+
+func TestCommandsShouldBindKeys(t *testing.T) {
+	commands := new(Commands)
+	if err := commands.Init(); err != nil {
+		t.FailNow()
+	}
+
+	handler := func() (bool, error) { return false, nil }
+	if err := commands.Register("next-buffer", "Next buffer", handler); err != nil {
+		t.FailNow()
+	}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{{Char: 'n', Key: KeyPrintable, Modifier: ModifierCtrl}}, "next-buffer"); err != nil {
+		t.FailNow()
+	}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{{Char: 'x', Key: KeyPgDn, Modifier: ModifierCtrl}}, "next-buffer"); err != nil {
+		t.FailNow()
+	}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{{Char: 'N', Key: KeyPrintable, Modifier: ModifierCtrl}}, "next-buffer"); err == nil {
+		t.Fail()
+	}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{{Key: KeyF1}}, "missing"); err == nil {
+		t.Fail()
+	}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{}, "next-buffer"); err == nil {
+		t.Fail()
+	}
+
+	command, result := commands.Dispatch(ConsoleEventKeyPress{Key: KeyPgDn, Modifier: ModifierCtrl})
+	if result != DispatchCommand || command.GetName() != "next-buffer" {
+		t.Fail()
+	}
+
+	if _, result := commands.Dispatch(ConsoleEventKeyPress{Key: KeyPgDn}); result != DispatchUnbound {
+		t.Fail()
+	}
+
+	if command.GetKeybindText() != "Ctrl+N, Ctrl+PgDn" {
+		t.Fail()
+	}
+}
+
+func TestCommandsShouldDispatchChords(t *testing.T) {
+	commands := new(Commands)
+	if err := commands.Init(); err != nil {
+		t.FailNow()
+	}
+
+	handler := func() (bool, error) { return false, nil }
+	if err := commands.Register("copy", "Copy", handler); err != nil {
+		t.FailNow()
+	}
+
+	ctrlK := ConsoleEventKeyPress{Char: 'k', Key: KeyPrintable, Modifier: ModifierCtrl}
+	ctrlC := ConsoleEventKeyPress{Char: 'c', Key: KeyPrintable, Modifier: ModifierCtrl}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{ctrlK, ctrlC}, "copy"); err != nil {
+		t.FailNow()
+	}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{ctrlK}, "copy"); err == nil {
+		t.Fail()
+	}
+
+	if err := commands.Bind([]ConsoleEventKeyPress{ctrlK, ctrlC, ctrlC}, "copy"); err == nil {
+		t.Fail()
+	}
+
+	if _, result := commands.Dispatch(ctrlK); result != DispatchPending || commands.GetSequenceText() != "Ctrl+K" {
+		t.Fail()
+	}
+
+	if command, result := commands.Dispatch(ctrlC); result != DispatchCommand || command.GetName() != "copy" {
+		t.Fail()
+	}
+
+	if _, result := commands.Dispatch(ctrlK); result != DispatchPending {
+		t.Fail()
+	}
+
+	if _, result := commands.Dispatch(ctrlK); result != DispatchInvalidChord || commands.GetSequenceText() != "Ctrl+K Ctrl+K" {
+		t.Fail()
+	}
+
+	if _, result := commands.Dispatch(ctrlC); result != DispatchUnbound {
+		t.Fail()
+	}
+}
+
+func TestCommandsShouldFormatKeyPress(t *testing.T) {
+	cases := map[string]ConsoleEventKeyPress{
+		"Ctrl+Shift+P": {Char: 'p', Key: KeyPrintable, Modifier: ModifierCtrl | ModifierShift},
+		"Alt+Left":     {Key: KeyLeft, Modifier: ModifierAlt},
+		"F1":           {Key: KeyF1},
+		"Ctrl+Space":   {Char: ' ', Key: KeyPrintable, Modifier: ModifierCtrl},
+	}
+
+	for expected, keyPress := range cases {
+		if FormatKeyPress(keyPress) != expected {
+			t.Fail()
+		}
+	}
+}
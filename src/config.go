@@ -13,16 +13,24 @@ const (
 // TODO: Application version specific version migration
 // Structure representig the configuration properties insinde the termpad-config.json file
 type Config struct {
-	HistoryConfiguration  HistoryConfig  `json:"history-configuration"`
-	KeybindsConfiguration KeybindsConfig `json:"keybinds-configuration"`
-	CursorConfiguration   CursorConfig   `json:"cursor-configuration"`
-	TextConfiguration     TextConfig     `json:"text-configuration"`
+	HistoryConfiguration   HistoryConfig   `json:"history-configuration"`
+	KeybindsConfiguration  KeybindsConfig  `json:"keybinds-configuration"`
+	CursorConfiguration    CursorConfig    `json:"cursor-configuration"`
+	TextConfiguration      TextConfig      `json:"text-configuration"`
+	ClipboardConfiguration ClipboardConfig `json:"clipboard-configuration"`
+	DisplayConfiguration   DisplayConfig   `json:"display-configuration"`
+	ThemeConfiguration     ThemeConfig     `json:"theme-configuration"`
 }
 
 // Config structure initialization function. The function is retriving the config file or creating a default one if not present
 func (config *Config) Init() error {
+	return config.initFromFile(configFilePath)
+}
+
+// Helper function used to retrieve the config from the given file or create the file with the default config if not present
+func (config *Config) initFromFile(filePath string) error {
 	var configFileExists bool
-	if _, err := os.Stat(configFilePath); err == nil {
+	if _, err := os.Stat(filePath); err == nil {
 		configFileExists = true
 	} else if errors.Is(err, os.ErrNotExist) {
 		configFileExists = false
@@ -30,9 +38,18 @@ func (config *Config) Init() error {
 		return errors.New("config: can not determine if the config file is accesable")
 	}
 
+	// NOTE: Default values are applied first, so properties missing in older config files are not left empty
+	config.HistoryConfiguration = CreateDefaultHistoryConfig()
+	config.KeybindsConfiguration = CreateDefaultKeybindsConfig()
+	config.CursorConfiguration = CreateDefaultCursorConfig()
+	config.TextConfiguration = CreateDefaultTextConfig()
+	config.ClipboardConfiguration = CreateDefaultClipboardConfig()
+	config.DisplayConfiguration = CreateDefaultDisplayConfig()
+	config.ThemeConfiguration = CreateDefaultThemeConfig()
+
 	// NOTE: Retrieve config from existing file
 	if configFileExists {
-		configFileData, err := os.ReadFile(configFilePath)
+		configFileData, err := os.ReadFile(filePath)
 		if err != nil {
 			return err
 		}
@@ -45,17 +62,12 @@ func (config *Config) Init() error {
 	}
 
 	// NOTE: Config file not found, creating config file with defaut values
-	config.HistoryConfiguration = CreateDefaultHistoryConfig()
-	config.KeybindsConfiguration = CreateDefaultKeybindsConfig()
-	config.CursorConfiguration = CreateDefaultCursorConfig()
-	config.TextConfiguration = CreateDefaultTextConfig()
-
 	jsonConfig, err := json.MarshalIndent(config, "", " ")
 	if err != nil {
 		return err
 	}
 
-	configFile, err := os.Create(configFilePath)
+	configFile, err := os.Create(filePath)
 	if err != nil {
 		return err
 	}
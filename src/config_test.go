@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigShouldLoadBaselineConfigFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "termpad-config.json")
+
+	// NOTE: The config file created by the first version of the editor, which is binding the exit to [Ctrl] + [X]
+	baselineConfig := `{
+ "history-configuration": {
+  "history-stack-size": 256
+ },
+ "keybinds-configuration": {
+  "keybind-save": "s",
+  "keybind-exit": "x"
+ },
+ "cursor-configuration": {
+  "cursor-style": "bar",
+  "use-animations": false
+ },
+ "text-configuration": {
+  "use-platform-specific-eol-sequence": true
+ }
+}`
+
+	if err := os.WriteFile(filePath, []byte(baselineConfig), 0644); err != nil {
+		t.FailNow()
+	}
+
+	config := new(Config)
+	if err := config.initFromFile(filePath); err != nil {
+		t.FailNow()
+	}
+
+	if config.DisplayConfiguration != CreateDefaultDisplayConfig() {
+		t.Fail()
+	}
+
+	keybinds := new(Keybinds)
+	if err := keybinds.Init(&config.KeybindsConfiguration); err != nil {
+		t.FailNow()
+	}
+
+	if keybinds.GetExitKeybind() != 'x' || keybinds.GetCutKeybind() != 'q' || keybinds.GetUndoKeybind() != 'z' {
+		t.Fail()
+	}
+
+	// NOTE: The exit keybind of the baseline config is the default one, so no default keybind is removed
+	if len(keybinds.GetWarnings()) != 0 {
+		t.Fail()
+	}
+}
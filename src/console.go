@@ -1,5 +1,10 @@
 package main
 
+import "errors"
+
+// NOTE: Error returned by the console suspending on the platforms without the job control
+var ErrSuspendNotSupported = errors.New("console: suspending is not supported on this platform")
+
 // Contract abstraction for the underlying console API
 type Console interface {
 	// Set a given character at given console position
@@ -23,6 +28,9 @@ type Console interface {
 	// Returns an event related to behavior or interaction with the console
 	WatchConsoleEvent() interface{}
 
+	// Wake up the event watching by delivering the interrupt event. The function is safe to call from other goroutines
+	Interrupt() error
+
 	// Return the x (width) of the console
 	GetWidth() int
 
@@ -35,7 +43,20 @@ type Console interface {
 	// Set the cursor style provided by the console
 	SetCursorStyle(cursorStyle CursorStyle) error
 
-	// Finalize the screen and release resources
+	// Enable or disable the reporting of the mouse events (clicks, drags and the wheel)
+	SetMouseEnabled(enabled bool) error
+
+	// Enter the alternate screen and place the terminal in the raw mode. The console content has to be redrawn afterwards
+	EnterAlternateScreen() error
+
+	// Leave the alternate screen and restore the original terminal content, cursor and mode
+	LeaveAlternateScreen() error
+
+	// Release the terminal and stop the program until it is resumed by the shell (SIGTSTP and SIGCONT). The terminal is
+	// re-acquired afterwards, so the console content has to be redrawn
+	Suspend() error
+
+	// Finalize the screen, restore the original terminal state and release resources. The function can be called multiple times
 	Dispose() error
 }
 
@@ -46,21 +67,62 @@ type ConsoleEventKeyPress struct {
 	Modifier ModifierKey
 }
 
+// Structure representing the paste console event. The whole pasted text is delivered as a single event (bracketed paste)
+type ConsoleEventPaste struct {
+	Text string
+}
+
+// Structure representing the interrupt event, delivered after the console was interrupted (e.g. by a background task
+// notifying about new results)
+type ConsoleEventInterrupt struct {
+}
+
+// Structure representing the mouse console event. The event is delivered when a button is pressed, moved while pressed
+// (dragged) or released (no buttons) and when the wheel is scrolled. The position is specified as the console position
+type ConsoleEventMouse struct {
+	X        int
+	Y        int
+	Buttons  MouseButton
+	Wheel    MouseWheel
+	Modifier ModifierKey
+}
+
 // Structure representing the display/console size change event
 type ConsoleEventResize struct {
 	Width  int
 	Height int
 }
 
-// Structure representing the style for a given character to print on the console
-// TODO: Add support for console-sepcific colors
+// Structure representing the style for a given character to print on the console. The colors are specified in one of the
+// formats supported by the ParseColor function and are converted to the colors supported by the console
 type CharacterStyle struct {
-	Bold          bool
-	Italic        bool
-	StrikeThrough bool
-	Underline     bool
-	Foreground    string
-	Background    string
+	Bold          bool   `json:"bold,omitempty"`
+	Italic        bool   `json:"italic,omitempty"`
+	StrikeThrough bool   `json:"strike-through,omitempty"`
+	Underline     bool   `json:"underline,omitempty"`
+	Foreground    string `json:"foreground,omitempty"`
+	Background    string `json:"background,omitempty"`
+}
+
+// Return the style created by applying the given style on top of the current style. The colors of the given style are used
+// if specified and the text attributes are combined
+func (characterStyle CharacterStyle) Merge(overlay CharacterStyle) CharacterStyle {
+	merged := characterStyle
+
+	if len(overlay.Foreground) > 0 {
+		merged.Foreground = overlay.Foreground
+	}
+
+	if len(overlay.Background) > 0 {
+		merged.Background = overlay.Background
+	}
+
+	merged.Bold = merged.Bold || overlay.Bold
+	merged.Italic = merged.Italic || overlay.Italic
+	merged.StrikeThrough = merged.StrikeThrough || overlay.StrikeThrough
+	merged.Underline = merged.Underline || overlay.Underline
+
+	return merged
 }
 
 // Type representing the console cursor style, that can be provided by the console API implementation
@@ -111,12 +173,35 @@ const (
 	KeyF12
 )
 
-// Type representing modifier keys, the first one, named none indicates that no modifiers were applied
+// Type representing modifier keys, the first one, named none indicates that no modifiers were applied. The modifiers
+// are bit flags, so combinations (e.g. [Ctrl] + [Shift]) can be represented as ModifierCtrl | ModifierShift
 type ModifierKey int16
 
 const (
-	ModifierNone ModifierKey = iota
-	ModifierShift
+	ModifierNone  ModifierKey = 0
+	ModifierShift ModifierKey = 1 << (iota - 1)
 	ModifierCtrl
 	ModifierAlt
 )
+
+// Type representing the mouse buttons pressed during the mouse event. The buttons are bit flags, so multiple pressed buttons
+// can be represented as MouseButtonPrimary | MouseButtonSecondary
+type MouseButton int16
+
+const (
+	MouseButtonNone    MouseButton = 0
+	MouseButtonPrimary MouseButton = 1 << (iota - 1)
+	MouseButtonSecondary
+	MouseButtonMiddle
+)
+
+// Type representing the direction of the mouse wheel scroll, the first one, named none indicates that the wheel was not scrolled
+type MouseWheel int16
+
+const (
+	MouseWheelNone MouseWheel = iota
+	MouseWheelUp
+	MouseWheelDown
+	MouseWheelLeft
+	MouseWheelRight
+)
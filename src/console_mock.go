@@ -1,17 +1,25 @@
 package main
 
+import "errors"
+
 const (
 	MockConsoleWidth  = 10
 	MockConsoleHeight = 10
 )
 
-// Structure implementing the console contract, used as a mockup for testing purposes
+// Structure implementing the console contract, used as a mockup for testing purposes. The alternate screen and dispose state
+// is tracked, similar to the Tcell based console
 type ConsoleMock struct {
+	alternateScreen bool
+	disposed        bool
 }
 
 // Create a new instance of the Tcell based console. The size of the console is 10x10
 func CreateConsoleMockup() Console {
-	return &ConsoleMock{}
+	return &ConsoleMock{
+		alternateScreen: true,
+		disposed:        false,
+	}
 }
 
 func (console *ConsoleMock) InsertCharacter(xIndex int, yIndex int, char rune) error {
@@ -42,6 +50,10 @@ func (console *ConsoleMock) WatchConsoleEvent() interface{} {
 	return nil
 }
 
+func (console *ConsoleMock) Interrupt() error {
+	return nil
+}
+
 func (console *ConsoleMock) GetWidth() int {
 	return MockConsoleWidth
 }
@@ -58,6 +70,30 @@ func (console *ConsoleMock) SetCursorStyle(cursorStyle CursorStyle) error {
 	return nil
 }
 
+func (console *ConsoleMock) SetMouseEnabled(enabled bool) error {
+	return nil
+}
+
+func (console *ConsoleMock) EnterAlternateScreen() error {
+	if console.disposed {
+		return errors.New("console: the console is disposed")
+	}
+
+	console.alternateScreen = true
+	return nil
+}
+
+func (console *ConsoleMock) LeaveAlternateScreen() error {
+	console.alternateScreen = false
+	return nil
+}
+
+func (console *ConsoleMock) Suspend() error {
+	return nil
+}
+
 func (console *ConsoleMock) Dispose() error {
+	console.alternateScreen = false
+	console.disposed = true
 	return nil
 }
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestConsoleMockShouldEnterAndLeaveAlternateScreen(t *testing.T) {
+	console := CreateConsoleMockup().(*ConsoleMock)
+
+	if !console.alternateScreen {
+		t.Fail()
+	}
+
+	for index := 0; index < 2; index += 1 {
+		if err := console.LeaveAlternateScreen(); err != nil || console.alternateScreen {
+			t.Fail()
+		}
+	}
+
+	for index := 0; index < 2; index += 1 {
+		if err := console.EnterAlternateScreen(); err != nil || !console.alternateScreen {
+			t.Fail()
+		}
+	}
+}
+
+func TestConsoleMockShouldAllowDisposingTwice(t *testing.T) {
+	console := CreateConsoleMockup().(*ConsoleMock)
+
+	if err := console.Dispose(); err != nil {
+		t.Fail()
+	}
+
+	if err := console.Dispose(); err != nil {
+		t.Fail()
+	}
+
+	if !console.disposed || console.alternateScreen {
+		t.Fail()
+	}
+
+	if err := console.EnterAlternateScreen(); err == nil {
+		t.Fail()
+	}
+}
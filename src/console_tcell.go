@@ -2,16 +2,25 @@ package main
 
 import (
 	"errors"
+	"strings"
+	"sync"
 
 	"github.com/gdamore/tcell/v2"
 )
 
 // Structure implementing the console contract based on the console API exposed by Tcell library
 type ConsoleTcell struct {
-	screen tcell.Screen
+	screen          tcell.Screen
+	pasteActive     bool
+	pasteBuffer     strings.Builder
+	cursorStyle     tcell.CursorStyle
+	alternateScreen bool
+	disposeOnce     sync.Once
+	mutex           sync.Mutex
 }
 
-// Create a new instance of the Tcell based console
+// Create a new instance of the Tcell based console. The console is entering the alternate screen, so the original content of the
+// terminal is restored after the console is disposed
 func CreateConsole() (Console, error) {
 	screen, err := tcell.NewScreen()
 	if err != nil {
@@ -22,18 +31,24 @@ func CreateConsole() (Console, error) {
 		return nil, err
 	}
 
+	// NOTE: The mouse is disabled by default, so the terminal native text selection is working unless the mouse is enabled by the config
 	screen.DisableMouse()
+	screen.EnablePaste()
 	screen.ShowCursor(0, 0)
 
-	console := ConsoleTcell{
-		screen: screen,
+	// NOTE: The Tcell screen initialization is placing the terminal in the raw mode and entering the alternate screen
+	console := &ConsoleTcell{
+		screen:          screen,
+		pasteActive:     false,
+		cursorStyle:     tcell.CursorStyleDefault,
+		alternateScreen: true,
 	}
 
 	if err := console.SetCursorStyle(BarCursorStatic); err != nil {
 		return nil, err
 	}
 
-	return &console, nil
+	return console, nil
 }
 
 func (console *ConsoleTcell) InsertCharacter(xIndex int, yIndex int, char rune) error {
@@ -65,11 +80,11 @@ func (console *ConsoleTcell) InsertCharacterWithStyle(xIndex int, yIndex int, ch
 		Underline(characterStyle.Underline)
 
 	if len(characterStyle.Foreground) > 0 {
-		style = style.Foreground(tcell.GetColor(characterStyle.Foreground))
+		style = style.Foreground(console.translateColor(characterStyle.Foreground))
 	}
 
 	if len(characterStyle.Background) > 0 {
-		style = style.Background(tcell.GetColor(characterStyle.Background))
+		style = style.Background(console.translateColor(characterStyle.Background))
 	}
 
 	console.screen.SetContent(xIndex, yIndex, char, nil, style)
@@ -107,8 +122,42 @@ func (console *ConsoleTcell) Clear() error {
 func (console *ConsoleTcell) WatchConsoleEvent() interface{} {
 	for {
 		switch event := console.screen.PollEvent().(type) {
+		case *tcell.EventPaste:
+			{
+				if event.Start() {
+					console.pasteActive = true
+					console.pasteBuffer.Reset()
+					continue
+				}
+
+				// NOTE: Normalizing the CRLF and CR line breaks to the LF line break
+				pastedText := strings.Replace(console.pasteBuffer.String(), "\r\n", "\n", -1)
+				pastedText = strings.Replace(pastedText, "\r", "\n", -1)
+
+				console.pasteActive = false
+				return ConsoleEventPaste{
+					Text: pastedText,
+				}
+			}
+
 		case *tcell.EventKey:
 			{
+				// NOTE: The keys pressed between the paste start and end are accumulated as the pasted text
+				if console.pasteActive {
+					switch event.Key() {
+					case tcell.KeyRune:
+						console.pasteBuffer.WriteRune(event.Rune())
+					case tcell.KeyCR:
+						console.pasteBuffer.WriteRune('\r')
+					case tcell.KeyLF:
+						console.pasteBuffer.WriteRune('\n')
+					case tcell.KeyTab:
+						console.pasteBuffer.WriteRune('\t')
+					}
+
+					continue
+				}
+
 				return ConsoleEventKeyPress{
 					Char:     console.translateCharacter(event),
 					Key:      console.translateNamedKey(event.Key()),
@@ -124,10 +173,34 @@ func (console *ConsoleTcell) WatchConsoleEvent() interface{} {
 					Height: height,
 				}
 			}
+
+		case *tcell.EventMouse:
+			{
+				x, y := event.Position()
+				return ConsoleEventMouse{
+					X:        x,
+					Y:        y,
+					Buttons:  console.translateMouseButtons(event.Buttons()),
+					Wheel:    console.translateMouseWheel(event.Buttons()),
+					Modifier: console.translateModifierKey(event.Modifiers()),
+				}
+			}
+
+		case *tcell.EventInterrupt:
+			return ConsoleEventInterrupt{}
 		}
 	}
 }
 
+func (console *ConsoleTcell) Interrupt() error {
+	// NOTE: The full event queue is already guaranteeing that the event watching will wake up
+	if err := console.screen.PostEvent(tcell.NewEventInterrupt(nil)); err != nil && !errors.Is(err, tcell.ErrEventQFull) {
+		return err
+	}
+
+	return nil
+}
+
 func (console *ConsoleTcell) GetWidth() int {
 	width, _ := console.screen.Size()
 
@@ -171,12 +244,90 @@ func (console *ConsoleTcell) SetCursorStyle(cursorStyle CursorStyle) error {
 		return errors.New("console: invalid internal cursor style")
 	}
 
+	console.cursorStyle = selectedCursorStyle
 	console.screen.SetCursorStyle(selectedCursorStyle)
 	return nil
 }
 
+func (console *ConsoleTcell) SetMouseEnabled(enabled bool) error {
+	if !enabled {
+		console.screen.DisableMouse()
+		return nil
+	}
+
+	// NOTE: The motion events without any pressed button are not reported, because they are not used by the editor
+	console.screen.EnableMouse(tcell.MouseButtonEvents, tcell.MouseDragEvents)
+	return nil
+}
+
+func (console *ConsoleTcell) EnterAlternateScreen() error {
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+
+	if console.alternateScreen {
+		return nil
+	}
+
+	if err := console.screen.Resume(); err != nil {
+		return err
+	}
+
+	console.alternateScreen = true
+	console.screen.SetCursorStyle(console.cursorStyle)
+
+	// NOTE: The terminal content is cleared on resume, so all cells are redrawn on the next commit
+	console.screen.Sync()
+	return nil
+}
+
+func (console *ConsoleTcell) LeaveAlternateScreen() error {
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+
+	if !console.alternateScreen {
+		return nil
+	}
+
+	// NOTE: The style of the cursor is not part of the restored terminal state, so the default style is applied before leaving
+	console.screen.SetCursorStyle(tcell.CursorStyleDefault)
+	console.screen.Show()
+
+	if err := console.screen.Suspend(); err != nil {
+		return err
+	}
+
+	console.alternateScreen = false
+	return nil
+}
+
+func (console *ConsoleTcell) Suspend() error {
+	if err := console.LeaveAlternateScreen(); err != nil {
+		return err
+	}
+
+	// NOTE: The terminal is re-acquired even if the process could not be stopped
+	suspendErr := suspendProcess()
+
+	if err := console.EnterAlternateScreen(); err != nil {
+		return err
+	}
+
+	return suspendErr
+}
+
 func (console *ConsoleTcell) Dispose() error {
-	console.screen.Fini()
+	// NOTE: The console can be disposed from multiple places (e.g. the signal handler), but the screen is finalized only once
+	console.disposeOnce.Do(func() {
+		console.mutex.Lock()
+		defer console.mutex.Unlock()
+
+		console.screen.DisablePaste()
+		console.screen.SetCursorStyle(tcell.CursorStyleDefault)
+		console.screen.Show()
+		console.screen.Fini()
+		console.alternateScreen = false
+	})
+
 	return nil
 }
 
@@ -300,7 +451,7 @@ func (console *ConsoleTcell) translateNamedKey(key tcell.Key) NamedKey {
 		return KeyTab
 	case tcell.KeyESC:
 		return KeyEscape
-	case tcell.KeyBS:
+	case tcell.KeyBS, tcell.KeyBackspace2:
 		return KeyBackspace
 	case tcell.KeyF1:
 		return KeyF1
@@ -333,14 +484,72 @@ func (console *ConsoleTcell) translateNamedKey(key tcell.Key) NamedKey {
 
 // Helper funcation used for converting implementation specific to contract specific modifier key representation
 func (console *ConsoleTcell) translateModifierKey(mod tcell.ModMask) ModifierKey {
-	switch mod {
-	case tcell.ModShift:
-		return ModifierShift
-	case tcell.ModCtrl:
-		return ModifierCtrl
-	case tcell.ModAlt:
-		return ModifierAlt
+	modifier := ModifierNone
+
+	if mod&tcell.ModShift != 0 {
+		modifier |= ModifierShift
+	}
+
+	if mod&tcell.ModCtrl != 0 {
+		modifier |= ModifierCtrl
+	}
+
+	if mod&tcell.ModAlt != 0 {
+		modifier |= ModifierAlt
+	}
+
+	return modifier
+}
+
+// Helper function used for converting implementation specific to contract specific mouse buttons representation
+func (console *ConsoleTcell) translateMouseButtons(buttons tcell.ButtonMask) MouseButton {
+	mouseButton := MouseButtonNone
+
+	if buttons&tcell.ButtonPrimary != 0 {
+		mouseButton |= MouseButtonPrimary
+	}
+
+	if buttons&tcell.ButtonSecondary != 0 {
+		mouseButton |= MouseButtonSecondary
+	}
+
+	if buttons&tcell.ButtonMiddle != 0 {
+		mouseButton |= MouseButtonMiddle
+	}
+
+	return mouseButton
+}
+
+// Helper function used for converting implementation specific to contract specific mouse wheel representation
+func (console *ConsoleTcell) translateMouseWheel(buttons tcell.ButtonMask) MouseWheel {
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		return MouseWheelUp
+	case buttons&tcell.WheelDown != 0:
+		return MouseWheelDown
+	case buttons&tcell.WheelLeft != 0:
+		return MouseWheelLeft
+	case buttons&tcell.WheelRight != 0:
+		return MouseWheelRight
+	default:
+		return MouseWheelNone
+	}
+}
+
+// Helper function used to translate the color string to the tcell color. The color is converted to the nearest color supported
+// by the terminal and invalid colors are replaced with the default color
+func (console *ConsoleTcell) translateColor(value string) tcell.Color {
+	color, err := ResolveColor(value, console.screen.Colors())
+	if err != nil {
+		return tcell.ColorDefault
+	}
+
+	switch color.Kind {
+	case ColorIndexed:
+		return tcell.PaletteColor(color.Index)
+	case ColorRGB:
+		return tcell.NewRGBColor(int32(color.Red), int32(color.Green), int32(color.Blue))
 	default:
-		return ModifierNone
+		return tcell.ColorDefault
 	}
 }
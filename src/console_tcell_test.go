@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestConsoleTcellShouldDeliverPastedTextAsSingleEvent(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.FailNow()
+	}
+
+	defer screen.Fini()
+
+	console := &ConsoleTcell{
+		screen:      screen,
+		pasteActive: false,
+	}
+
+	events := []tcell.Event{
+		tcell.NewEventPaste(true),
+		tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone),
+		tcell.NewEventPaste(false),
+		tcell.NewEventInterrupt(nil),
+	}
+
+	for _, event := range events {
+		if err := screen.PostEvent(event); err != nil {
+			t.FailNow()
+		}
+	}
+
+	pastedTexts := make([]string, 0)
+
+	// NOTE: The interrupt is posted after the paste, so all events produced by the paste are watched before the interrupt
+	for {
+		event := console.WatchConsoleEvent()
+		if _, ok := event.(ConsoleEventInterrupt); ok {
+			break
+		}
+
+		switch event := event.(type) {
+		case ConsoleEventPaste:
+			pastedTexts = append(pastedTexts, event.Text)
+		case ConsoleEventKeyPress:
+			t.Fail()
+		}
+	}
+
+	if len(pastedTexts) != 1 || pastedTexts[0] != "ab\n\tc" {
+		t.Fail()
+	}
+}
@@ -1,6 +1,9 @@
 package main
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
 // TODO: The paddingFalback is indicating if the padding is greater than the size. The logical to handle such
 // situation can be implemented later (during widgets implementation)
@@ -15,13 +18,35 @@ type Display struct {
 	yCalculatedBoundary int
 	paddingFallback     bool
 	padding             *Padding
+	gutterWidth         int
+	wrapEnabled         bool
+	ySegmentBoundary    int
+	text                *Text
 	cursor              *Cursor
+	selection           *Selection
+	search              *Search
+	highlighter         *Highlighter
+	theme               *Theme
 	console             Console
+	config              *DisplayConfig
 }
 
 // Display structure initialization function
-func (display *Display) Init(cursor *Cursor, padding *Padding, console Console) error {
+func (display *Display) Init(cursor *Cursor, padding *Padding, console Console, displayConfig *DisplayConfig) error {
+	if displayConfig == nil {
+		defaultConfig := CreateDefaultDisplayConfig()
+		display.config = &defaultConfig
+	} else {
+		display.config = displayConfig
+	}
+
+	defaultTheme := CreateDefaultTheme()
+	display.theme = &defaultTheme
+
 	display.xCalculatedBoundary = 0
+	display.ySegmentBoundary = 0
+	display.gutterWidth = 0
+	display.wrapEnabled = display.config.SoftWrap
 	display.yCalculatedBoundary = 0
 
 	pTop := 0
@@ -88,14 +113,22 @@ func (display *Display) Resize(width int, height int) error {
 
 // Function is used to recalculate the boundaries based on the cursor position and current display size
 func (display *Display) RecalculateBoundaries() error {
+	if display.isWrapActive() {
+		return display.recalculateWrappedBoundaries()
+	}
+
 	xOffset := display.cursor.GetOffsetX()
 	yOffset := display.cursor.GetOffsetY()
 
 	display.xCalculatedBoundary = 0
 	display.yCalculatedBoundary = 0
+	display.ySegmentBoundary = 0
+
+	// NOTE: The horizontal boundary is calculated for the width available for the text (e.g. without the line number gutter)
+	textWidth, _ := display.GetTextDisplaySize()
 
 	// NOTE: Right side overflow
-	for xOffset+1 >= display.width+display.xCalculatedBoundary {
+	for xOffset+1 >= textWidth+display.xCalculatedBoundary {
 		display.xCalculatedBoundary += 1
 	}
 
@@ -105,7 +138,7 @@ func (display *Display) RecalculateBoundaries() error {
 	}
 
 	// NOTE: Top side overflow
-	for yOffset+1 >= display.height+display.yCalculatedBoundary {
+	for yOffset >= display.getCursorRowCount()+display.yCalculatedBoundary {
 		display.yCalculatedBoundary += 1
 	}
 
@@ -117,6 +150,52 @@ func (display *Display) RecalculateBoundaries() error {
 	return nil
 }
 
+// Function is used to recalculate the boundaries and vertically center the viewport at the cursor position (e.g. after a jump)
+func (display *Display) CenterBoundaries() error {
+	if err := display.RecalculateBoundaries(); err != nil {
+		return err
+	}
+
+	_, textHeight := display.GetTextDisplaySize()
+
+	if display.isWrapActive() {
+		yCursor, sCursor, err := display.getCursorVisualRow()
+		if err != nil {
+			return err
+		}
+
+		display.yCalculatedBoundary, display.ySegmentBoundary, _, err = display.stepVisualRows(yCursor, sCursor, -(textHeight / 2))
+		return err
+	}
+
+	yCenteredBoundary := display.cursor.GetOffsetY() - textHeight/2
+	if yCenteredBoundary < 0 {
+		yCenteredBoundary = 0
+	}
+
+	// NOTE: The centered boundary is only applied if it is keeping the cursor inside the viewport
+	if yCenteredBoundary < display.yCalculatedBoundary || display.cursor.GetOffsetY() >= yCenteredBoundary+textHeight {
+		return nil
+	}
+
+	display.yCalculatedBoundary = yCenteredBoundary
+	return nil
+}
+
+// Replace the display padding, which specifies the area of the console used by the display (e.g. a pane of a split). The
+// boundaries are recalculated for the new area
+func (display *Display) SetPadding(padding *Padding) error {
+	if padding == nil {
+		return errors.New("display: invalid padding struct reference")
+	}
+
+	if err := display.padding.Init(padding.GetTopPadding(), padding.GetBottomPadding(), padding.GetLeftPadding(), padding.GetRightPadding()); err != nil {
+		return err
+	}
+
+	return display.Resize(display.width, display.height)
+}
+
 // Return a bool value indicating whether the console size specified by the given width and height has changed (not the size of the display)
 func (display *Display) HasSizeChanged(width int, height int) bool {
 	if display.width != width {
@@ -130,6 +209,17 @@ func (display *Display) HasSizeChanged(width int, height int) bool {
 	return false
 }
 
+// Helper function used to calculate the count of rows in which the cursor can be placed without shifting the boundaries. The last
+// text row is kept as a margin if the display has no bottom padding
+func (display *Display) getCursorRowCount() int {
+	_, textHeight := display.GetTextDisplaySize()
+	if display.padding.GetBottomPadding() == 0 {
+		return textHeight - 1
+	}
+
+	return textHeight
+}
+
 // Return the full width and height of the display, which is the raw size deriving from the underlying console API
 func (display *Display) GetFullDisplaySize() (int, int) {
 	return display.width, display.height
@@ -137,7 +227,7 @@ func (display *Display) GetFullDisplaySize() (int, int) {
 
 // Return the width and height provided for the text. The sizes are affected by the specified display padding
 func (display *Display) GetTextDisplaySize() (int, int) {
-	width := display.width - display.padding.GetLeftPadding() - display.padding.GetRightPadding()
+	width := display.width - display.GetXLeftOffsetPadding() - display.padding.GetRightPadding()
 	height := display.height - display.padding.GetTopPadding() - display.padding.GetBottomPadding()
 
 	return width, height
@@ -145,12 +235,12 @@ func (display *Display) GetTextDisplaySize() (int, int) {
 
 // Return the x (horizontal) display padding (left and right), specified on display initialization
 func (display *Display) GetXOffsetPadding() int {
-	return display.padding.GetLeftPadding() + display.padding.GetRightPadding()
+	return display.GetXLeftOffsetPadding() + display.padding.GetRightPadding()
 }
 
-// Return the left x (horizontal) display padding, specified on display initialization
+// Return the left x (horizontal) display padding, specified on display initialization and extended by the line number gutter
 func (display *Display) GetXLeftOffsetPadding() int {
-	return display.padding.GetLeftPadding()
+	return display.padding.GetLeftPadding() + display.gutterWidth
 }
 
 // Return the right x (horizontal) display padding, specified on display initialization
@@ -185,11 +275,18 @@ func (display *Display) GetYOffsetShift() int {
 
 // Return a bool value indicating whether the cursor is currenlty ,,visible'' according to the offsets (boundaries)
 func (display *Display) CursorInBoundries() bool {
+	if display.isWrapActive() {
+		inBoundaries, _, _, err := display.wrappedCursorInBoundaries()
+		return err == nil && inBoundaries
+	}
+
 	xOffset := display.cursor.GetOffsetX()
 	yOffset := display.cursor.GetOffsetY()
 
+	textWidth, _ := display.GetTextDisplaySize()
+
 	// NOTE: Right side overflow
-	if xOffset+1 >= display.width+display.xCalculatedBoundary {
+	if xOffset+1 >= textWidth+display.xCalculatedBoundary {
 		return false
 	}
 
@@ -199,7 +296,7 @@ func (display *Display) CursorInBoundries() bool {
 	}
 
 	// NOTE: Top side overflow
-	if yOffset+1 >= display.height+display.yCalculatedBoundary {
+	if yOffset >= display.getCursorRowCount()+display.yCalculatedBoundary {
 		return false
 	}
 
@@ -211,10 +308,143 @@ func (display *Display) CursorInBoundries() bool {
 	return true
 }
 
+// Return the text offsets (x, y) of the text position presented at the given console position. The rows above and below the
+// display are mapped to the lines outside the boundaries (e.g. while dragging the selection). The offsets are limited to the text,
+// so the positions after the line end are mapped to the line end and the positions after the last line to the text end
+func (display *Display) GetTextOffsetsByConsolePosition(xConsole int, yConsole int) (int, int, error) {
+	if display.text == nil {
+		return 0, 0, errors.New("display: no text attached to the display")
+	}
+
+	// NOTE: The positions on the left side of the text (e.g. the line number gutter) are mapped to the first displayed column
+	xColumn := xConsole - display.GetXLeftOffsetPadding()
+	if xColumn < 0 {
+		xColumn = 0
+	}
+
+	yRow := yConsole - display.padding.GetTopPadding()
+
+	if display.isWrapActive() {
+		return display.getWrappedTextOffsets(xColumn, yRow)
+	}
+
+	yOffset := yRow + display.yCalculatedBoundary
+	if yOffset < 0 {
+		return 0, 0, nil
+	}
+
+	if yOffset >= display.text.GetLineCount() {
+		return display.getTextEndOffsets()
+	}
+
+	xLength, err := display.text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	xOffset := xColumn + display.xCalculatedBoundary
+	if xOffset > xLength {
+		xOffset = xLength
+	}
+
+	return xOffset, yOffset, nil
+}
+
+// Move the boundaries by the given count of rows (negative values are scrolling up) without moving the cursor. The scrolling
+// is stopped when the first line is displayed at the top or the last line is displayed at the top. The boundaries are
+// recalculated on the next cursor movement, so the cursor is brought back into view. Returns a bool value indicating if the
+// boundaries have changed
+func (display *Display) ScrollBoundaries(rowShift int) (bool, error) {
+	if display.text == nil {
+		return false, errors.New("display: no text attached to the display")
+	}
+
+	lineCount := display.text.GetLineCount()
+
+	if display.isWrapActive() {
+		yBoundary, sBoundary, err := display.getWrappedBoundaries()
+		if err != nil {
+			return false, err
+		}
+
+		yTarget, sTarget, _, err := display.stepVisualRows(yBoundary, sBoundary, rowShift)
+		if err != nil {
+			return false, err
+		}
+
+		changed := yTarget != display.yCalculatedBoundary || sTarget != display.ySegmentBoundary
+
+		display.yCalculatedBoundary = yTarget
+		display.ySegmentBoundary = sTarget
+
+		return changed, nil
+	}
+
+	yTarget := display.yCalculatedBoundary + rowShift
+	if yTarget > lineCount-1 {
+		yTarget = lineCount - 1
+	}
+
+	if yTarget < 0 {
+		yTarget = 0
+	}
+
+	if yTarget == display.yCalculatedBoundary {
+		return false, nil
+	}
+
+	display.yCalculatedBoundary = yTarget
+	return true, nil
+}
+
+// Move the boundaries, so the line specified by the y (vertical) offset is displayed at the top without moving the cursor. The
+// offset is limited to the text. The first segment of the line is displayed at the top if the line is wrapped
+func (display *Display) SetYOffsetShift(yOffset int) error {
+	if display.text == nil {
+		return errors.New("display: no text attached to the display")
+	}
+
+	if yOffset > display.text.GetLineCount()-1 {
+		yOffset = display.text.GetLineCount() - 1
+	}
+
+	if yOffset < 0 {
+		yOffset = 0
+	}
+
+	if yOffset != display.yCalculatedBoundary {
+		display.yCalculatedBoundary = yOffset
+		display.ySegmentBoundary = 0
+	}
+
+	return nil
+}
+
 // Request a render of all changes to the screen of the underlying console API
 func (display *Display) RenderChanges() error {
-	xDiff := display.xCalculatedBoundary
-	yDiff := display.yCalculatedBoundary
+	// NOTE: The cursor can be scrolled out of the viewport (e.g. with the mouse wheel), so the console cursor is placed outside
+	// of the console which is hiding the cursor
+	if !display.cursorInTextArea() {
+		if err := display.console.SetCursorPosition(-1, -1); err != nil {
+			return err
+		}
+
+		return display.console.Commit()
+	}
+
+	// NOTE: The difference between the text and console position is including the left and top padding
+	xDiff := display.xCalculatedBoundary - display.GetXLeftOffsetPadding()
+	yDiff := display.yCalculatedBoundary - display.padding.GetTopPadding()
+
+	if display.isWrapActive() {
+		xConsole, yConsole, err := display.getWrappedCursorConsolePosition()
+		if err != nil {
+			return err
+		}
+
+		xDiff = display.cursor.GetOffsetX() - xConsole
+		yDiff = display.cursor.GetOffsetY() - yConsole
+	}
 
 	if err := display.cursor.CorrectUnderlyingConsolePositionDifference(xDiff, yDiff); err != nil {
 		return err
@@ -223,169 +453,985 @@ func (display *Display) RenderChanges() error {
 	return display.console.Commit()
 }
 
+// Helper function used to check if the cursor position is presented inside the text area of the display. In contrast to the
+// boundaries check, the rows and columns kept as the scrolling margin are treated as a part of the text area
+func (display *Display) cursorInTextArea() bool {
+	if display.isWrapActive() {
+		return display.CursorInBoundries()
+	}
+
+	xConsole := display.cursor.GetOffsetX() - display.xCalculatedBoundary + display.GetXLeftOffsetPadding()
+	yConsole := display.cursor.GetOffsetY() - display.yCalculatedBoundary + display.padding.GetTopPadding()
+
+	if xConsole < display.GetXLeftOffsetPadding() || xConsole >= display.width-display.padding.GetRightPadding() {
+		return false
+	}
+
+	if yConsole < display.padding.GetTopPadding() || yConsole >= display.height-display.padding.GetBottomPadding() {
+		return false
+	}
+
+	return true
+}
+
 // Function is rewriting text changes to the underlying console API screen, according to the display boundaries. All lines are affected
-// TODO: The tHeight can be greater than dHeight - we can avoid redundand off-display rendering. We can implement this by adding range
-// based functions in the display struct
 func (display *Display) RedrawTextFull(text *Text) error {
-	yTextLength := text.GetLineCount()
+	if _, err := display.applyGutterWidth(text); err != nil {
+		return err
+	}
+
+	if display.isWrapActive() {
+		return display.redrawWrappedText(text)
+	}
 
-	xlPadding := display.padding.GetLeftPadding()
-	xrPadding := display.padding.GetRightPadding()
 	ytPadding := display.padding.GetTopPadding()
 	ybPadding := display.padding.GetBottomPadding()
 
 	for ycIndex := ytPadding; ycIndex < display.height-ybPadding; ycIndex += 1 {
-		ytIndex := ycIndex + display.yCalculatedBoundary
+		if err := display.redrawTextRow(text, ycIndex); err != nil {
+			return err
+		}
+	}
 
-		if ytIndex < yTextLength {
-			xtLength, err := text.GetLineLengthByOffset(ytIndex)
-			if err != nil {
-				return err
-			}
+	return nil
+}
+
+// Function is rewriting text changes to the underlying console API screen, according to the display boundaries. Only the line specified by the cursor is affected.
+func (display *Display) RedrawTextLine(text *Text, fullRedrawFallback bool) error {
+	if !display.CursorInBoundries() && fullRedrawFallback {
+		return display.RedrawTextFull(text)
+	}
 
-			for xcIndex := xlPadding; xcIndex < display.width-xrPadding; xcIndex += 1 {
-				xtIndex := xcIndex + display.xCalculatedBoundary
+	// NOTE: The change of a wrapped line can change the count of its visual rows, so all rows are affected
+	if display.isWrapActive() {
+		return display.RedrawTextFull(text)
+	}
 
-				var char rune = ' '
+	// NOTE: The change of the gutter width is shifting all lines
+	if changed, err := display.applyGutterWidth(text); err != nil || changed {
+		if err != nil {
+			return err
+		}
 
-				if xtIndex < xtLength {
-					char, err = text.GetCharacterByOffsets(xtIndex, ytIndex)
-					if err != nil {
-						return err
-					}
-				}
+		return display.RedrawTextFull(text)
+	}
 
-				if err := display.console.InsertCharacter(xcIndex, ycIndex, char); err != nil {
-					return err
-				}
-			}
+	ycOffset := display.cursor.GetOffsetY() - display.yCalculatedBoundary + display.padding.GetTopPadding()
 
-			continue
+	return display.redrawTextRow(text, ycOffset)
+}
+
+// Function is rewriting text changes to the underlying console API screen, according to the display boundaries. All lines (including the current) below the cursor are affected.
+func (display *Display) RedrawTextBelow(text *Text, fullRedrawFallback bool) error {
+	if !display.CursorInBoundries() && fullRedrawFallback {
+		return display.RedrawTextFull(text)
+	}
+
+	if display.isWrapActive() {
+		return display.RedrawTextFull(text)
+	}
+
+	if changed, err := display.applyGutterWidth(text); err != nil || changed {
+		if err != nil {
+			return err
 		}
 
-		for xcIndex := xlPadding; xcIndex < display.width-xrPadding; xcIndex += 1 {
-			if err := display.console.InsertCharacter(xcIndex, ycIndex, ' '); err != nil {
-				return err
-			}
+		return display.RedrawTextFull(text)
+	}
+
+	ybPadding := display.padding.GetBottomPadding()
+
+	for ycIndex := display.cursor.GetOffsetY() - display.yCalculatedBoundary + display.padding.GetTopPadding(); ycIndex < display.height-ybPadding; ycIndex += 1 {
+		if err := display.redrawTextRow(text, ycIndex); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// Function is rewriting text changes to the underlying console API screen, according to the display boundaries. Only the line specified by the cursor is affected.
-func (display *Display) RedrawTextLine(text *Text, fullRedrawFallback bool) error {
-	if !display.CursorInBoundries() && fullRedrawFallback {
-		return display.RedrawTextFull(text)
+// Attach the selection structure which will be used to highlight the selected characters
+func (display *Display) AttachSelection(selection *Selection) error {
+	if selection == nil {
+		return errors.New("display: invalid selection struct reference")
 	}
 
-	ytOffset := display.cursor.GetOffsetY()
-	ycOffset := ytOffset - display.yCalculatedBoundary
+	display.selection = selection
+	return nil
+}
 
-	tWidth, err := text.GetLineLengthByOffset(ytOffset)
-	if err != nil {
+// Attach the search structure which will be used to highlight the search matches
+func (display *Display) AttachSearch(search *Search) error {
+	if search == nil {
+		return errors.New("display: invalid search struct reference")
+	}
+
+	display.search = search
+	return nil
+}
+
+// Helper function used to rewrite a single console row with the corresponding text line, according to the display boundaries
+func (display *Display) redrawTextRow(text *Text, ycIndex int) error {
+	ytIndex := ycIndex - display.padding.GetTopPadding() + display.yCalculatedBoundary
+
+	return display.redrawTextSegment(text, ycIndex, ytIndex, display.xCalculatedBoundary, -1, false)
+}
+
+// Helper function used to rewrite a single console row with the part of the text line starting at the given x (horizontal) offset.
+// The characters from the end offset are not displayed (a negative value represents no limit). The continuation value indicates
+// if the row is a following visual row of a wrapped line (the line number is not displayed)
+func (display *Display) redrawTextSegment(text *Text, ycIndex int, ytIndex int, xtStart int, xtEnd int, continuation bool) error {
+	if err := display.redrawGutterRow(text, ycIndex, ytIndex, continuation); err != nil {
 		return err
 	}
 
-	xlPadding := display.padding.GetLeftPadding()
+	xlPadding := display.GetXLeftOffsetPadding()
 	xrPadding := display.padding.GetRightPadding()
 
-	for xcIndex := xlPadding; xcIndex < display.width-xrPadding; xcIndex += 1 {
-		xtIndex := xcIndex + display.xCalculatedBoundary
-
-		var char rune = ' '
-		if xtIndex < tWidth {
-			char, err = text.GetCharacterByOffsets(xtIndex, ytOffset)
-			if err != nil {
+	if ytIndex >= text.GetLineCount() {
+		for xcIndex := xlPadding; xcIndex < display.width-xrPadding; xcIndex += 1 {
+			if err := display.console.InsertCharacterWithStyle(xcIndex, ycIndex, ' ', display.theme.Text); err != nil {
 				return err
 			}
 		}
 
-		if err := display.console.InsertCharacter(xcIndex, ycOffset, char); err != nil {
-			return err
-		}
+		return nil
 	}
 
-	return nil
-}
+	xtLength, err := text.GetLineLengthByOffset(ytIndex)
+	if err != nil {
+		return err
+	}
 
-// Function is rewriting text changes to the underlying console API screen, according to the display boundaries. All lines (including the current) below the cursor are affected.
-// TODO: The ycIndex < ytLength condition prevents the overwrting of previous screen data (Edit: ycIndex patched to ytIndex, does this problem still exist?)
-func (display *Display) RedrawTextBelow(text *Text, fullRedrawFallback bool) error {
-	if !display.CursorInBoundries() && fullRedrawFallback {
-		return display.RedrawTextFull(text)
+	var tokens []TokenType
+	if display.highlighter != nil {
+		if tokens, err = display.highlighter.GetLineTokens(ytIndex); err != nil {
+			return err
+		}
 	}
 
-	ytLength := text.GetLineCount()
+	selectionStyle := display.theme.Text.Merge(display.theme.Selection)
+	searchMatchStyle := display.theme.Text.Merge(display.theme.SearchMatch)
+	searchCurrentMatchStyle := display.theme.Text.Merge(display.theme.SearchCurrentMatch)
 
-	xlPadding := display.padding.GetLeftPadding()
-	xrPadding := display.padding.GetRightPadding()
-	ybPadding := display.padding.GetBottomPadding()
+	for xcIndex := xlPadding; xcIndex < display.width-xrPadding; xcIndex += 1 {
+		xtIndex := xcIndex - xlPadding + xtStart
+
+		// NOTE: The characters after the end of the visual row are displayed in the following row
+		if xtEnd >= 0 && xtIndex >= xtEnd {
+			if err := display.console.InsertCharacterWithStyle(xcIndex, ycIndex, ' ', display.theme.Text); err != nil {
+				return err
+			}
 
-	for ycIndex := display.cursor.GetOffsetY() - display.yCalculatedBoundary; ycIndex < display.height-ybPadding; ycIndex += 1 {
-		ytIndex := ycIndex + display.yCalculatedBoundary
+			continue
+		}
 
-		if ytIndex < ytLength {
-			xtLength, err := text.GetLineLengthByOffset(ytIndex)
+		var char rune = ' '
+		if xtIndex < xtLength {
+			char, err = text.GetCharacterByOffsets(xtIndex, ytIndex)
 			if err != nil {
 				return err
 			}
+		}
 
-			for xcIndex := xlPadding; xcIndex < display.width-xrPadding; xcIndex += 1 {
-				xtIndex := xcIndex + display.xCalculatedBoundary
+		// NOTE: The selected line break is highlighted as a single space after the end of the line
+		if display.selection != nil && xtIndex <= xtLength && display.selection.Contains(xtIndex, ytIndex) {
+			if err := display.console.InsertCharacterWithStyle(xcIndex, ycIndex, char, selectionStyle); err != nil {
+				return err
+			}
 
-				var char rune = ' '
+			continue
+		}
 
-				if xtIndex < xtLength {
-					char, err = text.GetCharacterByOffsets(xtIndex, ytIndex)
-					if err != nil {
-						return err
-					}
+		if display.search != nil {
+			if isMatch, isCurrent := display.search.MatchAt(xtIndex, ytIndex); isMatch {
+				style := searchMatchStyle
+				if isCurrent {
+					style = searchCurrentMatchStyle
 				}
 
-				if err := display.console.InsertCharacter(xcIndex, ycIndex, char); err != nil {
+				if err := display.console.InsertCharacterWithStyle(xcIndex, ycIndex, char, style); err != nil {
 					return err
 				}
-			}
 
-			continue
+				continue
+			}
 		}
 
-		for xcIndex := xlPadding; xcIndex < display.width-xrPadding; xcIndex += 1 {
-			if err := display.console.InsertCharacter(xcIndex, ycIndex, ' '); err != nil {
+		if xtIndex < len(tokens) && tokens[xtIndex] != TokenNone {
+			if err := display.console.InsertCharacterWithStyle(xcIndex, ycIndex, char, display.theme.GetTokenStyle(tokens[xtIndex])); err != nil {
 				return err
 			}
+
+			continue
+		}
+
+		if err := display.console.InsertCharacterWithStyle(xcIndex, ycIndex, char, display.theme.Text); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (display *Display) RedrawMenu(menu *Menu) error {
-	mBuffer, err := menu.GenerateOutputBuffer(display.width)
-	if err != nil {
-		return err
+// Function is rewriting the line number gutter to the underlying console API screen. The whole text is redrawn if the gutter width
+// has changed. The gutter should be redrawn after every cursor movement, because of the relative numbers and the cursor line highlight
+func (display *Display) RedrawGutter(text *Text) error {
+	if changed, err := display.applyGutterWidth(text); err != nil || changed {
+		if err != nil {
+			return err
+		}
+
+		return display.RedrawTextFull(text)
 	}
 
-	if display.padding.GetBottomPadding() != MenuHeight {
-		return errors.New("display: The current boundaries are preventing the menu redrawing")
+	ytPadding := display.padding.GetTopPadding()
+	ybPadding := display.padding.GetBottomPadding()
+
+	// NOTE: The visual rows of wrapped lines are not mapped directly to the text lines
+	if display.isWrapActive() {
+		return display.redrawWrappedText(text)
 	}
 
-	style := CharacterStyle{
-		Background:    "white",
-		Foreground:    "black",
-		Bold:          true,
-		Italic:        false,
-		Underline:     false,
-		StrikeThrough: false,
+	for ycIndex := ytPadding; ycIndex < display.height-ybPadding; ycIndex += 1 {
+		ytIndex := ycIndex - ytPadding + display.yCalculatedBoundary
+
+		if err := display.redrawGutterRow(text, ycIndex, ytIndex, false); err != nil {
+			return err
+		}
 	}
 
-	yIndex := display.height - MenuHeight
-	for xIndex := 0; xIndex < display.width; xIndex += 1 {
-		if err := display.console.InsertCharacterWithStyle(xIndex, yIndex, mBuffer[xIndex], style); err != nil {
+	return nil
+}
+
+// Helper function used to rewrite the line number gutter of a single console row. The numbers are aligned to the right and
+// separated from the text by a single space. In the relative mode the cursor line number is absolute and the remaining numbers
+// are representing the distance from the cursor line. The number is not displayed for the continuation rows of wrapped lines
+func (display *Display) redrawGutterRow(text *Text, ycIndex int, ytIndex int, continuation bool) error {
+	if display.gutterWidth == 0 {
+		return nil
+	}
+
+	gutterStyle := display.theme.Text.Merge(display.theme.Gutter)
+	gutterCurrentLineStyle := gutterStyle.Merge(display.theme.GutterCurrentLine)
+
+	xlPadding := display.padding.GetLeftPadding()
+	yCursor := display.cursor.GetOffsetY()
+
+	numberBuffer := []rune{}
+	if ytIndex < text.GetLineCount() && !continuation {
+		number := ytIndex + 1
+		if display.config.RelativeLineNumbers && ytIndex != yCursor {
+			number = ytIndex - yCursor
+			if number < 0 {
+				number = -number
+			}
+		}
+
+		numberBuffer = []rune(strconv.Itoa(number))
+	}
+
+	style := gutterStyle
+	if display.config.HighlightCurrentLineNumber && ytIndex == yCursor {
+		style = gutterCurrentLineStyle
+	}
+
+	// NOTE: The last gutter column is the separator between the numbers and the text
+	numberStart := display.gutterWidth - 1 - len(numberBuffer)
+	for xgIndex := 0; xgIndex < display.gutterWidth; xgIndex += 1 {
+		var char rune = ' '
+		if xgIndex >= numberStart && xgIndex < display.gutterWidth-1 {
+			char = numberBuffer[xgIndex-numberStart]
+		}
+
+		if err := display.console.InsertCharacterWithStyle(xlPadding+xgIndex, ycIndex, char, style); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// Set the theme which will be used to style the displayed elements
+func (display *Display) SetTheme(theme *Theme) error {
+	if theme == nil {
+		return errors.New("display: invalid theme struct reference")
+	}
+
+	display.theme = theme
+	return nil
+}
+
+// Attach the highlighter structure which will be used to apply the syntax highlighting to the text
+func (display *Display) AttachHighlighter(highlighter *Highlighter) error {
+	if highlighter == nil {
+		return errors.New("display: invalid highlighter struct reference")
+	}
+
+	display.highlighter = highlighter
+	return nil
+}
+
+// Attach the text structure which is required to map the text lines to the visual rows in the soft wrap mode
+func (display *Display) AttachText(text *Text) error {
+	if text == nil {
+		return errors.New("display: invalid text struct reference")
+	}
+
+	display.text = text
+	return nil
+}
+
+// Enable or disable the soft wrap mode. In the soft wrap mode the lines longer than the display width are split into multiple
+// visual rows instead of being scrolled horizontally. The boundaries are recalculated for the new mode
+func (display *Display) SetWrapEnabled(enabled bool) error {
+	display.wrapEnabled = enabled
+	display.xCalculatedBoundary = 0
+	display.yCalculatedBoundary = 0
+	display.ySegmentBoundary = 0
+
+	return display.RecalculateBoundaries()
+}
+
+// Return a bool value indicating if the soft wrap mode is enabled
+func (display *Display) IsWrapEnabled() bool {
+	return display.wrapEnabled
+}
+
+// Move the cursor by the given count of visual rows (negative values are moving up) in the soft wrap mode. The cursor is keeping
+// the column relative to the start of the visual row, limited by the row length
+func (display *Display) MoveCursorByVisualRows(rowShift int) error {
+	if !display.isWrapActive() {
+		return errors.New("display: the soft wrap mode is not active")
+	}
+
+	yCursor, sCursor, err := display.getCursorVisualRow()
+	if err != nil {
+		return err
+	}
+
+	segments, err := display.getLineSegments(yCursor)
+	if err != nil {
+		return err
+	}
+
+	xColumn := display.cursor.GetOffsetX() - segments[sCursor]
+
+	yTarget, sTarget, _, err := display.stepVisualRows(yCursor, sCursor, rowShift)
+	if err != nil {
+		return err
+	}
+
+	targetSegments, err := display.getLineSegments(yTarget)
+	if err != nil {
+		return err
+	}
+
+	xTargetEnd, err := display.text.GetLineLengthByOffset(yTarget)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The offset of the next row start would place the cursor in the next row, so the end is moved back by one
+	if sTarget+1 < len(targetSegments) {
+		xTargetEnd = targetSegments[sTarget+1] - 1
+	}
+
+	xTarget := targetSegments[sTarget] + xColumn
+	if xTarget > xTargetEnd {
+		xTarget = xTargetEnd
+	}
+
+	return display.cursor.SetOffsets(xTarget, yTarget)
+}
+
+// Helper function used to check if the soft wrap mode can be applied (the text is required to calculate the visual rows)
+func (display *Display) isWrapActive() bool {
+	return display.wrapEnabled && display.text != nil
+}
+
+// Helper function used to calculate the start offsets of the visual rows of the line with the given y (vertical) offset.
+// One column is reserved for the cursor placed after the last character of the row
+func (display *Display) getLineSegments(yOffset int) ([]int, error) {
+	lineBuffer, err := display.text.GetLineBufferByOffset(yOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	textWidth, _ := display.GetTextDisplaySize()
+
+	wrapWidth := textWidth - 1
+	if wrapWidth < 1 {
+		wrapWidth = 1
+	}
+
+	return CalculateWrapSegments(lineBuffer, wrapWidth, display.config.WrapAtWordBoundary), nil
+}
+
+// Helper function used to return the line and the visual row (segment) index of the cursor position
+func (display *Display) getCursorVisualRow() (int, int, error) {
+	yCursor := display.cursor.GetOffsetY()
+
+	segments, err := display.getLineSegments(yCursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return yCursor, GetWrapSegmentIndex(segments, display.cursor.GetOffsetX()), nil
+}
+
+// Helper function used to move the visual row position (line and segment index) by the given count of rows. The movement is
+// limited by the start and the end of the text. Returns the new position and the count of rows actually moved
+func (display *Display) stepVisualRows(yOffset int, sOffset int, rowShift int) (int, int, int, error) {
+	rowsMoved := 0
+
+	for rowShift < 0 {
+		if sOffset > 0 {
+			sOffset -= 1
+		} else if yOffset > 0 {
+			yOffset -= 1
+
+			segments, err := display.getLineSegments(yOffset)
+			if err != nil {
+				return 0, 0, 0, err
+			}
+
+			sOffset = len(segments) - 1
+		} else {
+			break
+		}
+
+		rowShift += 1
+		rowsMoved += 1
+	}
+
+	for rowShift > 0 {
+		segments, err := display.getLineSegments(yOffset)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		if sOffset+1 < len(segments) {
+			sOffset += 1
+		} else if yOffset+1 < display.text.GetLineCount() {
+			yOffset += 1
+			sOffset = 0
+		} else {
+			break
+		}
+
+		rowShift -= 1
+		rowsMoved += 1
+	}
+
+	return yOffset, sOffset, rowsMoved, nil
+}
+
+// Helper function used to check if the cursor visual row is placed between the first displayed visual row and the bottom of the
+// display. Returns the check result and the first visual row position for which the cursor would be placed in the last display row
+func (display *Display) wrappedCursorInBoundaries() (bool, int, int, error) {
+	yCursor, sCursor, err := display.getCursorVisualRow()
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	// NOTE: The segment boundary can be outdated if the first displayed line was shortened
+	ySegments := 1
+	if display.yCalculatedBoundary < display.text.GetLineCount() {
+		segments, err := display.getLineSegments(display.yCalculatedBoundary)
+		if err != nil {
+			return false, 0, 0, err
+		}
+
+		ySegments = len(segments)
+	}
+
+	if display.ySegmentBoundary >= ySegments {
+		display.ySegmentBoundary = ySegments - 1
+	}
+
+	if yCursor < display.yCalculatedBoundary || (yCursor == display.yCalculatedBoundary && sCursor < display.ySegmentBoundary) {
+		return false, yCursor, sCursor, nil
+	}
+
+	_, textHeight := display.GetTextDisplaySize()
+
+	yRow, sRow := yCursor, sCursor
+	for rowIndex := 0; rowIndex < textHeight; rowIndex += 1 {
+		if yRow == display.yCalculatedBoundary && sRow == display.ySegmentBoundary {
+			return true, 0, 0, nil
+		}
+
+		if rowIndex+1 == textHeight {
+			break
+		}
+
+		yRow, sRow, _, err = display.stepVisualRows(yRow, sRow, -1)
+		if err != nil {
+			return false, 0, 0, err
+		}
+	}
+
+	return false, yRow, sRow, nil
+}
+
+// Helper function used to recalculate the boundaries in the soft wrap mode. There is no horizontal scrolling and the vertical
+// boundary is the first displayed visual row. The boundary is moved only if the cursor is not visible
+func (display *Display) recalculateWrappedBoundaries() error {
+	display.xCalculatedBoundary = 0
+
+	inBoundaries, yBoundary, sBoundary, err := display.wrappedCursorInBoundaries()
+	if err != nil {
+		return err
+	}
+
+	if !inBoundaries {
+		display.yCalculatedBoundary = yBoundary
+		display.ySegmentBoundary = sBoundary
+	}
+
+	return nil
+}
+
+// Helper function used to calculate the console position of the cursor in the soft wrap mode
+func (display *Display) getWrappedCursorConsolePosition() (int, int, error) {
+	yCursor, sCursor, err := display.getCursorVisualRow()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	segments, err := display.getLineSegments(yCursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rowIndex := 0
+	yRow, sRow := yCursor, sCursor
+	for yRow > display.yCalculatedBoundary || (yRow == display.yCalculatedBoundary && sRow > display.ySegmentBoundary) {
+		yRow, sRow, _, err = display.stepVisualRows(yRow, sRow, -1)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		rowIndex += 1
+	}
+
+	xConsole := display.cursor.GetOffsetX() - segments[sCursor] + display.GetXLeftOffsetPadding()
+	yConsole := rowIndex + display.padding.GetTopPadding()
+
+	return xConsole, yConsole, nil
+}
+
+// Helper function used to return the first displayed visual row position limited to the text. The boundaries can be outdated
+// if the text was shortened
+func (display *Display) getWrappedBoundaries() (int, int, error) {
+	yBoundary := display.yCalculatedBoundary
+	if yBoundary >= display.text.GetLineCount() {
+		yBoundary = display.text.GetLineCount() - 1
+	}
+
+	segments, err := display.getLineSegments(yBoundary)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sBoundary := display.ySegmentBoundary
+	if yBoundary != display.yCalculatedBoundary || sBoundary >= len(segments) {
+		sBoundary = len(segments) - 1
+	}
+
+	return yBoundary, sBoundary, nil
+}
+
+// Helper function used to calculate the text offsets of the given column of the visual row (relative to the first displayed
+// visual row) in the soft wrap mode. The column is limited to the end of the visual row
+func (display *Display) getWrappedTextOffsets(xColumn int, yRow int) (int, int, error) {
+	yBoundary, sBoundary, err := display.getWrappedBoundaries()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	yOffset, sOffset, rowsMoved, err := display.stepVisualRows(yBoundary, sBoundary, yRow)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// NOTE: The rows before the start and after the end of the text are mapped to the text start and end
+	if yRow < 0 && rowsMoved < -yRow {
+		return 0, 0, nil
+	}
+
+	if yRow > 0 && rowsMoved < yRow {
+		return display.getTextEndOffsets()
+	}
+
+	segments, err := display.getLineSegments(yOffset)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	xEnd, err := display.text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// NOTE: The offset of the next row start would place the cursor in the next row, so the end is moved back by one
+	if sOffset+1 < len(segments) {
+		xEnd = segments[sOffset+1] - 1
+	}
+
+	xOffset := segments[sOffset] + xColumn
+	if xOffset > xEnd {
+		xOffset = xEnd
+	}
+
+	return xOffset, yOffset, nil
+}
+
+// Helper function used to return the offsets of the position after the last character of the text
+func (display *Display) getTextEndOffsets() (int, int, error) {
+	yOffset := display.text.GetLineCount() - 1
+
+	xOffset, err := display.text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return xOffset, yOffset, nil
+}
+
+// Helper function used to rewrite all console rows with the visual rows of the wrapped text lines
+func (display *Display) redrawWrappedText(text *Text) error {
+	ytPadding := display.padding.GetTopPadding()
+	ybPadding := display.padding.GetBottomPadding()
+
+	yRow, sRow := display.yCalculatedBoundary, display.ySegmentBoundary
+	textEnded := yRow >= text.GetLineCount()
+
+	for ycIndex := ytPadding; ycIndex < display.height-ybPadding; ycIndex += 1 {
+		if textEnded {
+			if err := display.redrawTextSegment(text, ycIndex, text.GetLineCount(), 0, -1, false); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		segments, err := display.getLineSegments(yRow)
+		if err != nil {
+			return err
+		}
+
+		if sRow >= len(segments) {
+			sRow = len(segments) - 1
+		}
+
+		xtEnd := -1
+		if sRow+1 < len(segments) {
+			xtEnd = segments[sRow+1]
+		}
+
+		if err := display.redrawTextSegment(text, ycIndex, yRow, segments[sRow], xtEnd, sRow > 0); err != nil {
+			return err
+		}
+
+		var rowsMoved int
+		yRow, sRow, rowsMoved, err = display.stepVisualRows(yRow, sRow, 1)
+		if err != nil {
+			return err
+		}
+
+		textEnded = rowsMoved == 0
+	}
+
+	return nil
+}
+
+// Helper function used to apply the gutter width required by the text line count. The boundaries are recalculated if the cursor
+// is no longer visible. Returns a bool value indicating if the width has changed
+func (display *Display) applyGutterWidth(text *Text) (bool, error) {
+	if !display.updateGutterWidth(text) {
+		return false, nil
+	}
+
+	if !display.CursorInBoundries() {
+		if err := display.RecalculateBoundaries(); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// Helper function used to calculate the gutter width based on the count of digits of the text line count. The gutter is hidden
+// if it is disabled or there is not enough space for the text. Returns a bool value indicating if the width has changed
+func (display *Display) updateGutterWidth(text *Text) bool {
+	gutterWidth := 0
+	if display.config.ShowLineNumbers {
+		gutterWidth = len(strconv.Itoa(text.GetLineCount())) + 1
+
+		if display.width-display.padding.GetLeftPadding()-display.padding.GetRightPadding()-gutterWidth < minimalTextWidth {
+			gutterWidth = 0
+		}
+	}
+
+	if gutterWidth == display.gutterWidth {
+		return false
+	}
+
+	display.gutterWidth = gutterWidth
+	return true
+}
+
+// Request a render of all changes to the screen of the underlying console API. The console cursor is placed inside the menu input
+func (display *Display) RenderChangesWithMenuInput(menu *Menu) error {
+	if !menu.IsInputActive() {
+		return display.RenderChanges()
+	}
+
+	if err := display.console.SetCursorPosition(menu.GetInputCaretIndex(), display.height-MenuHeight); err != nil {
+		return err
+	}
+
+	return display.console.Commit()
+}
+
+// Function is rewriting the menu widget to the underlying console API screen. The menu is placed inside the bottom padding
+func (display *Display) RedrawMenu(menu *Menu) error {
+	mBuffer, err := menu.GenerateOutputBuffer(display.width)
+	if err != nil {
+		return err
+	}
+
+	if display.padding.GetBottomPadding() < MenuHeight {
+		return errors.New("display: The current boundaries are preventing the menu redrawing")
+	}
+
+	style := display.theme.Menu
+
+	yIndex := display.height - MenuHeight
+	for xIndex := 0; xIndex < display.width; xIndex += 1 {
+		if err := display.console.InsertCharacterWithStyle(xIndex, yIndex, mBuffer[xIndex], style); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Function is rewriting the popup widget to the underlying console API screen. The popup is centered inside the area provided
+// for the text and is covering the text until the next full text redraw. The popup is skipped if the area is too small
+func (display *Display) RedrawPopup(popup *Popup) error {
+	if popup == nil {
+		return errors.New("display: invalid popup struct reference")
+	}
+
+	xArea := display.padding.GetLeftPadding()
+	yArea := display.padding.GetTopPadding()
+	areaWidth := display.width - display.padding.GetLeftPadding() - display.padding.GetRightPadding()
+	areaHeight := display.height - display.GetYOffsetPadding()
+
+	if display.paddingFallback || areaWidth < 6 || areaHeight < 3 {
+		return nil
+	}
+
+	title := []rune(popup.GetTitle())
+	items := popup.GetItems()
+	preview := popup.GetPreview()
+
+	popupWidth := popupMinimalWidth
+	if len(title)+4 > popupWidth {
+		popupWidth = len(title) + 4
+	}
+
+	for _, lines := range [][]string{items, preview} {
+		for _, line := range lines {
+			if len([]rune(line))+4 > popupWidth {
+				popupWidth = len([]rune(line)) + 4
+			}
+		}
+	}
+
+	if popupWidth > areaWidth-2 {
+		popupWidth = areaWidth - 2
+	}
+
+	rows := len(items)
+	if rows > popupMaxVisibleItems {
+		rows = popupMaxVisibleItems
+	}
+
+	if rows > areaHeight-2 {
+		rows = areaHeight - 2
+	}
+
+	// NOTE: The empty popup is still displayed with a single blank row
+	if rows <= 0 {
+		rows = 1
+	}
+
+	// NOTE: The preview is separated from the items with a border line and is only displayed if there is enough space left
+	previewRows := len(preview)
+	if previewRows > popupMaxVisiblePreview {
+		previewRows = popupMaxVisiblePreview
+	}
+
+	if previewRows > areaHeight-rows-3 {
+		previewRows = areaHeight - rows - 3
+	}
+
+	popupHeight := rows + 2
+	if previewRows > 0 {
+		popupHeight += previewRows + 1
+	}
+
+	xStart := xArea + (areaWidth-popupWidth)/2
+	yStart := yArea + (areaHeight-popupHeight)/2
+
+	borderStyle := display.theme.Text.Merge(display.theme.Gutter)
+	titleStyle := display.theme.Menu
+
+	// NOTE: The top border is containing the title
+	for xIndex := 0; xIndex < popupWidth; xIndex += 1 {
+		char := '─'
+		style := borderStyle
+
+		switch {
+		case xIndex == 0:
+			char = '┌'
+		case xIndex == popupWidth-1:
+			char = '┐'
+		case xIndex >= 2 && xIndex-2 < len(title) && xIndex < popupWidth-2:
+			char = title[xIndex-2]
+			style = titleStyle
+		}
+
+		if err := display.console.InsertCharacterWithStyle(xStart+xIndex, yStart, char, style); err != nil {
+			return err
+		}
+	}
+
+	itemStart, itemEnd := popup.GetVisibleRange(rows)
+	selectedIndex := popup.GetSelectedIndex()
+
+	for rowIndex := 0; rowIndex < rows; rowIndex += 1 {
+		var item []rune = nil
+		style := display.theme.Text
+
+		if itemIndex := itemStart + rowIndex; itemIndex < itemEnd {
+			item = []rune(items[itemIndex])
+
+			if itemIndex == selectedIndex {
+				style = display.theme.Text.Merge(display.theme.Selection)
+			}
+		}
+
+		if err := display.redrawPopupRow(xStart, yStart+1+rowIndex, popupWidth, item, style, borderStyle); err != nil {
+			return err
+		}
+	}
+
+	if previewRows > 0 {
+		if err := display.redrawPopupBorder(xStart, yStart+rows+1, popupWidth, '├', '┤', borderStyle); err != nil {
+			return err
+		}
+
+		for rowIndex := 0; rowIndex < previewRows; rowIndex += 1 {
+			line := []rune(preview[rowIndex])
+			if err := display.redrawPopupRow(xStart, yStart+rows+2+rowIndex, popupWidth, line, display.theme.Text, borderStyle); err != nil {
+				return err
+			}
+		}
+	}
+
+	return display.redrawPopupBorder(xStart, yStart+popupHeight-1, popupWidth, '└', '┘', borderStyle)
+}
+
+// Helper function used to rewrite a single row of the popup containing the given content. The content is truncated to the width of the popup
+func (display *Display) redrawPopupRow(xStart int, yIndex int, popupWidth int, content []rune, style CharacterStyle, borderStyle CharacterStyle) error {
+	for xIndex := 0; xIndex < popupWidth; xIndex += 1 {
+		char := ' '
+		charStyle := style
+
+		switch {
+		case xIndex == 0 || xIndex == popupWidth-1:
+			char = '│'
+			charStyle = borderStyle
+		case xIndex >= 2 && xIndex-2 < len(content) && xIndex < popupWidth-2:
+			char = content[xIndex-2]
+		}
+
+		if err := display.console.InsertCharacterWithStyle(xStart+xIndex, yIndex, char, charStyle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Helper function used to rewrite a horizontal border line of the popup with the given corner characters
+func (display *Display) redrawPopupBorder(xStart int, yIndex int, popupWidth int, leftCorner rune, rightCorner rune, borderStyle CharacterStyle) error {
+	for xIndex := 0; xIndex < popupWidth; xIndex += 1 {
+		char := '─'
+		switch xIndex {
+		case 0:
+			char = leftCorner
+		case popupWidth - 1:
+			char = rightCorner
+		}
+
+		if err := display.console.InsertCharacterWithStyle(xStart+xIndex, yIndex, char, borderStyle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Function is rewriting the separator line between the panes of a split to the underlying console API screen. The vertical
+// separator is drawn downwards and the horizontal separator is drawn to the right from the given console position
+func (display *Display) RedrawSeparator(xIndex int, yIndex int, length int, vertical bool) error {
+	style := display.theme.Text.Merge(display.theme.Gutter)
+
+	for index := 0; index < length; index += 1 {
+		var err error = nil
+		if vertical {
+			err = display.console.InsertCharacterWithStyle(xIndex, yIndex+index, '│', style)
+		} else {
+			err = display.console.InsertCharacterWithStyle(xIndex+index, yIndex, '─', style)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The minimal width of the text area, for which the line number gutter is still displayed
+const minimalTextWidth = 8
+
+// A structure containing the configuration for the display structure
+type DisplayConfig struct {
+	ShowLineNumbers            bool `json:"show-line-numbers"`
+	RelativeLineNumbers        bool `json:"relative-line-numbers"`
+	HighlightCurrentLineNumber bool `json:"highlight-current-line-number"`
+	SoftWrap                   bool `json:"soft-wrap"`
+	WrapAtWordBoundary         bool `json:"soft-wrap-word-boundary"`
+	SyntaxHighlighting         bool `json:"syntax-highlighting"`
+	MouseSupport               bool `json:"mouse-support"`
+}
+
+// Return a new instance of the display configuration with default values
+func CreateDefaultDisplayConfig() DisplayConfig {
+	return DisplayConfig{
+		ShowLineNumbers:            false,
+		RelativeLineNumbers:        false,
+		HighlightCurrentLineNumber: true,
+		SoftWrap:                   false,
+		WrapAtWordBoundary:         true,
+		SyntaxHighlighting:         true,
+		MouseSupport:               false,
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -13,7 +14,7 @@ func TestDisplayShouldInitialzieForValidParams(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 }
@@ -27,7 +28,7 @@ func TestDisplayShouldNotInitialzieForInvalidParams(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, nil); err == nil {
+	if err := display.Init(cursor, nil, nil, nil); err == nil {
 		t.Fail()
 	}
 }
@@ -41,7 +42,7 @@ func TestDisplayShouldResizeForValidUpdatedSize(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -69,7 +70,7 @@ func TestDisplayShouldNotResizeForInvalidUpdatedSize(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -87,7 +88,7 @@ func TestDisplayHasSizeChangedShouldCorrectlyIndicateChangedSize(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -105,7 +106,7 @@ func TestDisplayHasSizeChangedShouldCorrectlyIndicateUnchangedSize(t *testing.T)
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -123,7 +124,7 @@ func TestDisplayShouldCalculateCorrectHeightBoundaries(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -145,7 +146,7 @@ func TestDisplayShouldCalculateCorrectWidthBoundaries(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -167,7 +168,7 @@ func TestDisplayShouldCalculateCorrectHeightAndWidthBoundaries(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -189,7 +190,7 @@ func TestDisplayShouldIndicateInBoundries(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -211,7 +212,7 @@ func TestDisplayShouldIndicateOutOfBoundriesForHeight(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -233,7 +234,7 @@ func TestDisplayShouldIndicateOutOfBoundriesForWidth(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -255,7 +256,7 @@ func TestDisplayShouldIndicateOutOfBoundriesForHeightAndWidth(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -282,7 +283,7 @@ func TestDisplayShouldReturnCorrectSpecifiedPadding(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, padding, console); err != nil {
+	if err := display.Init(cursor, padding, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -320,7 +321,7 @@ func TestDisplayShouldReturnCorrectUnspecifiedPadding(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -358,7 +359,7 @@ func TestDisplayShouldReturnFullSize(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -387,7 +388,7 @@ func TestDisplayShouldReturnCorrectTextSizeForInitializedPadding(t *testing.T) {
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, padding, console); err != nil {
+	if err := display.Init(cursor, padding, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -411,7 +412,7 @@ func TestDisplayShouldReturnCorrectTextSizeForUninitializedPadding(t *testing.T)
 	}
 
 	display := new(Display)
-	if err := display.Init(cursor, nil, console); err != nil {
+	if err := display.Init(cursor, nil, console, nil); err != nil {
 		t.Fail()
 	}
 
@@ -425,3 +426,492 @@ func TestDisplayShouldReturnCorrectTextSizeForUninitializedPadding(t *testing.T)
 		t.Fail()
 	}
 }
+
+func TestDisplayShouldCenterBoundaries(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 30, console, nil); err != nil {
+		t.Fail()
+	}
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, nil); err != nil {
+		t.Fail()
+	}
+
+	if err := display.CenterBoundaries(); err != nil {
+		t.Fail()
+	}
+
+	if display.GetYOffsetShift() != 25 {
+		t.Fail()
+	}
+
+	if err := cursor.SetOffsets(0, 3); err != nil {
+		t.Fail()
+	}
+
+	if err := display.CenterBoundaries(); err != nil {
+		t.Fail()
+	}
+
+	if display.GetYOffsetShift() != 0 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldAdaptGutterWidthToLineCount(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.Fail()
+	}
+
+	config := CreateDefaultDisplayConfig()
+	config.ShowLineNumbers = true
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, &config); err != nil {
+		t.Fail()
+	}
+
+	if err := display.Resize(40, 10); err != nil {
+		t.Fail()
+	}
+
+	text := new(Text)
+	if err := text.Init(strings.Repeat("line\n", 119), false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := display.RedrawTextFull(text); err != nil {
+		t.Fail()
+	}
+
+	if display.GetXLeftOffsetPadding() != 4 {
+		t.Fail()
+	}
+
+	width, _ := display.GetTextDisplaySize()
+	if width != 36 {
+		t.Fail()
+	}
+
+	if err := cursor.SetOffsets(36, 0); err != nil {
+		t.Fail()
+	}
+
+	if err := display.RecalculateBoundaries(); err != nil {
+		t.Fail()
+	}
+
+	if display.GetXOffsetShift() != 2 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldHideGutterForNarrowDisplay(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.Fail()
+	}
+
+	config := CreateDefaultDisplayConfig()
+	config.ShowLineNumbers = true
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, &config); err != nil {
+		t.Fail()
+	}
+
+	text := new(Text)
+	if err := text.Init(strings.Repeat("line\n", 119), false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := display.RedrawTextFull(text); err != nil {
+		t.Fail()
+	}
+
+	if display.GetXLeftOffsetPadding() != 0 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldHideGutterForDisabledLineNumbers(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.Fail()
+	}
+
+	config := CreateDefaultDisplayConfig()
+	config.ShowLineNumbers = false
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, &config); err != nil {
+		t.Fail()
+	}
+
+	if err := display.Resize(40, 10); err != nil {
+		t.Fail()
+	}
+
+	text := new(Text)
+	if err := text.Init("First line\nSecond line", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := display.RedrawTextFull(text); err != nil {
+		t.Fail()
+	}
+
+	if display.GetXLeftOffsetPadding() != 0 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldMoveCursorByVisualRowsInSoftWrapMode(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(12, 0, console, nil); err != nil {
+		t.Fail()
+	}
+
+	config := CreateDefaultDisplayConfig()
+	config.SoftWrap = true
+	config.WrapAtWordBoundary = false
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, &config); err != nil {
+		t.Fail()
+	}
+
+	text := new(Text)
+	if err := text.Init("0123456789abcdefghij\nshort", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := display.AttachText(text); err != nil {
+		t.Fail()
+	}
+
+	if err := display.MoveCursorByVisualRows(-1); err != nil {
+		t.Fail()
+	}
+
+	if cursor.GetOffsetX() != 3 || cursor.GetOffsetY() != 0 {
+		t.Fail()
+	}
+
+	if err := display.MoveCursorByVisualRows(2); err != nil {
+		t.Fail()
+	}
+
+	if cursor.GetOffsetX() != 20 || cursor.GetOffsetY() != 0 {
+		t.Fail()
+	}
+
+	if err := display.MoveCursorByVisualRows(1); err != nil {
+		t.Fail()
+	}
+
+	if cursor.GetOffsetX() != 2 || cursor.GetOffsetY() != 1 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldCalculateBoundariesInVisualRowsInSoftWrapMode(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.Fail()
+	}
+
+	config := CreateDefaultDisplayConfig()
+	config.SoftWrap = true
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, &config); err != nil {
+		t.Fail()
+	}
+
+	text := new(Text)
+	if err := text.Init("0123456789abcdefghij"+strings.Repeat("\nline", 12), false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := display.AttachText(text); err != nil {
+		t.Fail()
+	}
+
+	if err := cursor.SetOffsets(0, 7); err != nil {
+		t.Fail()
+	}
+
+	if !display.CursorInBoundries() {
+		t.Fail()
+	}
+
+	if err := cursor.SetOffsets(0, 10); err != nil {
+		t.Fail()
+	}
+
+	if display.CursorInBoundries() {
+		t.Fail()
+	}
+
+	if err := display.RecalculateBoundaries(); err != nil {
+		t.Fail()
+	}
+
+	if display.GetXOffsetShift() != 0 || display.GetYOffsetShift() != 1 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldRedrawPopup(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.Fail()
+	}
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, nil); err != nil {
+		t.Fail()
+	}
+
+	popup := new(Popup)
+	if err := popup.Init("Buffers", []string{"1: first.go", "2: second.go", "3: third.json"}); err != nil {
+		t.FailNow()
+	}
+
+	if err := display.RedrawPopup(popup); err != nil {
+		t.Fail()
+	}
+
+	popup.SetPreview([]string{"package main", "", "func main() {", "}"})
+	if err := display.RedrawPopup(popup); err != nil {
+		t.Fail()
+	}
+
+	if err := display.Resize(2, 2); err != nil {
+		t.Fail()
+	}
+
+	if err := display.RedrawPopup(popup); err != nil {
+		t.Fail()
+	}
+
+	if err := display.RedrawPopup(nil); err == nil {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldCalculateBoundariesForPaddingOfPane(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 4, console, nil); err != nil {
+		t.Fail()
+	}
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, nil); err != nil {
+		t.Fail()
+	}
+
+	if display.GetYOffsetShift() != 0 {
+		t.Fail()
+	}
+
+	padding := new(Padding)
+	if err := padding.Init(5, 1, 0, 0); err != nil {
+		t.Fail()
+	}
+
+	if err := display.SetPadding(padding); err != nil {
+		t.Fail()
+	}
+
+	if display.GetYOffsetShift() != 1 {
+		t.Fail()
+	}
+
+	if err := display.SetPadding(nil); err == nil {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldMapConsolePositionToTextOffsets(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.FailNow()
+	}
+
+	padding := new(Padding)
+	if err := padding.Init(1, 1, 0, 0); err != nil {
+		t.FailNow()
+	}
+
+	display := new(Display)
+	if err := display.Init(cursor, padding, console, nil); err != nil {
+		t.FailNow()
+	}
+
+	text := new(Text)
+	if err := text.Init("first line\nsecond\nthird", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	if err := display.AttachText(text); err != nil {
+		t.FailNow()
+	}
+
+	cases := []struct {
+		xConsole int
+		yConsole int
+		xOffset  int
+		yOffset  int
+	}{
+		{3, 1, 3, 0},
+		{9, 2, 6, 1},
+		{2, 0, 0, 0},
+		{0, 9, 5, 2},
+	}
+
+	for _, c := range cases {
+		xOffset, yOffset, err := display.GetTextOffsetsByConsolePosition(c.xConsole, c.yConsole)
+		if err != nil {
+			t.FailNow()
+		}
+
+		if xOffset != c.xOffset || yOffset != c.yOffset {
+			t.Fail()
+		}
+	}
+
+	if changed, err := display.ScrollBoundaries(1); err != nil || !changed {
+		t.FailNow()
+	}
+
+	if xOffset, yOffset, err := display.GetTextOffsetsByConsolePosition(1, 1); err != nil || xOffset != 1 || yOffset != 1 {
+		t.Fail()
+	}
+
+	if cursor.GetOffsetX() != 0 || cursor.GetOffsetY() != 0 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldMapConsolePositionToTextOffsetsInSoftWrapMode(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.FailNow()
+	}
+
+	config := CreateDefaultDisplayConfig()
+	config.SoftWrap = true
+	config.WrapAtWordBoundary = false
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, &config); err != nil {
+		t.FailNow()
+	}
+
+	text := new(Text)
+	if err := text.Init("0123456789abcdefghij\nshort", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	if err := display.AttachText(text); err != nil {
+		t.FailNow()
+	}
+
+	cases := []struct {
+		xConsole int
+		yConsole int
+		xOffset  int
+		yOffset  int
+	}{
+		{2, 1, 11, 0},
+		{9, 0, 8, 0},
+		{0, 3, 0, 1},
+		{0, 8, 5, 1},
+	}
+
+	for _, c := range cases {
+		xOffset, yOffset, err := display.GetTextOffsetsByConsolePosition(c.xConsole, c.yConsole)
+		if err != nil {
+			t.FailNow()
+		}
+
+		if xOffset != c.xOffset || yOffset != c.yOffset {
+			t.Fail()
+		}
+	}
+
+	if changed, err := display.ScrollBoundaries(1); err != nil || !changed {
+		t.FailNow()
+	}
+
+	if xOffset, yOffset, err := display.GetTextOffsetsByConsolePosition(0, 0); err != nil || xOffset != 9 || yOffset != 0 {
+		t.Fail()
+	}
+}
+
+func TestDisplayShouldLimitScrolledBoundaries(t *testing.T) {
+	console := CreateConsoleMockup()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, console, nil); err != nil {
+		t.FailNow()
+	}
+
+	display := new(Display)
+	if err := display.Init(cursor, nil, console, nil); err != nil {
+		t.FailNow()
+	}
+
+	text := new(Text)
+	if err := text.Init(strings.Repeat("line\n", 20), false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	if err := display.AttachText(text); err != nil {
+		t.FailNow()
+	}
+
+	if changed, err := display.ScrollBoundaries(100); err != nil || !changed {
+		t.Fail()
+	}
+
+	if display.GetYOffsetShift() != 20 {
+		t.Fail()
+	}
+
+	if changed, err := display.ScrollBoundaries(-100); err != nil || !changed {
+		t.Fail()
+	}
+
+	if changed, err := display.ScrollBoundaries(-1); err != nil || changed {
+		t.Fail()
+	}
+
+	if display.GetYOffsetShift() != 0 {
+		t.Fail()
+	}
+}
@@ -5,51 +5,58 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	mouseDoubleClickInterval = 400 * time.Millisecond
+	mouseWheelScrollRows     = 3
 )
 
 // TODO: Move key handler to helper struct
-// TODO: Implement ,,alternate screen” in order to restore previous console content after program exit
 
-// Structure representing the editor instance which is a warapper for text I/O
+// Structure representing the editor instance which is a warapper for text I/O. The display, cursor, selection and search are
+// referencing the components of the focused pane and the text, history and highlighter are referencing the components of the
+// buffer presented inside the focused pane
 type Editor struct {
-	filePath   string
-	fileName   string
-	fileExists bool
-	console    Console
-	display    *Display
-	text       *Text
-	cursor     *Cursor
-	history    *History
-	config     *Config
-	keybinds   *Keybinds
-	menu       *Menu
-}
-
-// Editor structure initialization funcation
-func (editor *Editor) Init(filePath string, console Console, config *Config) error {
-	if len(filePath) <= 0 {
-		return errors.New("editor: invalid path passed to editor")
-	}
-
-	editor.filePath = filePath
-	editor.fileName = filepath.Base(editor.filePath)
-
-	if _, err := os.Stat(editor.filePath); err == nil {
-		editor.fileExists = true
-	} else if errors.Is(err, os.ErrNotExist) {
-		editor.fileExists = false
-	} else {
-		return errors.New("editor: can not determine if the file is accesable")
-	}
+	buffers     []*Buffer
+	bufferIndex int
+	layout      *Layout
+	pane        *Pane
+	console     Console
+	display     *Display
+	highlighter *Highlighter
+	themes      *Themes
+	text        *Text
+	cursor      *Cursor
+	selection   *Selection
+	search      *Search
+	history     *History
+	clipboard   Clipboard
+	config      *Config
+	keybinds    *Keybinds
+	commands    *Commands
+	menu        *Menu
+	mouse       editorMouseState
+	readOnly    bool
+}
 
-	fileTextContent := ""
-	if editor.fileExists {
-		fileData, err := os.ReadFile(editor.filePath)
-		if err != nil {
-			return err
-		}
+// Structure representing the state of the mouse between the mouse events, used to recognize the drags and the double clicks
+type editorMouseState struct {
+	buttons   MouseButton
+	pane      *Pane
+	clickTime time.Time
+	xClick    int
+	yClick    int
+}
 
-		fileTextContent = string(fileData)
+// Editor structure initialization funcation. A separate buffer is opened for every given file path
+func (editor *Editor) Init(filePaths []string, console Console, config *Config) error {
+	if len(filePaths) <= 0 {
+		return errors.New("editor: no file paths passed to editor")
 	}
 
 	if console == nil {
@@ -64,46 +71,84 @@ func (editor *Editor) Init(filePath string, console Console, config *Config) err
 
 	editor.config = config
 
-	editor.text = new(Text)
-	if err := editor.text.Init(fileTextContent, !editor.fileExists, &editor.config.TextConfiguration); err != nil {
+	editor.buffers = make([]*Buffer, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		if len(filePath) <= 0 {
+			return errors.New("editor: invalid path passed to editor")
+		}
+
+		buffer := new(Buffer)
+		if err := buffer.Init(filePath, editor.config); err != nil {
+			return err
+		}
+
+		if err := editor.startBufferLoading(buffer); err != nil {
+			return err
+		}
+
+		editor.buffers = append(editor.buffers, buffer)
+	}
+
+	editor.themes = new(Themes)
+	if err := editor.themes.Init(&editor.config.ThemeConfiguration); err != nil {
 		return err
 	}
 
-	editor.cursor = new(Cursor)
-	if err := editor.cursor.Init(0, 0, console, &editor.config.CursorConfiguration); err != nil {
+	editor.pane = new(Pane)
+	if err := editor.pane.Init(editor.buffers[0], 0, editor.console, editor.themes.GetCurrent(), editor.config); err != nil {
 		return err
 	}
 
-	editor.history = new(History)
-	if err := editor.history.Init(&editor.config.HistoryConfiguration); err != nil {
+	editor.layout = new(Layout)
+	if err := editor.layout.Init(editor.pane); err != nil {
 		return err
 	}
 
+	editor.clipboard = CreateClipboard(&editor.config.ClipboardConfiguration)
+
 	editor.keybinds = new(Keybinds)
 	if err := editor.keybinds.Init(&editor.config.KeybindsConfiguration); err != nil {
 		return err
 	}
 
-	editor.menu = new(Menu)
-	if err := editor.menu.Init(editor.fileName, editor.text.GetEndOfLineSequenceName()); err != nil {
+	editor.commands = new(Commands)
+	if err := editor.commands.Init(); err != nil {
 		return err
 	}
 
-	if err := editor.menuUpdateInformation(); err != nil {
+	if err := editor.registerCommands(); err != nil {
 		return err
 	}
 
-	editorPadding := new(Padding)
-	if err := editorPadding.Init(0, MenuHeight, 0, 0); err != nil {
+	if err := editor.bindCommands(); err != nil {
 		return err
 	}
 
-	editor.display = new(Display)
-	if err := editor.display.Init(editor.cursor, editorPadding, editor.console); err != nil {
+	if err := editor.console.SetMouseEnabled(editor.config.DisplayConfiguration.MouseSupport); err != nil {
 		return err
 	}
 
-	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+	editor.menu = new(Menu)
+	if err := editor.menu.Init(editor.buffers[0].GetFileName(), editor.buffers[0].GetText().GetEndOfLineSequenceName()); err != nil {
+		return err
+	}
+
+	// NOTE: The conflicts of the older configuration files with the new default keybinds are not preventing the editor start
+	if warnings := editor.keybinds.GetWarnings(); len(warnings) > 0 {
+		if err := editor.menu.SetNotificationText(strings.Join(warnings, " ")); err != nil {
+			return err
+		}
+	}
+
+	if err := editor.focusPane(editor.pane); err != nil {
+		return err
+	}
+
+	if err := editor.menuUpdateInformation(); err != nil {
+		return err
+	}
+
+	if err := editor.arrangePanes(); err != nil {
 		return err
 	}
 
@@ -132,6 +177,14 @@ func (editor *Editor) Start() error {
 				}
 			}
 
+		case ConsoleEventPaste:
+			{
+				editorBreak, err := editor.handleConsoleEventPaste(event)
+				if err != nil || editorBreak {
+					return err
+				}
+			}
+
 		case ConsoleEventResize:
 			{
 				editorBreak, err := editor.handleConsoleEventResize(event)
@@ -139,11 +192,120 @@ func (editor *Editor) Start() error {
 					return err
 				}
 			}
+
+		case ConsoleEventMouse:
+			{
+				editorBreak, err := editor.handleConsoleEventMouse(event)
+				if err != nil || editorBreak {
+					return err
+				}
+			}
+		}
+
+		// NOTE: The interrupts are notifying about the lines loaded in the background. The interrupts consumed by the prompts
+		// are not applying the lines, so the loaded lines are checked after every event
+		if err := editor.applyLoadedLines(); err != nil {
+			return err
+		}
+	}
+}
+
+// Helper function used to start loading the remaining lines of the buffer file in the background. The editor loop is
+// interrupted when the loaded lines are ready
+func (editor *Editor) startBufferLoading(buffer *Buffer) error {
+	return buffer.StartLoading(func() { _ = editor.console.Interrupt() })
+}
+
+// Helper function used to append the lines loaded in the background to the buffers. The panes presenting the loading buffers
+// and the menu (loading progress) are redrawn. The loading failure is presented as the menu notification
+func (editor *Editor) applyLoadedLines() error {
+	loadingBuffers := make(map[*Buffer]bool)
+
+	for _, buffer := range editor.buffers {
+		if !buffer.IsLoading() {
+			continue
+		}
+
+		loadingBuffers[buffer] = true
+
+		if _, err := buffer.ApplyLoadedLines(); err != nil {
+			notification := fmt.Sprintf("Failed to load %s completely (%s). The changes are not allowed.", buffer.GetFileName(), err)
+			if err := editor.menu.SetNotificationText(notification); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(loadingBuffers) == 0 {
+		return nil
+	}
+
+	for _, pane := range editor.layout.GetPanes() {
+		if !loadingBuffers[pane.GetBuffer()] {
+			continue
+		}
+
+		if err := pane.GetDisplay().RedrawTextFull(pane.GetBuffer().GetText()); err != nil {
+			return err
 		}
+
+		pane.MarkTextSynchronized()
+	}
+
+	if err := editor.menuUpdateInformation(); err != nil {
+		return err
+	}
+
+	if err := editor.display.RedrawMenu(editor.menu); err != nil {
+		return err
+	}
+
+	return editor.display.RenderChanges()
+}
+
+// Helper function used to check if the focused buffer can be changed. The changes of the partially loaded buffer and the
+// read-only buffer are not allowed, so the user is notified and false is returned
+func (editor *Editor) checkBufferEditable() (bool, error) {
+	buffer := editor.buffers[editor.bufferIndex]
+
+	if buffer.IsLoading() {
+		return false, editor.menu.SetNotificationText("The file is still loading, the changes are not allowed yet.")
+	}
+
+	if !buffer.IsLoaded() {
+		return false, editor.menu.SetNotificationText("The file was not loaded completely, the changes are not allowed.")
+	}
+
+	if buffer.IsReadOnly() {
+		return false, editor.menu.SetNotificationText("The file is read-only, the changes are not allowed.")
+	}
+
+	return true, nil
+}
+
+// Enable or disable the read-only mode of all buffers, including the buffers opened later. The files without the write
+// permission are read-only regardless of the mode
+func (editor *Editor) SetReadOnly(readOnly bool) error {
+	editor.readOnly = readOnly
+
+	for _, buffer := range editor.buffers {
+		buffer.SetReadOnly(readOnly)
+	}
+
+	if err := editor.menuUpdateInformation(); err != nil {
+		return err
+	}
+
+	if err := editor.display.RedrawMenu(editor.menu); err != nil {
+		return err
 	}
+
+	return editor.display.RenderChanges()
 }
 
-// Handling function for the ConsoleEventKeyPress console event. The funcation returns a bool value indicating if the editor loop should be broken
+// Handling function for the ConsoleEventKeyPress console event. The key presses are dispatched to the commands bound by the
+// keymap (including the chords) and the remaining printable keys are inserted as the text. The funcation returns a bool value
+// indicating if the editor loop should be broken
 func (editor *Editor) handleConsoleEventKeyPress(event ConsoleEventKeyPress) (bool, error) {
 	var breakEditorLoop bool = false
 	var err error = nil
@@ -153,85 +315,194 @@ func (editor *Editor) handleConsoleEventKeyPress(event ConsoleEventKeyPress) (bo
 		return false, err
 	}
 
-	// NOTE: The [Ctrl] key modifier was applied
-	if event.Modifier == ModifierCtrl {
-		if event.Key == KeyPrintable {
-			switch event.Char {
-			case editor.keybinds.GetSaveKeybind():
-				err = editor.handleKeybindSave()
-			case editor.keybinds.GetExitKeybind():
-				breakEditorLoop, err = editor.handleKeybindExit()
-			default:
-				err = errors.New("editor: can not handle given input")
-			}
-		} else {
-			switch event.Key {
-			case KeyLeft:
-				err = editor.handleKeysCtrlArrowLeft()
-			case KeyRight:
-				err = editor.handleKeysCtrlArrowRight()
-			default:
-				err = errors.New("editor: can not handle given input")
+	command, result := editor.commands.Dispatch(event)
+
+	switch result {
+	case DispatchCommand:
+		breakEditorLoop, err = command.Execute()
+	case DispatchPending:
+		err = editor.menu.SetNotificationText(fmt.Sprintf("(%s) was pressed. Waiting for the next key...", editor.commands.GetSequenceText()))
+	case DispatchInvalidChord:
+		err = editor.menu.SetNotificationText(fmt.Sprintf("The key combination (%s) is not bound.", editor.commands.GetSequenceText()))
+	case DispatchUnbound:
+		{
+			// NOTE: The [Shift] or none key modifier applied to the printable key is inserting the character
+			if event.Key == KeyPrintable && (event.Modifier == ModifierNone || event.Modifier == ModifierShift) {
+				if editable, editableErr := editor.checkBufferEditable(); editableErr != nil || !editable {
+					err = editableErr
+				} else {
+					err = editor.handleKeyPrintableCharacter(event.Char)
+				}
+			} else {
+				err = editor.menu.SetNotificationText(fmt.Sprintf("The key (%s) is not bound.", editor.commands.GetSequenceText()))
 			}
 		}
+	}
 
+	if err != nil {
+		return false, err
 	}
 
-	// NOTE: The [Alt] key modifier was applied
-	if event.Modifier == ModifierAlt {
-		switch event.Char {
-		default:
-			err = errors.New("editor: can not handle given input")
+	return breakEditorLoop, editor.renderInputChanges()
+}
+
+// Helper function used to register all editor actions as the named commands, so the actions can be dispatched by the keybinds
+// and executed from the command palette
+func (editor *Editor) registerCommands() error {
+	// NOTE: Most of the handlers are not affecting the editor loop, so they are adapted to the command handler signature
+	action := func(handler func() error) func() (bool, error) {
+		return func() (bool, error) {
+			return false, handler()
 		}
 	}
 
-	// NOTE: The [Shift] or none key modifier applied
-	if event.Modifier == ModifierNone || event.Modifier == ModifierShift {
-		switch event.Key {
-		case KeyPrintable:
-			err = editor.handleKeyPrintableCharacter(event.Char)
-		case KeyEnter:
-			err = editor.handleKeyEnter()
-		case KeyBackspace:
-			err = editor.handleKeyBackspace()
-		case KeyDelete:
-			err = editor.handleKeyDelete()
-		case KeyLeft:
-			err = editor.handleKeyLeftArrow()
-		case KeyRight:
-			err = editor.handleKeyRightArrow()
-		case KeyUp:
-			err = editor.handleKeyUpArrow()
-		case KeyDown:
-			err = editor.handleKeyDownArrow()
-		default:
-			err = errors.New("editor: can not handle given input")
+	move := func(movementHandler func() error) func() (bool, error) {
+		return action(func() error { return editor.moveCursor(movementHandler) })
+	}
+
+	selectTo := func(movementHandler func() error) func() (bool, error) {
+		return action(func() error { return editor.extendSelection(movementHandler) })
+	}
+
+	// NOTE: The actions changing the text are not allowed for the read-only buffers and until the file is loaded completely
+	edit := func(handler func() error) func() (bool, error) {
+		return action(func() error {
+			if editable, err := editor.checkBufferEditable(); err != nil || !editable {
+				return err
+			}
+
+			return handler()
+		})
+	}
+
+	commands := []struct {
+		name    string
+		title   string
+		handler func() (bool, error)
+	}{
+		{"cursor-left", "Move cursor left", move(editor.handleKeyLeftArrow)},
+		{"cursor-right", "Move cursor right", move(editor.handleKeyRightArrow)},
+		{"cursor-up", "Move cursor up", move(editor.handleKeyUpArrow)},
+		{"cursor-down", "Move cursor down", move(editor.handleKeyDownArrow)},
+		{"cursor-line-start", "Move cursor to line start", move(editor.handleKeyHome)},
+		{"cursor-line-end", "Move cursor to line end", move(editor.handleKeyEnd)},
+		{"cursor-word-left", "Move cursor to previous word", move(editor.handleKeysCtrlArrowLeft)},
+		{"cursor-word-right", "Move cursor to next word", move(editor.handleKeysCtrlArrowRight)},
+		{"select-left", "Extend selection left", selectTo(editor.handleKeyLeftArrow)},
+		{"select-right", "Extend selection right", selectTo(editor.handleKeyRightArrow)},
+		{"select-up", "Extend selection up", selectTo(editor.handleKeyUpArrow)},
+		{"select-down", "Extend selection down", selectTo(editor.handleKeyDownArrow)},
+		{"select-line-start", "Extend selection to line start", selectTo(editor.handleKeyHome)},
+		{"select-line-end", "Extend selection to line end", selectTo(editor.handleKeyEnd)},
+		{"select-word-left", "Extend selection to previous word", selectTo(editor.handleKeysCtrlArrowLeft)},
+		{"select-word-right", "Extend selection to next word", selectTo(editor.handleKeysCtrlArrowRight)},
+		{"new-line", "Insert new line", edit(editor.handleKeyEnter)},
+		{"delete-backward", "Delete previous character", edit(editor.handleKeyBackspace)},
+		{"delete-forward", "Delete next character", edit(editor.handleKeyDelete)},
+		{"save", "Save", action(editor.handleKeybindSave)},
+		{"save-as", "Save as", action(editor.handleKeybindSaveAs)},
+		{"exit", "Exit", editor.handleKeybindExit},
+		{"undo", "Undo", edit(editor.handleKeybindUndo)},
+		{"redo", "Redo", edit(editor.handleKeybindRedo)},
+		{"copy", "Copy", action(editor.handleKeybindCopy)},
+		{"cut", "Cut", edit(editor.handleKeybindCut)},
+		{"paste", "Paste", edit(editor.handleKeybindPaste)},
+		{"select-all", "Select all", action(editor.handleCommandSelectAll)},
+		{"find", "Find", action(editor.handleKeybindFind)},
+		{"find-next", "Find next match", action(func() error { return editor.handleKeyF3(false) })},
+		{"find-previous", "Find previous match", action(func() error { return editor.handleKeyF3(true) })},
+		{"replace", "Find and replace", edit(editor.handleKeybindReplace)},
+		{"go-to-line", "Go to line", action(editor.handleKeybindGoToLine)},
+		{"toggle-soft-wrap", "Toggle soft wrap", action(editor.handleKeybindSoftWrap)},
+		{"select-theme", "Select theme", action(editor.handleKeybindTheme)},
+		{"file-finder", "Find file", action(editor.handleKeybindFileFinder)},
+		{"next-buffer", "Next buffer", action(editor.handleKeybindNextBuffer)},
+		{"previous-buffer", "Previous buffer", action(editor.handleKeybindPreviousBuffer)},
+		{"buffer-list", "List buffers", action(editor.handleKeybindBufferList)},
+		{"split-vertical", "Split pane vertically", action(func() error { return editor.handleKeybindSplit(SplitVertical) })},
+		{"split-horizontal", "Split pane horizontally", action(func() error { return editor.handleKeybindSplit(SplitHorizontal) })},
+		{"next-pane", "Next pane", action(editor.handleKeybindNextPane)},
+		{"close-pane", "Close pane", action(editor.handleKeybindClosePane)},
+		{"pane-grow-width", "Grow pane width", action(func() error { return editor.handleKeysPaneResize(SplitVertical, true) })},
+		{"pane-shrink-width", "Shrink pane width", action(func() error { return editor.handleKeysPaneResize(SplitVertical, false) })},
+		{"pane-grow-height", "Grow pane height", action(func() error { return editor.handleKeysPaneResize(SplitHorizontal, true) })},
+		{"pane-shrink-height", "Shrink pane height", action(func() error { return editor.handleKeysPaneResize(SplitHorizontal, false) })},
+		{"suspend", "Suspend to shell", action(editor.handleCommandSuspend)},
+		{"command-palette", "Command palette", editor.handleKeybindCommandPalette},
+	}
+
+	for _, command := range commands {
+		if err := editor.commands.Register(command.name, command.title, command.handler); err != nil {
+			return err
 		}
 	}
 
-	if err != nil {
+	return nil
+}
+
+// Helper function used to bind the key sequences of the keymap to the registered commands. The unknown command names and
+// the conflicting key sequences are rejected
+func (editor *Editor) bindCommands() error {
+	for _, binding := range editor.keybinds.GetBindings() {
+		if err := editor.commands.Bind(binding.Sequence, binding.Command); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handling function for the ConsoleEventPaste console event. The pasted text is inserted as a single operation, without
+// triggering any keybinds. The funcation returns a bool value indicating if the editor loop should be broken
+func (editor *Editor) handleConsoleEventPaste(event ConsoleEventPaste) (bool, error) {
+	if err := editor.menu.SetNotificationText(""); err != nil {
+		return false, err
+	}
+
+	if editable, err := editor.checkBufferEditable(); err != nil {
 		return false, err
+	} else if !editable {
+		return false, editor.renderInputChanges()
 	}
 
+	if err := editor.insertText(event.Text); err != nil {
+		return false, err
+	}
+
+	return false, editor.renderInputChanges()
+}
+
+// Helper function used to finish the handling of the user input. The display boundaries are recalculated if the cursor
+// left the visible area, the menu is updated and all pending changes are rendered
+func (editor *Editor) renderInputChanges() error {
 	if !editor.display.CursorInBoundries() {
 		if err := editor.display.RecalculateBoundaries(); err != nil {
-			return false, err
+			return err
 		}
 
 		if err := editor.display.RedrawTextFull(editor.text); err != nil {
-			return false, err
+			return err
 		}
 	}
 
+	// NOTE: The line numbers are depending on the cursor position (relative numbers and the cursor line highlight)
+	if err := editor.display.RedrawGutter(editor.text); err != nil {
+		return err
+	}
+
+	if err := editor.synchronizePanes(); err != nil {
+		return err
+	}
+
 	if err := editor.menuUpdateInformation(); err != nil {
-		return false, err
+		return err
 	}
 
 	if err := editor.display.RedrawMenu(editor.menu); err != nil {
-		return false, err
+		return err
 	}
 
-	return breakEditorLoop, editor.display.RenderChanges()
+	return editor.display.RenderChanges()
 }
 
 // Handling function for the ConsoleEventResize console event. The funcation returns a bool value indicating if the editor loop should be broken
@@ -240,11 +511,14 @@ func (editor *Editor) handleConsoleEventResize(event ConsoleEventResize) (bool,
 		return false, nil
 	}
 
-	if err := editor.display.Resize(event.Width, event.Height); err != nil {
-		return false, err
+	for _, pane := range editor.layout.GetPanes() {
+		if err := pane.GetDisplay().Resize(event.Width, event.Height); err != nil {
+			return false, err
+		}
 	}
 
-	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+	// NOTE: The space is redistributed according to the split ratios, so the panes are keeping the proportions
+	if err := editor.arrangePanes(); err != nil {
 		return false, err
 	}
 
@@ -259,131 +533,475 @@ func (editor *Editor) handleConsoleEventResize(event ConsoleEventResize) (bool,
 	return false, editor.display.RenderChanges()
 }
 
-// Generate string from text structure and create or truncate target file
-func (editor *Editor) SaveChanges() error {
-	file, err := os.Create(editor.filePath)
-	if err != nil {
-		return err
+// Handling function for the ConsoleEventMouse console event. The primary button is placing the cursor inside the pane under the
+// pointer (the double click selects the word and [Shift] extends the selection) and dragging is extending the selection. The wheel
+// is scrolling the pane under the pointer without moving the cursor. The funcation returns a bool value indicating if the editor
+// loop should be broken
+func (editor *Editor) handleConsoleEventMouse(event ConsoleEventMouse) (bool, error) {
+	if event.Wheel != MouseWheelNone {
+		return false, editor.handleMouseWheel(event)
 	}
 
-	textContent, err := editor.text.GetTextAsString()
-	if err != nil {
-		if fileErr := file.Close(); fileErr != nil {
-			return fileErr
-		}
-		return err
+	previousButtons := editor.mouse.buttons
+	editor.mouse.buttons = event.Buttons
+
+	if event.Buttons&MouseButtonPrimary == 0 {
+		editor.mouse.pane = nil
+		return false, nil
 	}
 
-	if _, err := file.WriteString(*textContent); err != nil {
-		if fileErr := file.Close(); fileErr != nil {
-			return fileErr
-		}
-		return err
+	if previousButtons&MouseButtonPrimary != 0 {
+		return false, editor.handleMouseDrag(event)
 	}
 
-	if fileErr := file.Close(); fileErr != nil {
-		return fileErr
+	return false, editor.handleMouseClick(event)
+}
+
+// Helper function used to handle the primary button press. The pane under the pointer is focused and the cursor is placed at
+// the text position under the pointer. The clicks outside of the panes (e.g. the menu or the separators) are ignored
+func (editor *Editor) handleMouseClick(event ConsoleEventMouse) error {
+	pane := editor.getPaneByConsolePosition(event.X, event.Y)
+	if pane == nil {
+		return nil
 	}
 
-	if !editor.fileExists {
-		editor.fileExists = true
+	editor.mouse.pane = pane
+
+	if err := editor.menu.SetNotificationText(""); err != nil {
+		return err
 	}
 
-	return nil
-}
+	if pane != editor.pane {
+		if err := editor.focusPane(pane); err != nil {
+			return err
+		}
+	}
 
-// Helper function used to update the cursor position and file modification informations displayed on the menu widget
-func (editor *Editor) menuUpdateInformation() error {
-	if err := editor.menu.SetCursorPositionText(*editor.cursor); err != nil {
+	xOffset, yOffset, err := editor.display.GetTextOffsetsByConsolePosition(event.X, event.Y)
+	if err != nil {
 		return err
 	}
 
-	if err := editor.menu.SetFileModificationState(editor.text.IsModified()); err != nil {
+	doubleClick := time.Since(editor.mouse.clickTime) <= mouseDoubleClickInterval && event.X == editor.mouse.xClick && event.Y == editor.mouse.yClick
+
+	editor.mouse.clickTime = time.Now()
+	editor.mouse.xClick = event.X
+	editor.mouse.yClick = event.Y
+
+	setOffsets := func() error {
+		return editor.cursor.SetOffsets(xOffset, yOffset)
+	}
+
+	switch {
+	case doubleClick:
+		{
+			// NOTE: The following click is not treated as the next double click
+			editor.mouse.clickTime = time.Time{}
+			err = editor.selectWord(xOffset, yOffset)
+		}
+	case event.Modifier&ModifierShift != 0:
+		err = editor.extendSelection(setOffsets)
+	default:
+		err = editor.moveCursor(setOffsets)
+	}
+
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return editor.renderInputChanges()
 }
 
-// Helper function creates a ,,confirmation prompt”. The message is displayed as the menu notification and the
-// program input is intercepted. The function will return true on confirm [T] or false on cancle [N]. The function
-// is also intercepting the resize event to make sure the UI beahaviour stays correct.
-func (editor *Editor) menuPrompt(notification string) (bool, error) {
-	message := fmt.Sprintf("%s [Y] [N]", notification)
+// Helper function used to handle the pointer movement with the primary button pressed. The selection is extended to the text
+// position under the pointer. The positions above and below the pane are scrolling the pane
+func (editor *Editor) handleMouseDrag(event ConsoleEventMouse) error {
+	if editor.mouse.pane == nil || editor.mouse.pane != editor.pane {
+		return nil
+	}
 
-	width, _ := editor.display.GetFullDisplaySize()
-	if len(message) > width {
-		message = "[Y/N]"
+	xOffset, yOffset, err := editor.display.GetTextOffsetsByConsolePosition(event.X, event.Y)
+	if err != nil {
+		return err
 	}
 
-	if err := editor.menu.SetNotificationText(message); err != nil {
-		return false, err
+	if xOffset == editor.cursor.GetOffsetX() && yOffset == editor.cursor.GetOffsetY() {
+		return nil
 	}
 
-	if err := editor.display.RedrawMenu(editor.menu); err != nil {
-		return false, err
+	if err := editor.extendSelection(func() error { return editor.cursor.SetOffsets(xOffset, yOffset) }); err != nil {
+		return err
 	}
 
-	if err := editor.display.RenderChanges(); err != nil {
-		return false, err
+	return editor.renderInputChanges()
+}
+
+// Helper function used to handle the mouse wheel. The pane under the pointer is scrolled vertically, the cursor position and
+// the focus are not changed
+func (editor *Editor) handleMouseWheel(event ConsoleEventMouse) error {
+	var rowShift int
+
+	switch event.Wheel {
+	case MouseWheelUp:
+		rowShift = -mouseWheelScrollRows
+	case MouseWheelDown:
+		rowShift = mouseWheelScrollRows
+	default:
+		return nil
 	}
 
-	resultValue := false
-	resultReady := false
+	pane := editor.getPaneByConsolePosition(event.X, event.Y)
+	if pane == nil {
+		return nil
+	}
 
-	for {
-		ev := editor.console.WatchConsoleEvent()
-		switch event := ev.(type) {
+	display := pane.GetDisplay()
 
-		case ConsoleEventKeyPress:
-			{
-				if event.Char == 't' || event.Char == 'T' {
-					resultValue = true
-					resultReady = true
-				}
+	changed, err := display.ScrollBoundaries(rowShift)
+	if err != nil || !changed {
+		return err
+	}
 
-				if event.Char == 'n' || event.Char == 'N' {
-					resultValue = false
-					resultReady = true
-				}
+	if err := display.RedrawTextFull(pane.GetBuffer().GetText()); err != nil {
+		return err
+	}
 
-				if event.Modifier == ModifierCtrl && event.Char == 'c' {
-					resultValue = false
-					resultReady = true
-				}
+	return editor.display.RenderChanges()
+}
 
-				if resultReady {
-					if err := editor.menu.SetNotificationText(""); err != nil {
-						return false, err
-					}
+// Helper function used to select the word placed at the given offsets (e.g. after the double click). The cursor is placed at the
+// end of the word
+func (editor *Editor) selectWord(xOffset int, yOffset int) error {
+	xStart, xEnd, err := editor.text.GetWordRangeByOffsets(xOffset, yOffset)
+	if err != nil {
+		return err
+	}
 
-					if err := editor.display.RedrawMenu(editor.menu); err != nil {
-						return false, err
-					}
+	editor.selection.Clear()
 
-					if err := editor.display.RenderChanges(); err != nil {
-						return false, err
-					}
+	if err := editor.cursor.SetOffsets(xStart, yOffset); err != nil {
+		return err
+	}
 
-					return resultValue, nil
-				}
+	editor.selection.Start()
 
-			}
+	if err := editor.cursor.SetOffsets(xEnd, yOffset); err != nil {
+		return err
+	}
 
-		// NOTE: The inner editor loop is also handling the resize event to avoid UI glitches
-		// on resizing during an active prompt.
-		case ConsoleEventResize:
-			{
-				editorBreak, err := editor.handleConsoleEventResize(event)
-				if err != nil || editorBreak {
-					return false, err
-				}
-			}
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// Helper function used to find the pane presented at the given console position. Returns nil if no pane is presented at the position
+func (editor *Editor) getPaneByConsolePosition(xIndex int, yIndex int) *Pane {
+	for _, pane := range editor.layout.GetPanes() {
+		if pane.ContainsConsolePosition(xIndex, yIndex) {
+			return pane
 		}
 	}
-}
 
-// NOTE: This section contains all the key-specific handler functions
+	return nil
+}
+
+// Save the changes of the active buffer by replacing the content of the target file atomically
+func (editor *Editor) SaveChanges() error {
+	return editor.buffers[editor.bufferIndex].Save()
+}
+
+// Helper function used to update the cursor position and file modification informations displayed on the menu widget
+func (editor *Editor) menuUpdateInformation() error {
+	if err := editor.menu.SetCursorPositionText(*editor.cursor); err != nil {
+		return err
+	}
+
+	if err := editor.menu.SetFileModificationState(editor.text.IsModified()); err != nil {
+		return err
+	}
+
+	buffer := editor.buffers[editor.bufferIndex]
+	if err := editor.menu.SetFileReadOnlyState(buffer.IsReadOnly()); err != nil {
+		return err
+	}
+
+	if err := editor.menu.SetLoadingProgressText(buffer.IsLoading(), buffer.GetLoadingProgress()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Type representing the result of the menu input key handler
+type menuInputAction int16
+
+const (
+	// NOTE: The key was not handled, the default input field behaviour is applied
+	menuInputUnhandled menuInputAction = iota
+	menuInputHandled
+	menuInputConfirm
+	menuInputCancel
+)
+
+// Helper function creates an ,,input prompt”. The label and the editable value are displayed inside the menu and the program
+// input is intercepted. Every key press is passed to the key handler first and only the unhandled keys are editing the value
+// ([Enter] confirms, [Esc] or [Ctrl] + [C] cancels). The change handler is called after every value change and after the console
+// interrupt event. The function returns
+// the value and a bool value indicating if the input was confirmed. The function is also intercepting the resize event to make
+// sure the UI beahaviour stays correct.
+func (editor *Editor) menuInput(label string, value string, keyHandler func(ConsoleEventKeyPress) (menuInputAction, error), changeHandler func(string) error) (string, bool, error) {
+	if err := editor.menu.StartInput(label, value); err != nil {
+		return "", false, err
+	}
+
+	if err := editor.redrawMenuInput(); err != nil {
+		return "", false, err
+	}
+
+	for {
+		action := menuInputHandled
+		valueChanged := false
+
+		ev := editor.console.WatchConsoleEvent()
+		switch event := ev.(type) {
+
+		case ConsoleEventKeyPress:
+			{
+				var err error = nil
+
+				action = menuInputUnhandled
+				if keyHandler != nil {
+					action, err = keyHandler(event)
+					if err != nil {
+						return "", false, err
+					}
+				}
+
+				if action == menuInputUnhandled {
+					action, valueChanged, err = editor.handleMenuInputKey(event)
+					if err != nil {
+						return "", false, err
+					}
+				}
+			}
+
+		case ConsoleEventPaste:
+			{
+				for _, char := range event.Text {
+					if char == '\n' {
+						break
+					}
+
+					if err := editor.menu.InsertInputCharacter(char); err != nil {
+						return "", false, err
+					}
+
+					valueChanged = true
+				}
+			}
+
+		// NOTE: The interrupt is notifying about the changes made by a background task, so the change handler is called
+		// to refresh the presented state
+		case ConsoleEventInterrupt:
+			valueChanged = true
+
+		// NOTE: The inner editor loop is also handling the resize event to avoid UI glitches
+		// on resizing during an active prompt.
+		case ConsoleEventResize:
+			{
+				editorBreak, err := editor.handleConsoleEventResize(event)
+				if err != nil || editorBreak {
+					return "", false, err
+				}
+			}
+		}
+
+		if action == menuInputConfirm || action == menuInputCancel {
+			inputValue := editor.menu.GetInputValue()
+
+			if err := editor.menu.StopInput(); err != nil {
+				return "", false, err
+			}
+
+			if err := editor.display.RedrawMenu(editor.menu); err != nil {
+				return "", false, err
+			}
+
+			if err := editor.display.RenderChanges(); err != nil {
+				return "", false, err
+			}
+
+			return inputValue, action == menuInputConfirm, nil
+		}
+
+		if valueChanged && changeHandler != nil {
+			if err := changeHandler(editor.menu.GetInputValue()); err != nil {
+				return "", false, err
+			}
+		}
+
+		if err := editor.redrawMenuInput(); err != nil {
+			return "", false, err
+		}
+	}
+}
+
+// Helper function used to apply the default input field behaviour for the given key press. The function returns the
+// action resulting from the key press and a bool value indicating if the input value was changed
+func (editor *Editor) handleMenuInputKey(event ConsoleEventKeyPress) (menuInputAction, bool, error) {
+	if event.Modifier == ModifierCtrl && event.Key == KeyPrintable && event.Char == 'c' {
+		return menuInputCancel, false, nil
+	}
+
+	if event.Modifier != ModifierNone && event.Modifier != ModifierShift {
+		return menuInputHandled, false, nil
+	}
+
+	position := editor.menu.GetInputCursorPosition()
+
+	switch event.Key {
+	case KeyEnter:
+		return menuInputConfirm, false, nil
+	case KeyEscape:
+		return menuInputCancel, false, nil
+	case KeyPrintable:
+		return menuInputHandled, true, editor.menu.InsertInputCharacter(event.Char)
+	case KeyBackspace:
+		{
+			removed, err := editor.menu.RemoveInputCharacterHead()
+			return menuInputHandled, removed, err
+		}
+	case KeyDelete:
+		{
+			removed, err := editor.menu.RemoveInputCharacterTail()
+			return menuInputHandled, removed, err
+		}
+	case KeyLeft:
+		return menuInputHandled, false, editor.menu.SetInputCursorPosition(position - 1)
+	case KeyRight:
+		return menuInputHandled, false, editor.menu.SetInputCursorPosition(position + 1)
+	case KeyHome:
+		return menuInputHandled, false, editor.menu.SetInputCursorPosition(0)
+	case KeyEnd:
+		return menuInputHandled, false, editor.menu.SetInputCursorPosition(len(editor.menu.GetInputValue()))
+	default:
+		return menuInputHandled, false, nil
+	}
+}
+
+// Helper function used to redraw the menu in the input mode and place the console cursor inside the input
+func (editor *Editor) redrawMenuInput() error {
+	if err := editor.display.RedrawMenu(editor.menu); err != nil {
+		return err
+	}
+
+	return editor.display.RenderChangesWithMenuInput(editor.menu)
+}
+
+// Helper function creates a ,,choice prompt”. The message together with the available choices is displayed as the menu input and
+// the program input is intercepted. The function returns the selected choice (lower case) or zero if the prompt was cancelled
+func (editor *Editor) menuChoice(notification string, choices []rune) (rune, error) {
+	choicesBuilder := strings.Builder{}
+	for _, choice := range choices {
+		choicesBuilder.WriteString(fmt.Sprintf("[%c] ", unicode.ToUpper(choice)))
+	}
+
+	message := fmt.Sprintf("%s %s", notification, choicesBuilder.String())
+
+	width, _ := editor.display.GetFullDisplaySize()
+	if len(message) > width {
+		message = choicesBuilder.String()
+	}
+
+	var selectedChoice rune = 0
+
+	_, confirmed, err := editor.menuInput(message, "", func(event ConsoleEventKeyPress) (menuInputAction, error) {
+		if event.Key == KeyEscape || (event.Modifier == ModifierCtrl && event.Char == 'c') {
+			return menuInputUnhandled, nil
+		}
+
+		if event.Key != KeyPrintable || event.Modifier&ModifierCtrl != 0 {
+			return menuInputHandled, nil
+		}
+
+		for _, choice := range choices {
+			if unicode.ToLower(event.Char) == choice {
+				selectedChoice = choice
+				return menuInputConfirm, nil
+			}
+		}
+
+		return menuInputHandled, nil
+	}, nil)
+
+	if err != nil || !confirmed {
+		return 0, err
+	}
+
+	return selectedChoice, nil
+}
+
+// Helper function creates a ,,confirmation prompt”. The function will return true on confirm [Y] or false on cancel [N]
+func (editor *Editor) menuPrompt(notification string) (bool, error) {
+	choice, err := editor.menuChoice(notification, []rune{'y', 'n'})
+	if err != nil {
+		return false, err
+	}
+
+	return choice == 'y', nil
+}
+
+// Helper function used to move the cursor using the given movement handler. The current selection is dropped
+func (editor *Editor) moveCursor(movementHandler func() error) error {
+	selectionActive := editor.selection.IsActive()
+	editor.selection.Clear()
+
+	if err := movementHandler(); err != nil {
+		return err
+	}
+
+	if selectionActive {
+		return editor.display.RedrawTextFull(editor.text)
+	}
+
+	return nil
+}
+
+// Helper function used to move the cursor using the given movement handler. The selection is started or extended
+func (editor *Editor) extendSelection(movementHandler func() error) error {
+	editor.selection.Start()
+
+	if err := movementHandler(); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// Helper function used to remove the selected text and place the cursor at the selection start. The function returns
+// a bool value indicating if any text was removed
+func (editor *Editor) removeSelection() (bool, error) {
+	if !editor.selection.IsActive() {
+		editor.selection.Clear()
+		return false, nil
+	}
+
+	xStart, yStart, xEnd, yEnd := editor.selection.GetRange()
+	editor.selection.Clear()
+
+	if err := editor.text.RemoveRange(xStart, yStart, xEnd, yEnd); err != nil {
+		return false, err
+	}
+
+	if err := editor.cursor.SetOffsets(xStart, yStart); err != nil {
+		return false, err
+	}
+
+	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// NOTE: This section contains all the key-specific handler functions
 
 // [<] Handle left arrow key. Handling the movement of the cursor to the left, considering both x and y axis
 func (editor *Editor) handleKeyLeftArrow() error {
@@ -454,6 +1072,11 @@ func (editor *Editor) handleKeyRightArrow() error {
 
 // [/\] Handle up arrow key. Handling the movement of the cursor to the line above, considering both y and x axis
 func (editor *Editor) handleKeyUpArrow() error {
+	// NOTE: In the soft wrap mode the cursor is moved by the visual rows of the wrapped lines
+	if editor.display.IsWrapEnabled() {
+		return editor.display.MoveCursorByVisualRows(-1)
+	}
+
 	yOffset := editor.cursor.GetOffsetY()
 	if yOffset == 0 {
 		return nil
@@ -483,6 +1106,10 @@ func (editor *Editor) handleKeyUpArrow() error {
 
 // [\/] Handle down arrow key. Handling the movement of the cursor to the line below, considering both y and x axis
 func (editor *Editor) handleKeyDownArrow() error {
+	if editor.display.IsWrapEnabled() {
+		return editor.display.MoveCursorByVisualRows(1)
+	}
+
 	yOffset := editor.cursor.GetOffsetY()
 	if yOffset == editor.text.GetLineCount()-1 {
 		return nil
@@ -512,6 +1139,13 @@ func (editor *Editor) handleKeyDownArrow() error {
 
 // [Enter] Handle line breaking via the enter key.
 func (editor *Editor) handleKeyEnter() error {
+	editor.history.BeginGroup()
+	defer editor.history.EndGroup()
+
+	if _, err := editor.removeSelection(); err != nil {
+		return err
+	}
+
 	if err := editor.text.InsertLine(editor.cursor); err != nil {
 		return err
 	}
@@ -530,6 +1164,10 @@ func (editor *Editor) handleKeyEnter() error {
 
 // [Backspace] Handle character removing via the backspace key
 func (editor *Editor) handleKeyBackspace() error {
+	if removed, err := editor.removeSelection(); err != nil || removed {
+		return err
+	}
+
 	xOffset := editor.cursor.GetOffsetX()
 	yOffset := editor.cursor.GetOffsetY()
 
@@ -582,6 +1220,10 @@ func (editor *Editor) handleKeyBackspace() error {
 
 // [Delete] Handle character removing via the backsapce key
 func (editor *Editor) handleKeyDelete() error {
+	if removed, err := editor.removeSelection(); err != nil || removed {
+		return err
+	}
+
 	xOffset := editor.cursor.GetOffsetX()
 	yOffset := editor.cursor.GetOffsetY()
 
@@ -622,6 +1264,17 @@ func (editor *Editor) handleKeyDelete() error {
 
 // [ASCII 0x20 - 0x7E] Handle printable character insertion.
 func (editor *Editor) handleKeyPrintableCharacter(char rune) error {
+	// NOTE: The group is only joining the removal of the selection with the inserted character. The group is not used without
+	// the selection, so the consecutive typed characters are merged into a single step by the history
+	if editor.selection.IsActive() {
+		editor.history.BeginGroup()
+		defer editor.history.EndGroup()
+	}
+
+	if _, err := editor.removeSelection(); err != nil {
+		return err
+	}
+
 	if err := editor.text.InsertCharacter(char, editor.cursor); err != nil {
 		return err
 	}
@@ -638,6 +1291,21 @@ func (editor *Editor) handleKeyPrintableCharacter(char rune) error {
 	return nil
 }
 
+// [Home] Handle the movement of the cursor to the start of the line
+func (editor *Editor) handleKeyHome() error {
+	return editor.cursor.SetOffsetX(0)
+}
+
+// [End] Handle the movement of the cursor to the end of the line
+func (editor *Editor) handleKeyEnd() error {
+	xLength, err := editor.text.GetLineLengthByCursor(editor.cursor)
+	if err != nil {
+		return err
+	}
+
+	return editor.cursor.SetOffsetX(xLength)
+}
+
 // [Ctrl] + [<] Handle multi-key left jump to next word
 func (editor *Editor) handleKeysCtrlArrowLeft() error {
 	xOffset := editor.cursor.GetOffsetX()
@@ -721,42 +1389,1507 @@ func (editor *Editor) handleKeysCtrlArrowRight() error {
 	return editor.cursor.SetOffsetX(currentXLength)
 }
 
-// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle file save keybind
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle file save keybind. The read-only buffer can only be saved
+// as a different file. The save failure is presented as the menu notification
 func (editor *Editor) handleKeybindSave() error {
-	if err := editor.SaveChanges(); err != nil {
-		return err
+	buffer := editor.buffers[editor.bufferIndex]
+
+	if buffer.IsLoaded() && buffer.IsReadOnly() {
+		saveAs, err := editor.menuPrompt(fmt.Sprintf("The file %s is read-only. Save as a different file?", buffer.GetFileName()))
+		if err != nil || !saveAs {
+			return err
+		}
+
+		return editor.saveBufferAs()
 	}
 
-	if err := editor.menu.SetNotificationText("Changes saved successful."); err != nil {
+	if editable, err := editor.checkBufferEditable(); err != nil || !editable {
 		return err
 	}
 
-	if err := editor.text.ResetModificationState(); err != nil {
+	if err := editor.SaveChanges(); err != nil {
+		return editor.menu.SetNotificationText(fmt.Sprintf("Failed to save the changes (%s).", err))
+	}
+
+	return editor.menu.SetNotificationText("Changes saved successful.")
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle save as keybind. The buffer can be saved as a different file
+// only if the file is loaded completely, including the read-only buffers
+func (editor *Editor) handleKeybindSaveAs() error {
+	if !editor.buffers[editor.bufferIndex].IsLoaded() {
+		_, err := editor.checkBufferEditable()
 		return err
 	}
 
-	return nil
+	return editor.saveBufferAs()
 }
 
-// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle program exit keybind. The funcation
-// is returning a bool value that idicates if the program loop should be broken.
-func (editor *Editor) handleKeybindExit() (bool, error) {
-	if !editor.text.IsModified() {
-		return true, nil
+// Helper function used to save the focused buffer as a different file. The file path is entered inside the menu input, which
+// is prefilled with the current file path. [Tab] completes the path with the names of the matching files and directories (the
+// ambiguous names are listed next to the input). The user is asked to confirm overwriting an existing file and to create the
+// missing parent directories. The save failure is presented as the menu notification
+func (editor *Editor) saveBufferAs() error {
+	buffer := editor.buffers[editor.bufferIndex]
+
+	keyHandler := func(event ConsoleEventKeyPress) (menuInputAction, error) {
+		if event.Key != KeyTab {
+			return menuInputUnhandled, nil
+		}
+
+		completedPath, names, err := CompletePath(editor.menu.GetInputValue())
+		if err != nil || len(names) == 0 {
+			return menuInputHandled, editor.menu.SetInputStatusText("No matching files")
+		}
+
+		if err := editor.menu.SetInputValue(completedPath); err != nil {
+			return menuInputHandled, err
+		}
+
+		if len(names) == 1 {
+			return menuInputHandled, editor.menu.SetInputStatusText("")
+		}
+
+		return menuInputHandled, editor.menu.SetInputStatusText(strings.Join(names, " "))
 	}
 
-	result, err := editor.menuPrompt("Save pending changes?")
-	if err != nil {
-		return false, err
+	changeHandler := func(value string) error {
+		return editor.menu.SetInputStatusText("")
 	}
 
-	if !result {
-		return false, nil
+	filePath, confirmed, err := editor.menuInput("Save as: ", buffer.GetFilePath(), keyHandler, changeHandler)
+	if err != nil || !confirmed {
+		return err
 	}
 
-	if err := editor.SaveChanges(); err != nil {
-		return false, err
+	if len(strings.TrimSpace(filePath)) == 0 {
+		return editor.menu.SetNotificationText("No file path specified.")
 	}
 
-	return true, nil
+	sameFile := false
+	if absolutePath, err := filepath.Abs(filePath); err == nil {
+		if bufferPath, err := filepath.Abs(buffer.GetFilePath()); err == nil && bufferPath == absolutePath {
+			sameFile = true
+		}
+	}
+
+	if fileInfo, err := os.Stat(filePath); err == nil {
+		if fileInfo.IsDir() {
+			return editor.menu.SetNotificationText(fmt.Sprintf("The path %s is a directory.", filePath))
+		}
+
+		if !sameFile {
+			overwrite, err := editor.menuPrompt(fmt.Sprintf("The file %s already exists. Overwrite?", filepath.Base(filePath)))
+			if err != nil || !overwrite {
+				return err
+			}
+		}
+	} else if errors.Is(err, os.ErrNotExist) {
+		directory := filepath.Dir(filePath)
+
+		if _, err := os.Stat(directory); errors.Is(err, os.ErrNotExist) {
+			create, err := editor.menuPrompt(fmt.Sprintf("The directory %s does not exist. Create it?", directory))
+			if err != nil || !create {
+				return err
+			}
+
+			if err := os.MkdirAll(directory, 0755); err != nil {
+				return editor.menu.SetNotificationText(fmt.Sprintf("Failed to create the directory (%s).", err))
+			}
+		}
+	}
+
+	if err := buffer.SaveAs(filePath); err != nil {
+		return editor.menu.SetNotificationText(fmt.Sprintf("Failed to save the changes (%s).", err))
+	}
+
+	if err := editor.menu.SetFileName(buffer.GetFileName()); err != nil {
+		return err
+	}
+
+	if err := editor.menu.SetEndOfLineSequenceName(buffer.GetText().GetEndOfLineSequenceName()); err != nil {
+		return err
+	}
+
+	// NOTE: The highlighted language could be changed by the new file name
+	if err := editor.redrawPanes(); err != nil {
+		return err
+	}
+
+	return editor.menu.SetNotificationText(fmt.Sprintf("Changes saved as %s.", buffer.GetFileName()))
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle program exit keybind. The user is asked to save every
+// buffer with pending changes, [Y] saves the buffer, [N] discards the changes and [C] cancels the exit. The funcation
+// is returning a bool value that idicates if the program loop should be broken.
+func (editor *Editor) handleKeybindExit() (bool, error) {
+	for index, buffer := range editor.buffers {
+		if !buffer.IsModified() {
+			continue
+		}
+
+		// NOTE: The buffer is displayed to indicate which changes are affected by the prompt
+		if index != editor.bufferIndex {
+			if err := editor.switchBuffer(index); err != nil {
+				return false, err
+			}
+
+			if err := editor.renderInputChanges(); err != nil {
+				return false, err
+			}
+		}
+
+		choice, err := editor.menuChoice(fmt.Sprintf("Save pending changes in %s?", buffer.GetFileName()), []rune{'y', 'n', 'c'})
+		if err != nil {
+			return false, err
+		}
+
+		switch choice {
+		case 'y':
+			// NOTE: The exit is cancelled if the changes could not be saved, so the changes are not lost
+			if err := buffer.Save(); err != nil {
+				return false, editor.menu.SetNotificationText(fmt.Sprintf("Failed to save the changes (%s).", err))
+			}
+		case 'n':
+			continue
+		default:
+			return false, nil
+		}
+	}
+
+	return true, editor.closeBuffers()
+}
+
+// Helper function used to stop loading the buffer files in the background and close the files referenced by the buffers
+func (editor *Editor) closeBuffers() error {
+	for _, buffer := range editor.buffers {
+		if err := buffer.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle undo keybind. Revert the latest text changes and restore the cursor position
+func (editor *Editor) handleKeybindUndo() error {
+	editor.selection.Clear()
+
+	if !editor.history.CanUndo() {
+		return editor.menu.SetNotificationText("Nothing to undo.")
+	}
+
+	xOffset, yOffset, err := editor.text.Undo()
+	if err != nil {
+		return err
+	}
+
+	if err := editor.cursor.SetOffsets(xOffset, yOffset); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle redo keybind. Reapply the latest reverted text changes and restore the cursor position
+func (editor *Editor) handleKeybindRedo() error {
+	editor.selection.Clear()
+
+	if !editor.history.CanRedo() {
+		return editor.menu.SetNotificationText("Nothing to redo.")
+	}
+
+	xOffset, yOffset, err := editor.text.Redo()
+	if err != nil {
+		return err
+	}
+
+	if err := editor.cursor.SetOffsets(xOffset, yOffset); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle copy keybind. The selection or the current line (if nothing is selected) is copied to the clipboard
+func (editor *Editor) handleKeybindCopy() error {
+	content, err := editor.getClipboardTargetContent()
+	if err != nil {
+		return err
+	}
+
+	if err := editor.clipboard.SetContent(content); err != nil {
+		return editor.menu.SetNotificationText("Failed to copy to the clipboard.")
+	}
+
+	return editor.menu.SetNotificationText("Copied to the clipboard.")
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle cut keybind. The selection or the current line (if nothing is selected) is moved to the clipboard
+func (editor *Editor) handleKeybindCut() error {
+	content, err := editor.getClipboardTargetContent()
+	if err != nil {
+		return err
+	}
+
+	if err := editor.clipboard.SetContent(content); err != nil {
+		return editor.menu.SetNotificationText("Failed to copy to the clipboard.")
+	}
+
+	if editor.selection.IsActive() {
+		_, err := editor.removeSelection()
+		return err
+	}
+
+	yOffset := editor.cursor.GetOffsetY()
+	yOffsetMax := editor.text.GetLineCount() - 1
+
+	xLength, err := editor.text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The line is removed together with the following line break. The last line is removed
+	// together with the preceding line break, and the only line of the text is just cleared
+	switch {
+	case yOffset < yOffsetMax:
+		err = editor.text.RemoveRange(0, yOffset, 0, yOffset+1)
+	case yOffset > 0:
+		{
+			xPreviousLength, lengthErr := editor.text.GetLineLengthByOffset(yOffset - 1)
+			if lengthErr != nil {
+				return lengthErr
+			}
+
+			err = editor.text.RemoveRange(xPreviousLength, yOffset-1, xLength, yOffset)
+			yOffset -= 1
+		}
+	default:
+		err = editor.text.RemoveRange(0, yOffset, xLength, yOffset)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := editor.cursor.SetOffsets(0, yOffset); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle paste keybind. The clipboard content replaces the selection or is inserted at the cursor position
+func (editor *Editor) handleKeybindPaste() error {
+	content, err := editor.clipboard.GetContent()
+	if err != nil {
+		return editor.menu.SetNotificationText("Failed to paste from the clipboard.")
+	}
+
+	return editor.insertText(content)
+}
+
+// Helper function used to insert the given (multi-line) text as a single operation. The text is replacing the current selection
+func (editor *Editor) insertText(content string) error {
+	editor.history.BeginGroup()
+	defer editor.history.EndGroup()
+
+	if _, err := editor.removeSelection(); err != nil {
+		return err
+	}
+
+	xOffset, yOffset, err := editor.text.InsertText(content, editor.cursor)
+	if err != nil {
+		return err
+	}
+
+	if err := editor.cursor.SetOffsets(xOffset, yOffset); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// Helper function used to retrieve the content affected by the clipboard operations. It is the selected text or the current line including the line break
+func (editor *Editor) getClipboardTargetContent() (string, error) {
+	if editor.selection.IsActive() {
+		content, err := editor.text.GetRangeAsString(editor.selection.GetRange())
+		if err != nil {
+			return "", err
+		}
+
+		return *content, nil
+	}
+
+	yOffset := editor.cursor.GetOffsetY()
+
+	xLength, err := editor.text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := editor.text.GetRangeAsString(0, yOffset, xLength, yOffset)
+	if err != nil {
+		return "", err
+	}
+
+	return *content + "\n", nil
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle go-to-line keybind. The prompt accepts the line number, the line and
+// column number separated by a colon, a relative line shift (+10 or -10) or the $ character representing the last line
+func (editor *Editor) handleKeybindGoToLine() error {
+	if err := editor.menu.SetInputStatusText(fmt.Sprintf("line 1-%d | line[:column], +n, -n or $", editor.text.GetLineCount())); err != nil {
+		return err
+	}
+
+	value, confirmed, err := editor.menuInput("Go to line: ", "", nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return nil
+	}
+
+	return editor.goToPosition(value)
+}
+
+// Move the cursor to the position specified by the given string (see handleKeybindGoToLine for the supported formats) and
+// render the changes. This function is used to open the file at the position specified in the command line. An invalid position
+// is reported with a notification
+func (editor *Editor) GoToPosition(value string) error {
+	if err := editor.goToPosition(value); err != nil {
+		return err
+	}
+
+	if err := editor.menuUpdateInformation(); err != nil {
+		return err
+	}
+
+	if err := editor.display.RedrawMenu(editor.menu); err != nil {
+		return err
+	}
+
+	return editor.display.RenderChanges()
+}
+
+// Helper function used to parse the given position string, move the cursor and center the viewport at the new position
+func (editor *Editor) goToPosition(value string) error {
+	position, err := ParsePosition(value, editor.cursor.GetOffsetY()+1, editor.text.GetLineCount())
+	if err != nil {
+		return editor.menu.SetNotificationText(fmt.Sprintf("Invalid position: %s", value))
+	}
+
+	yOffset := position.Line - 1
+
+	lineLength, err := editor.text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return err
+	}
+
+	xOffset := 0
+	if position.Column > 0 {
+		// NOTE: The column placed directly after the last character of the line is allowed
+		if position.Column > lineLength+1 {
+			return editor.menu.SetNotificationText(fmt.Sprintf("Invalid position: line %d has %d column(s)", position.Line, lineLength+1))
+		}
+
+		xOffset = position.Column - 1
+	}
+
+	editor.selection.Clear()
+
+	if err := editor.cursor.SetOffsets(xOffset, yOffset); err != nil {
+		return err
+	}
+
+	if err := editor.display.CenterBoundaries(); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle soft wrap keybind. Toggle between wrapping the long lines into
+// multiple visual rows and scrolling them horizontally
+func (editor *Editor) handleKeybindSoftWrap() error {
+	if err := editor.display.SetWrapEnabled(!editor.display.IsWrapEnabled()); err != nil {
+		return err
+	}
+
+	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+		return err
+	}
+
+	if editor.display.IsWrapEnabled() {
+		return editor.menu.SetNotificationText("Soft wrap enabled.")
+	}
+
+	return editor.menu.SetNotificationText("Soft wrap disabled.")
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle theme keybind. The theme name is entered in the menu prompt,
+// [Tab] and [Shift] + [Tab] are cycling through the available themes. The matching theme is previewed while typing and the
+// previous theme is restored if the prompt is cancelled
+func (editor *Editor) handleKeybindTheme() error {
+	themeNames := editor.themes.GetNames()
+	originalThemeName := editor.themes.GetCurrentName()
+
+	keyHandler := func(event ConsoleEventKeyPress) (menuInputAction, error) {
+		if event.Key != KeyTab && event.Key != KeyBacktab {
+			return menuInputUnhandled, nil
+		}
+
+		nameIndex := 0
+		for index, name := range themeNames {
+			if name == editor.menu.GetInputValue() {
+				nameIndex = index + 1
+				if event.Key == KeyBacktab || event.Modifier == ModifierShift {
+					nameIndex = index - 1 + len(themeNames)
+				}
+			}
+		}
+
+		nextName := themeNames[nameIndex%len(themeNames)]
+		if err := editor.menu.SetInputValue(nextName); err != nil {
+			return menuInputHandled, err
+		}
+
+		return menuInputHandled, editor.applyTheme(nextName)
+	}
+
+	changeHandler := func(name string) error {
+		return editor.applyTheme(name)
+	}
+
+	if err := editor.menu.SetInputStatusText(fmt.Sprintf("Tab: next | %s", strings.Join(themeNames, ", "))); err != nil {
+		return err
+	}
+
+	name, confirmed, err := editor.menuInput("Theme: ", originalThemeName, keyHandler, changeHandler)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed || editor.themes.Select(name) != nil {
+		if err := editor.applyTheme(originalThemeName); err != nil {
+			return err
+		}
+
+		if confirmed {
+			return editor.menu.SetNotificationText(fmt.Sprintf("Unknown theme: %s", name))
+		}
+
+		return nil
+	}
+
+	return editor.menu.SetNotificationText(fmt.Sprintf("Theme changed to: %s", name))
+}
+
+// Helper function used to select the theme with the given name and redraw the text. Names of not existing themes are ignored
+func (editor *Editor) applyTheme(name string) error {
+	if err := editor.themes.Select(name); err != nil {
+		return nil
+	}
+
+	for _, pane := range editor.layout.GetPanes() {
+		if err := pane.GetDisplay().SetTheme(editor.themes.GetCurrent()); err != nil {
+			return err
+		}
+	}
+
+	return editor.redrawPanes()
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] or [Ctrl] + [PgDn] Handle next buffer keybind. The first buffer
+// is selected after the last one
+func (editor *Editor) handleKeybindNextBuffer() error {
+	if len(editor.buffers) == 1 {
+		return editor.menu.SetNotificationText("No other buffers are open.")
+	}
+
+	return editor.switchBuffer((editor.bufferIndex + 1) % len(editor.buffers))
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] or [Ctrl] + [PgUp] Handle previous buffer keybind. The last buffer
+// is selected before the first one
+func (editor *Editor) handleKeybindPreviousBuffer() error {
+	if len(editor.buffers) == 1 {
+		return editor.menu.SetNotificationText("No other buffers are open.")
+	}
+
+	return editor.switchBuffer((editor.bufferIndex - 1 + len(editor.buffers)) % len(editor.buffers))
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle buffer list keybind. The open buffers are listed inside a popup
+// and can be filtered by typing the name. [Up] and [Down] are changing the selection and [Enter] switches to the selected buffer
+func (editor *Editor) handleKeybindBufferList() error {
+	items := make([]string, 0, len(editor.buffers))
+	for index, buffer := range editor.buffers {
+		modificationMark := ""
+		if buffer.IsModified() {
+			modificationMark = "*"
+		}
+
+		items = append(items, fmt.Sprintf("%d: %s%s", index+1, buffer.GetFileName(), modificationMark))
+	}
+
+	index, confirmed, err := editor.popupSelect("Buffers", "Buffer: ", items, editor.bufferIndex)
+	if err != nil || !confirmed || index == editor.bufferIndex {
+		return err
+	}
+
+	return editor.switchBuffer(index)
+}
+
+// Activate the buffer specified by the given index and render the changes. This function is used to apply the positions specified
+// in the command line to the corresponding buffers
+func (editor *Editor) SwitchBuffer(index int) error {
+	if err := editor.switchBuffer(index); err != nil {
+		return err
+	}
+
+	return editor.renderInputChanges()
+}
+
+// Helper function used to present the buffer specified by the given index inside the focused pane. The cursor position of the
+// current buffer is stored and the stored cursor position of the selected buffer is restored
+func (editor *Editor) switchBuffer(index int) error {
+	if index < 0 || index >= len(editor.buffers) {
+		return errors.New("editor: invalid out of bound buffer index requested to switch")
+	}
+
+	if err := editor.pane.AttachBuffer(editor.buffers[index], index); err != nil {
+		return err
+	}
+
+	if err := editor.focusPane(editor.pane); err != nil {
+		return err
+	}
+
+	if err := editor.display.CenterBoundaries(); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// [F1] or [Ctrl] + [Shift] + [P] Handle command palette keybind. All registered commands are listed inside a popup together
+// with the bound keys and can be filtered by typing. [Enter] executes the selected command. The function returns a bool value
+// indicating if the editor loop should be broken
+func (editor *Editor) handleKeybindCommandPalette() (bool, error) {
+	commands := make([]*Command, 0)
+	for _, command := range editor.commands.GetCommands() {
+		if command.GetName() != "command-palette" {
+			commands = append(commands, command)
+		}
+	}
+
+	titleWidth := 0
+	for _, command := range commands {
+		if len(command.GetTitle()) > titleWidth {
+			titleWidth = len(command.GetTitle())
+		}
+	}
+
+	items := make([]string, 0, len(commands))
+	for _, command := range commands {
+		items = append(items, strings.TrimRight(fmt.Sprintf("%-*s  %s", titleWidth, command.GetTitle(), command.GetKeybindText()), " "))
+	}
+
+	index, confirmed, err := editor.popupSelect("Commands", "Command: ", items, 0)
+	if err != nil || !confirmed {
+		return false, err
+	}
+
+	return commands[index].Execute()
+}
+
+// Handle suspend command. The terminal is released and the program is stopped until it is resumed by the shell (e.g. with the
+// fg command). The terminal size could be changed in the meantime, so the panes are arranged and redrawn after the resume
+func (editor *Editor) handleCommandSuspend() error {
+	if err := editor.console.Suspend(); err != nil {
+		if errors.Is(err, ErrSuspendNotSupported) {
+			return editor.menu.SetNotificationText("Suspending is not supported on this platform.")
+		}
+
+		return err
+	}
+
+	width, height := editor.console.GetSize()
+	for _, pane := range editor.layout.GetPanes() {
+		if err := pane.GetDisplay().Resize(width, height); err != nil {
+			return err
+		}
+	}
+
+	return editor.arrangePanes()
+}
+
+// Handle select all command. The whole text is selected and the cursor is placed at the end of the text
+func (editor *Editor) handleCommandSelectAll() error {
+	editor.selection.Clear()
+
+	if err := editor.cursor.SetOffsets(0, 0); err != nil {
+		return err
+	}
+
+	editor.selection.Start()
+
+	yOffset := editor.text.GetLineCount() - 1
+	xOffset, err := editor.text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return err
+	}
+
+	if err := editor.cursor.SetOffsets(xOffset, yOffset); err != nil {
+		return err
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle file finder keybind. The files of the working directory are
+// collected in the background and fuzzy matched with the query typed inside the menu. The matches are listed inside a popup
+// together with the preview of the selected file. [Up] and [Down] are changing the selection and [Enter] opens the selected file
+func (editor *Editor) handleKeybindFileFinder() error {
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	finder := new(FileFinder)
+	if err := finder.Init(workingDirectory, func() { _ = editor.console.Interrupt() }); err != nil {
+		return err
+	}
+
+	if err := finder.Start(); err != nil {
+		return err
+	}
+
+	// NOTE: The walk is stopped on every return path, so the background work is not outliving the prompt
+	defer finder.Cancel()
+
+	popup := new(Popup)
+	if err := popup.Init("Files", nil); err != nil {
+		return err
+	}
+
+	query := ""
+	matches := make([]FinderMatch, 0)
+
+	redrawFinder := func() error {
+		popup.SetPreview(nil)
+		if index := popup.GetSelectedIndex(); index >= 0 {
+			// NOTE: The preview is skipped for the files which can not be read (e.g. removed since the walk)
+			if preview, err := finder.GetPreview(matches[index].Path, popupMaxVisiblePreview); err == nil {
+				popup.SetPreview(preview)
+			}
+		}
+
+		// NOTE: The popup size depends on the items and the preview, so the text is redrawn to remove the previous popup
+		if err := editor.display.RedrawTextFull(editor.text); err != nil {
+			return err
+		}
+
+		return editor.display.RedrawPopup(popup)
+	}
+
+	updateFinder := func(value string) error {
+		selectedPath := ""
+		if index := popup.GetSelectedIndex(); index >= 0 && value == query {
+			selectedPath = matches[index].Path
+		}
+
+		query = value
+		matches = finder.Match(query, finderMatchLimit)
+
+		items := make([]string, 0, len(matches))
+		for _, match := range matches {
+			items = append(items, match.Path)
+		}
+
+		popup.SetItems(items)
+
+		// NOTE: The selection is kept while new files are collected and moved to the top match when the query changes
+		for index, match := range matches {
+			if len(selectedPath) > 0 && match.Path == selectedPath {
+				if err := popup.SetSelectedIndex(index); err != nil {
+					return err
+				}
+			}
+		}
+
+		title := fmt.Sprintf("Files (%d)", finder.GetFileCount())
+		if !finder.IsDone() {
+			title = fmt.Sprintf("Files (%d, searching...)", finder.GetFileCount())
+		}
+
+		if err := popup.SetTitle(title); err != nil {
+			return err
+		}
+
+		return redrawFinder()
+	}
+
+	keyHandler := func(event ConsoleEventKeyPress) (menuInputAction, error) {
+		switch event.Key {
+		case KeyUp, KeyBacktab:
+			popup.SelectPrevious()
+		case KeyDown, KeyTab:
+			popup.SelectNext()
+		default:
+			return menuInputUnhandled, nil
+		}
+
+		return menuInputHandled, redrawFinder()
+	}
+
+	if err := updateFinder(""); err != nil {
+		return err
+	}
+
+	_, confirmed, err := editor.menuInput("Find file: ", "", keyHandler, updateFinder)
+	if err != nil {
+		return err
+	}
+
+	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+		return err
+	}
+
+	if !confirmed || popup.GetSelectedIndex() < 0 {
+		return nil
+	}
+
+	return editor.openFile(filepath.Join(finder.GetDirectory(), matches[popup.GetSelectedIndex()].Path))
+}
+
+// Helper function used to present the given file inside the focused pane. The already open buffer of the file is reused,
+// otherwise a new buffer is opened
+func (editor *Editor) openFile(filePath string) error {
+	absolutePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+
+	for index, buffer := range editor.buffers {
+		if bufferPath, err := filepath.Abs(buffer.GetFilePath()); err == nil && bufferPath == absolutePath {
+			return editor.switchBuffer(index)
+		}
+	}
+
+	buffer := new(Buffer)
+	if err := buffer.Init(absolutePath, editor.config); err != nil {
+		return err
+	}
+
+	if err := editor.startBufferLoading(buffer); err != nil {
+		return err
+	}
+
+	buffer.SetReadOnly(editor.readOnly)
+	editor.buffers = append(editor.buffers, buffer)
+	return editor.switchBuffer(len(editor.buffers) - 1)
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle split keybinds. The focused pane is splitted in the given
+// orientation and the new pane (presenting the same buffer at the same position) is focused
+func (editor *Editor) handleKeybindSplit(orientation SplitOrientation) error {
+	_, _, width, height := editor.pane.GetArea()
+
+	if (orientation == SplitVertical && width < 2*paneMinimalWidth+1) || (orientation == SplitHorizontal && height < 2*paneMinimalHeight+1) {
+		return editor.menu.SetNotificationText("Not enough space to split the pane.")
+	}
+
+	pane := new(Pane)
+	if err := pane.Init(editor.buffers[editor.bufferIndex], editor.bufferIndex, editor.console, editor.themes.GetCurrent(), editor.config); err != nil {
+		return err
+	}
+
+	if err := pane.GetCursor().SetOffsets(editor.cursor.GetOffsetX(), editor.cursor.GetOffsetY()); err != nil {
+		return err
+	}
+
+	if err := pane.GetDisplay().SetWrapEnabled(editor.display.IsWrapEnabled()); err != nil {
+		return err
+	}
+
+	if err := editor.layout.Split(editor.pane, pane, orientation); err != nil {
+		return err
+	}
+
+	if err := editor.focusPane(pane); err != nil {
+		return err
+	}
+
+	return editor.arrangePanes()
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle next pane keybind. The panes are focused in order from the
+// top-left to the bottom-right
+func (editor *Editor) handleKeybindNextPane() error {
+	panes := editor.layout.GetPanes()
+	if len(panes) == 1 {
+		return editor.menu.SetNotificationText("No other panes are open.")
+	}
+
+	for index, pane := range panes {
+		if pane == editor.pane {
+			return editor.focusPane(panes[(index+1)%len(panes)])
+		}
+	}
+
+	return errors.New("editor: the focused pane is not part of the layout")
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle close pane keybind. The space of the focused pane is assigned
+// to the neighbouring pane, which is focused afterwards. The buffer of the pane stays open
+func (editor *Editor) handleKeybindClosePane() error {
+	panes := editor.layout.GetPanes()
+	if len(panes) == 1 {
+		return editor.menu.SetNotificationText("The last pane can not be closed.")
+	}
+
+	paneIndex := 0
+	for index, pane := range panes {
+		if pane == editor.pane {
+			paneIndex = index
+		}
+	}
+
+	editor.buffers[editor.bufferIndex].StoreCursorPosition(editor.cursor.GetOffsetX(), editor.cursor.GetOffsetY())
+
+	if err := editor.layout.Remove(editor.pane); err != nil {
+		return err
+	}
+
+	if paneIndex > 0 {
+		paneIndex -= 1
+	}
+
+	if err := editor.focusPane(editor.layout.GetPanes()[paneIndex]); err != nil {
+		return err
+	}
+
+	return editor.arrangePanes()
+}
+
+// [Alt] + [Arrows] Handle pane resize keys. The [Right] and [Down] arrows are growing the focused pane and the [Left] and [Up]
+// arrows are shrinking the focused pane by moving the separator of the nearest split with the matching orientation
+func (editor *Editor) handleKeysPaneResize(orientation SplitOrientation, grow bool) error {
+	delta := paneResizeStep
+	if !grow {
+		delta = -paneResizeStep
+	}
+
+	resized, err := editor.layout.Resize(editor.pane, orientation, delta)
+	if err != nil {
+		return err
+	}
+
+	if !resized {
+		return editor.menu.SetNotificationText("No split to resize in this direction.")
+	}
+
+	return editor.arrangePanes()
+}
+
+// Helper function used to focus the given pane. The editor components are referencing the components of the pane and the menu
+// is presenting the information about the buffer of the pane
+func (editor *Editor) focusPane(pane *Pane) error {
+	if pane == nil {
+		return errors.New("editor: invalid pane struct reference")
+	}
+
+	editor.pane = pane
+	editor.display = pane.GetDisplay()
+	editor.cursor = pane.GetCursor()
+	editor.selection = pane.GetSelection()
+	editor.search = pane.GetSearch()
+
+	buffer := pane.GetBuffer()
+	editor.bufferIndex = pane.GetBufferIndex()
+	editor.text = buffer.GetText()
+	editor.history = buffer.GetHistory()
+	editor.highlighter = buffer.GetHighlighter()
+
+	if err := editor.menu.SetFileName(buffer.GetFileName()); err != nil {
+		return err
+	}
+
+	if err := editor.menu.SetEndOfLineSequenceName(editor.text.GetEndOfLineSequenceName()); err != nil {
+		return err
+	}
+
+	return editor.menu.SetBufferIndexText(editor.bufferIndex, len(editor.buffers))
+}
+
+// Helper function used to assign the console areas to the panes according to the layout and redraw all panes
+func (editor *Editor) arrangePanes() error {
+	width, height := editor.display.GetFullDisplaySize()
+	paneAreas, _ := editor.layout.Arrange(0, 0, width, height-MenuHeight)
+
+	for _, paneArea := range paneAreas {
+		if err := paneArea.Pane.SetArea(paneArea.X, paneArea.Y, paneArea.Width, paneArea.Height); err != nil {
+			return err
+		}
+	}
+
+	return editor.redrawPanes()
+}
+
+// Helper function used to redraw the text of all panes and the separators between them
+func (editor *Editor) redrawPanes() error {
+	for _, pane := range editor.layout.GetPanes() {
+		if err := pane.GetDisplay().RedrawTextFull(pane.GetBuffer().GetText()); err != nil {
+			return err
+		}
+
+		pane.MarkTextSynchronized()
+	}
+
+	width, height := editor.display.GetFullDisplaySize()
+	_, separatorAreas := editor.layout.Arrange(0, 0, width, height-MenuHeight)
+
+	for _, separatorArea := range separatorAreas {
+		if err := editor.display.RedrawSeparator(separatorArea.X, separatorArea.Y, separatorArea.Length, separatorArea.Vertical); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Helper function used to redraw the panes presenting a text changed inside the focused pane
+func (editor *Editor) synchronizePanes() error {
+	editor.pane.MarkTextSynchronized()
+
+	for _, pane := range editor.layout.GetPanes() {
+		if pane == editor.pane {
+			continue
+		}
+
+		changed, err := pane.SynchronizeText()
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			continue
+		}
+
+		display := pane.GetDisplay()
+		if !display.CursorInBoundries() {
+			if err := display.RecalculateBoundaries(); err != nil {
+				return err
+			}
+		}
+
+		if err := display.RedrawTextFull(pane.GetBuffer().GetText()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Helper function creates a ,,selection popup”. The items are listed inside a popup and the menu input is used to fuzzy filter
+// the items (case insensitive, the best matches first). [Up], [Down] and [Tab] are changing the selected item. The function
+// returns the index of the selected item (inside the given items slice) and a bool value indicating if the selection was confirmed
+func (editor *Editor) popupSelect(title string, label string, items []string, selectedIndex int) (int, bool, error) {
+	popup := new(Popup)
+	if err := popup.Init(title, items); err != nil {
+		return -1, false, err
+	}
+
+	if selectedIndex >= 0 && selectedIndex < len(items) {
+		if err := popup.SetSelectedIndex(selectedIndex); err != nil {
+			return -1, false, err
+		}
+	}
+
+	itemIndices := make([]int, len(items))
+	for index := range items {
+		itemIndices[index] = index
+	}
+
+	keyHandler := func(event ConsoleEventKeyPress) (menuInputAction, error) {
+		switch event.Key {
+		case KeyUp, KeyBacktab:
+			popup.SelectPrevious()
+		case KeyDown, KeyTab:
+			popup.SelectNext()
+		default:
+			return menuInputUnhandled, nil
+		}
+
+		return menuInputHandled, editor.display.RedrawPopup(popup)
+	}
+
+	changeHandler := func(value string) error {
+		query := []rune(strings.ToLower(strings.ReplaceAll(value, " ", "")))
+
+		type popupMatch struct {
+			index int
+			score int
+		}
+
+		matches := make([]popupMatch, 0, len(items))
+		for index, item := range items {
+			if score, matched := scoreFuzzyMatch(query, item); matched {
+				matches = append(matches, popupMatch{index: index, score: score})
+			}
+		}
+
+		// NOTE: The items with the same score are keeping the original order
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].score > matches[j].score
+		})
+
+		filteredItems := make([]string, 0, len(matches))
+		itemIndices = itemIndices[:0]
+
+		for _, match := range matches {
+			filteredItems = append(filteredItems, items[match.index])
+			itemIndices = append(itemIndices, match.index)
+		}
+
+		popup.SetItems(filteredItems)
+
+		// NOTE: The popup is covering the text only partially, so the text is redrawn to remove the previous (larger) popup
+		if err := editor.display.RedrawTextFull(editor.text); err != nil {
+			return err
+		}
+
+		return editor.display.RedrawPopup(popup)
+	}
+
+	if err := editor.display.RedrawPopup(popup); err != nil {
+		return -1, false, err
+	}
+
+	_, confirmed, err := editor.menuInput(label, "", keyHandler, changeHandler)
+	if err != nil {
+		return -1, false, err
+	}
+
+	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+		return -1, false, err
+	}
+
+	if !confirmed || popup.GetSelectedIndex() < 0 {
+		return -1, false, nil
+	}
+
+	return itemIndices[popup.GetSelectedIndex()], true, nil
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle find keybind. The search prompt is displayed inside the menu and the
+// matches are highlighted while typing. [Enter]/[F3] jumps to the next match, [Shift] + [Enter]/[F3] jumps to the previous match,
+// [Alt] + [C], [Alt] + [W] and [Alt] + [R] are toggling the case sensitivity, whole word and regular expression options. [Esc] closes
+// the prompt at the current match and [Ctrl] + [C] restores the cursor position from before the search
+func (editor *Editor) handleKeybindFind() error {
+	editor.selection.Clear()
+
+	xOrigin := editor.cursor.GetOffsetX()
+	yOrigin := editor.cursor.GetOffsetY()
+	options := editor.search.GetOptions()
+
+	keyHandler := func(event ConsoleEventKeyPress) (menuInputAction, error) {
+		xOffset := editor.cursor.GetOffsetX()
+		yOffset := editor.cursor.GetOffsetY()
+
+		switch {
+		case event.Key == KeyEscape:
+			return menuInputConfirm, nil
+		case (event.Key == KeyEnter || event.Key == KeyF3) && event.Modifier == ModifierNone:
+			{
+				editor.search.SelectNext(xOffset, yOffset, false)
+				return menuInputHandled, editor.jumpToSearchMatch()
+			}
+		case (event.Key == KeyEnter || event.Key == KeyF3) && event.Modifier == ModifierShift:
+			{
+				editor.search.SelectPrevious(xOffset, yOffset)
+				return menuInputHandled, editor.jumpToSearchMatch()
+			}
+		}
+
+		if editor.toggleSearchOption(event, &options) {
+			return menuInputHandled, editor.updateSearch(editor.menu.GetInputValue(), options, xOrigin, yOrigin)
+		}
+
+		return menuInputUnhandled, nil
+	}
+
+	changeHandler := func(pattern string) error {
+		return editor.updateSearch(pattern, options, xOrigin, yOrigin)
+	}
+
+	// NOTE: The status is updated before the prompt is displayed, so the options are visible from the start
+	if err := editor.updateSearchStatus(options, false); err != nil {
+		return err
+	}
+
+	_, confirmed, err := editor.menuInput("Find: ", "", keyHandler, changeHandler)
+	if err != nil {
+		return err
+	}
+
+	editor.search.Clear()
+
+	if !confirmed {
+		if err := editor.cursor.SetOffsets(xOrigin, yOrigin); err != nil {
+			return err
+		}
+
+		if err := editor.display.RecalculateBoundaries(); err != nil {
+			return err
+		}
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// [Ctrl] + [ASCII 0x20 - 0x7E (defined by configuration)] Handle replace keybind. The pattern and the replacement are entered
+// in two following prompts (the pattern prompt supports the same options as the find prompt). In the regular expression mode
+// the replacement can reference the capture groups ($1 or ${name}). Each match has to be confirmed with [Y] (replace), [N] (skip),
+// [A] (replace this and all remaining) or [Q] (quit). All replacements are reverted as a single undo step
+func (editor *Editor) handleKeybindReplace() error {
+	editor.selection.Clear()
+
+	xOrigin := editor.cursor.GetOffsetX()
+	yOrigin := editor.cursor.GetOffsetY()
+	options := editor.search.GetOptions()
+
+	keyHandler := func(event ConsoleEventKeyPress) (menuInputAction, error) {
+		if editor.toggleSearchOption(event, &options) {
+			return menuInputHandled, editor.updateSearch(editor.menu.GetInputValue(), options, xOrigin, yOrigin)
+		}
+
+		return menuInputUnhandled, nil
+	}
+
+	changeHandler := func(pattern string) error {
+		return editor.updateSearch(pattern, options, xOrigin, yOrigin)
+	}
+
+	if err := editor.updateSearchStatus(options, false); err != nil {
+		return err
+	}
+
+	pattern, confirmed, err := editor.menuInput("Replace: ", "", keyHandler, changeHandler)
+	if err != nil {
+		return err
+	}
+
+	matchCount := editor.search.GetMatchCount()
+
+	if !confirmed || matchCount == 0 {
+		editor.search.Clear()
+
+		if err := editor.cursor.SetOffsets(xOrigin, yOrigin); err != nil {
+			return err
+		}
+
+		if err := editor.display.RecalculateBoundaries(); err != nil {
+			return err
+		}
+
+		if err := editor.display.RedrawTextFull(editor.text); err != nil {
+			return err
+		}
+
+		if confirmed {
+			return editor.menu.SetNotificationText(fmt.Sprintf("No matches for: %s", pattern))
+		}
+
+		return nil
+	}
+
+	replacement, confirmed, err := editor.menuInput("With: ", "", nil, nil)
+	if err != nil {
+		return err
+	}
+
+	replacedCount := 0
+	if confirmed {
+		replacedCount, err = editor.replaceSearchMatches(pattern, replacement, options, xOrigin, yOrigin, matchCount)
+		if err != nil {
+			return err
+		}
+	}
+
+	editor.search.Clear()
+
+	if err := editor.display.RecalculateBoundaries(); err != nil {
+		return err
+	}
+
+	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+		return err
+	}
+
+	return editor.menu.SetNotificationText(fmt.Sprintf("Replaced %d occurrence(s).", replacedCount))
+}
+
+// Helper function used to replace the search matches, starting from the given offsets and wrapping around the end of the text.
+// Every match has to be confirmed, unless all remaining matches were accepted. At most the given count of matches is visited,
+// so matches created by the replacements can not cause an endless loop. The function returns the count of replaced matches
+func (editor *Editor) replaceSearchMatches(pattern string, replacement string, options SearchOptions, xOffset int, yOffset int, matchLimit int) (int, error) {
+	editor.history.BeginGroup()
+	defer editor.history.EndGroup()
+
+	replacedCount := 0
+	inclusive := true
+
+	for visitedCount := 0; visitedCount < matchLimit; visitedCount += 1 {
+		if err := editor.search.Update(pattern, options); err != nil {
+			return replacedCount, err
+		}
+
+		if !editor.search.SelectNext(xOffset, yOffset, inclusive) {
+			break
+		}
+
+		match, err := editor.search.GetCurrentMatch()
+		if err != nil {
+			return replacedCount, err
+		}
+
+		if err := editor.jumpToSearchMatch(); err != nil {
+			return replacedCount, err
+		}
+
+		choice, err := editor.menuChoice("Replace this match?", []rune{'y', 'n', 'a', 'q'})
+		if err != nil {
+			return replacedCount, err
+		}
+
+		if choice == 'n' {
+			xOffset, yOffset, inclusive = match.XOffset, match.YOffset, false
+			continue
+		}
+
+		// NOTE: The remaining matches are replaced at once, without searching the text again after every replacement
+		if choice == 'a' {
+			remainingCount, err := editor.replaceMatches(editor.search.GetMatchesFromCurrent(matchLimit-visitedCount), pattern, replacement, options)
+			return replacedCount + remainingCount, err
+		}
+
+		if choice != 'y' {
+			break
+		}
+
+		content, err := editor.text.ExpandReplacement(match, pattern, replacement, options)
+		if err != nil {
+			return replacedCount, err
+		}
+
+		xOffset, yOffset, err = editor.replaceMatch(match, content)
+		if err != nil {
+			return replacedCount, err
+		}
+
+		inclusive = true
+		replacedCount += 1
+	}
+
+	return replacedCount, nil
+}
+
+// Helper function used to replace the given matches of the current search. The replacements are expanded before the text is
+// changed and applied from the last match to the first one, so the offsets of the matches which are not replaced yet are not
+// affected. The cursor is placed after the replacement of the match closest to the text start. The function returns the count
+// of replaced matches
+func (editor *Editor) replaceMatches(matches []SearchMatch, pattern string, replacement string, options SearchOptions) (int, error) {
+	sort.Slice(matches, func(i int, j int) bool {
+		if matches[i].YOffset != matches[j].YOffset {
+			return matches[i].YOffset > matches[j].YOffset
+		}
+
+		return matches[i].XOffset > matches[j].XOffset
+	})
+
+	contents := make([]string, len(matches))
+	for index, match := range matches {
+		content, err := editor.text.ExpandReplacement(match, pattern, replacement, options)
+		if err != nil {
+			return 0, err
+		}
+
+		contents[index] = content
+	}
+
+	for index, match := range matches {
+		xOffset, yOffset, err := editor.replaceMatch(match, contents[index])
+		if err != nil {
+			return index, err
+		}
+
+		if err := editor.cursor.SetOffsets(xOffset, yOffset); err != nil {
+			return index, err
+		}
+	}
+
+	return len(matches), nil
+}
+
+// Helper function used to replace the given match with the content. The cursor is placed after the inserted content and the
+// offsets of the cursor are returned
+func (editor *Editor) replaceMatch(match SearchMatch, content string) (int, int, error) {
+	if err := editor.text.RemoveRange(match.XOffset, match.YOffset, match.XOffset+match.Length, match.YOffset); err != nil {
+		return 0, 0, err
+	}
+
+	if err := editor.cursor.SetOffsets(match.XOffset, match.YOffset); err != nil {
+		return 0, 0, err
+	}
+
+	xOffset, yOffset, err := editor.text.InsertText(content, editor.cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return xOffset, yOffset, editor.cursor.SetOffsets(xOffset, yOffset)
+}
+
+// Helper function used to toggle the search options with the [Alt] + [C] (case), [Alt] + [W] (word) and [Alt] + [R] (regular
+// expression) keys. The function returns a bool value indicating if any option was toggled
+func (editor *Editor) toggleSearchOption(event ConsoleEventKeyPress, options *SearchOptions) bool {
+	if event.Modifier != ModifierAlt || event.Key != KeyPrintable {
+		return false
+	}
+
+	switch unicode.ToLower(event.Char) {
+	case 'c':
+		options.CaseSensitive = !options.CaseSensitive
+	case 'w':
+		options.WholeWord = !options.WholeWord
+	case 'r':
+		options.RegularExpression = !options.RegularExpression
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Helper function used to search the pattern again and jump to the first match after the given origin offsets. If nothing is
+// found the cursor is placed back at the origin. An invalid regular expression is reported in the search status
+func (editor *Editor) updateSearch(pattern string, options SearchOptions, xOrigin int, yOrigin int) error {
+	patternInvalid := false
+	if err := editor.search.Update(pattern, options); err != nil {
+		if !options.RegularExpression {
+			return err
+		}
+
+		patternInvalid = true
+	}
+
+	if !editor.search.SelectNext(xOrigin, yOrigin, true) {
+		if err := editor.cursor.SetOffsets(xOrigin, yOrigin); err != nil {
+			return err
+		}
+	}
+
+	if err := editor.jumpToSearchMatch(); err != nil {
+		return err
+	}
+
+	return editor.updateSearchStatus(options, patternInvalid)
+}
+
+// [F3] Handle the jump to the next (or previous with [Shift] modifier) match of the latest search pattern
+func (editor *Editor) handleKeyF3(previous bool) error {
+	pattern := editor.search.GetPattern()
+	if len(pattern) == 0 {
+		return editor.menu.SetNotificationText("No search pattern specified.")
+	}
+
+	editor.selection.Clear()
+
+	if err := editor.search.Update(pattern, editor.search.GetOptions()); err != nil {
+		return err
+	}
+
+	xOffset := editor.cursor.GetOffsetX()
+	yOffset := editor.cursor.GetOffsetY()
+
+	if previous {
+		editor.search.SelectPrevious(xOffset, yOffset)
+	} else {
+		editor.search.SelectNext(xOffset, yOffset, false)
+	}
+
+	if err := editor.jumpToSearchMatch(); err != nil {
+		return err
+	}
+
+	notification := fmt.Sprintf("No matches for: %s", pattern)
+	if editor.search.GetMatchCount() > 0 {
+		notification = fmt.Sprintf("Match %d of %d", editor.search.GetCurrentMatchIndex()+1, editor.search.GetMatchCount())
+	}
+
+	// NOTE: The matches are not highlighted outside of the search prompt, because they are not updated on text changes
+	editor.search.Clear()
+
+	if err := editor.display.RedrawTextFull(editor.text); err != nil {
+		return err
+	}
+
+	return editor.menu.SetNotificationText(notification)
+}
+
+// Helper function used to move the cursor to the current search match. The display boundaries are recalculated
+// if the match is not visible
+func (editor *Editor) jumpToSearchMatch() error {
+	if match, err := editor.search.GetCurrentMatch(); err == nil {
+		if err := editor.cursor.SetOffsets(match.XOffset, match.YOffset); err != nil {
+			return err
+		}
+	}
+
+	if !editor.display.CursorInBoundries() {
+		if err := editor.display.RecalculateBoundaries(); err != nil {
+			return err
+		}
+	}
+
+	if editor.menu.IsInputActive() {
+		if err := editor.updateSearchStatus(editor.search.GetOptions(), false); err != nil {
+			return err
+		}
+	}
+
+	return editor.display.RedrawTextFull(editor.text)
+}
+
+// Helper function used to update the search results and options displayed next to the search prompt
+func (editor *Editor) updateSearchStatus(options SearchOptions, patternInvalid bool) error {
+	formatOption := func(enabled bool) string {
+		if enabled {
+			return "on"
+		}
+
+		return "off"
+	}
+
+	matchCount := editor.search.GetMatchCount()
+
+	results := "no matches"
+	if patternInvalid {
+		results = "invalid pattern"
+	} else if matchCount > 0 {
+		results = fmt.Sprintf("match %d of %d", editor.search.GetCurrentMatchIndex()+1, matchCount)
+	}
+
+	status := fmt.Sprintf("%s | Aa:%s W:%s .*:%s (Alt+C/W/R)",
+		results,
+		formatOption(options.CaseSensitive),
+		formatOption(options.WholeWord),
+		formatOption(options.RegularExpression))
+
+	return editor.menu.SetInputStatusText(status)
 }
@@ -0,0 +1,145 @@
+package main
+
+import "testing"
+
+func TestEditorShouldUndoTypedCharactersAsSingleStep(t *testing.T) {
+	editor := createEditorTestEditor(t, "")
+
+	sendEditorTestText(t, editor, "abc")
+
+	if editorTestContent(t, editor) != "abc" {
+		t.Fail()
+	}
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Char: 'z', Key: KeyPrintable, Modifier: ModifierCtrl})
+
+	if editorTestContent(t, editor) != "" {
+		t.Fail()
+	}
+
+	if editor.history.CanUndo() {
+		t.Fail()
+	}
+}
+
+func TestEditorShouldReplaceSelectionWithTypedCharacter(t *testing.T) {
+	editor := createEditorTestEditor(t, "Hello world")
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Key: KeyEnd, Modifier: ModifierNone})
+	sendEditorTestSelection(t, editor, 5)
+	sendEditorTestText(t, editor, "X")
+
+	if editorTestContent(t, editor) != "Hello X" {
+		t.Fail()
+	}
+
+	if editor.selection.IsActive() || editor.cursor.GetOffsetX() != 7 {
+		t.Fail()
+	}
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Char: 'z', Key: KeyPrintable, Modifier: ModifierCtrl})
+
+	if editorTestContent(t, editor) != "Hello world" {
+		t.Fail()
+	}
+}
+
+func TestEditorShouldReplaceSelectionWithPastedText(t *testing.T) {
+	editor := createEditorTestEditor(t, "Hello world")
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Key: KeyEnd, Modifier: ModifierNone})
+	sendEditorTestSelection(t, editor, 5)
+
+	if _, err := editor.handleConsoleEventPaste(ConsoleEventPaste{Text: "there\nagain"}); err != nil {
+		t.FailNow()
+	}
+
+	if editorTestContent(t, editor) != "Hello there\nagain" {
+		t.Fail()
+	}
+
+	if editor.selection.IsActive() || editor.cursor.GetOffsetX() != 5 || editor.cursor.GetOffsetY() != 1 {
+		t.Fail()
+	}
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Char: 'z', Key: KeyPrintable, Modifier: ModifierCtrl})
+
+	if editorTestContent(t, editor) != "Hello world" {
+		t.Fail()
+	}
+}
+
+func TestEditorShouldReplaceSelectionWithClipboardContent(t *testing.T) {
+	editor := createEditorTestEditor(t, "Hello world")
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Key: KeyEnd, Modifier: ModifierNone})
+	sendEditorTestSelection(t, editor, 5)
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Char: 'c', Key: KeyPrintable, Modifier: ModifierCtrl})
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Key: KeyHome, Modifier: ModifierNone})
+	for index := 0; index < 5; index += 1 {
+		sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Key: KeyRight, Modifier: ModifierShift})
+	}
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Char: 'v', Key: KeyPrintable, Modifier: ModifierCtrl})
+
+	if editorTestContent(t, editor) != "world world" {
+		t.Fail()
+	}
+
+	sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Char: 'z', Key: KeyPrintable, Modifier: ModifierCtrl})
+
+	if editorTestContent(t, editor) != "Hello world" {
+		t.Fail()
+	}
+}
+
+func createEditorTestEditor(t *testing.T, content string) *Editor {
+	filePath := createTestFile(t, "file.txt", content)
+
+	config := &Config{
+		HistoryConfiguration:   CreateDefaultHistoryConfig(),
+		KeybindsConfiguration:  CreateDefaultKeybindsConfig(),
+		CursorConfiguration:    CreateDefaultCursorConfig(),
+		TextConfiguration:      CreateDefaultTextConfig(),
+		ClipboardConfiguration: CreateDefaultClipboardConfig(),
+		DisplayConfiguration:   CreateDefaultDisplayConfig(),
+		ThemeConfiguration:     CreateDefaultThemeConfig(),
+	}
+
+	config.TextConfiguration.UsePlatformSpecificEndOfLineSequence = false
+
+	editor := new(Editor)
+	if err := editor.Init([]string{filePath}, CreateConsoleMockup(), config); err != nil {
+		t.FailNow()
+	}
+
+	return editor
+}
+
+func sendEditorTestKeyPress(t *testing.T, editor *Editor, event ConsoleEventKeyPress) {
+	if _, err := editor.handleConsoleEventKeyPress(event); err != nil {
+		t.FailNow()
+	}
+}
+
+func sendEditorTestText(t *testing.T, editor *Editor, text string) {
+	for _, char := range text {
+		sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Char: char, Key: KeyPrintable, Modifier: ModifierNone})
+	}
+}
+
+func editorTestContent(t *testing.T, editor *Editor) string {
+	content, err := editor.text.GetTextAsString()
+	if err != nil {
+		t.FailNow()
+	}
+
+	return *content
+}
+
+func sendEditorTestSelection(t *testing.T, editor *Editor, count int) {
+	for index := 0; index < count; index += 1 {
+		sendEditorTestKeyPress(t, editor, ConsoleEventKeyPress{Key: KeyLeft, Modifier: ModifierShift})
+	}
+}
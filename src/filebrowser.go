@@ -0,0 +1,231 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Name of the entry representing the parent directory
+const fileBrowserParentEntry = ".."
+
+// Structure representing a single entry (file or directory) of the browsed directory
+type FileBrowserEntry struct {
+	Name        string
+	IsDirectory bool
+}
+
+// Structure representing the state of the directory browser. The entries of the current directory are sorted (directories
+// first) and can be filtered by the name
+type FileBrowser struct {
+	directory string
+	entries   []FileBrowserEntry
+	filter    string
+	filtered  []FileBrowserEntry
+}
+
+// File browser structure initialization function. The entries of the given directory are loaded
+func (browser *FileBrowser) Init(directory string) error {
+	return browser.ChangeDirectory(directory)
+}
+
+// Return the absolute path of the browsed directory
+func (browser *FileBrowser) GetDirectory() string {
+	return browser.directory
+}
+
+// Change the browsed directory to the given directory (relative paths are resolved from the browsed directory). The filter is reset
+func (browser *FileBrowser) ChangeDirectory(directory string) error {
+	if !filepath.IsAbs(directory) && len(browser.directory) > 0 {
+		directory = filepath.Join(browser.directory, directory)
+	}
+
+	absoluteDirectory, err := filepath.Abs(directory)
+	if err != nil {
+		return err
+	}
+
+	directoryEntries, err := os.ReadDir(absoluteDirectory)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]FileBrowserEntry, 0, len(directoryEntries)+1)
+	for _, directoryEntry := range directoryEntries {
+		isDirectory := directoryEntry.IsDir()
+
+		// NOTE: The symbolic links are presented as the type of their target
+		if directoryEntry.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(absoluteDirectory, directoryEntry.Name())); err == nil {
+				isDirectory = info.IsDir()
+			}
+		}
+
+		entries = append(entries, FileBrowserEntry{Name: directoryEntry.Name(), IsDirectory: isDirectory})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].IsDirectory != entries[j].IsDirectory {
+			return entries[i].IsDirectory
+		}
+
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+
+	if filepath.Dir(absoluteDirectory) != absoluteDirectory {
+		entries = append([]FileBrowserEntry{{Name: fileBrowserParentEntry, IsDirectory: true}}, entries...)
+	}
+
+	browser.directory = absoluteDirectory
+	browser.entries = entries
+	browser.SetFilter("")
+
+	return nil
+}
+
+// Change the browsed directory to the parent directory
+func (browser *FileBrowser) ChangeToParentDirectory() error {
+	return browser.ChangeDirectory(filepath.Dir(browser.directory))
+}
+
+// Set the filter of the entries. The entries containing the filter in the name (case insensitive) are kept. The parent directory
+// entry is only presented without the filter
+func (browser *FileBrowser) SetFilter(filter string) {
+	browser.filter = filter
+	browser.filtered = make([]FileBrowserEntry, 0, len(browser.entries))
+
+	lowerFilter := strings.ToLower(filter)
+	for _, entry := range browser.entries {
+		if len(filter) == 0 {
+			browser.filtered = append(browser.filtered, entry)
+			continue
+		}
+
+		if entry.Name != fileBrowserParentEntry && strings.Contains(strings.ToLower(entry.Name), lowerFilter) {
+			browser.filtered = append(browser.filtered, entry)
+		}
+	}
+}
+
+// Return the current filter of the entries
+func (browser *FileBrowser) GetFilter() string {
+	return browser.filter
+}
+
+// Return the entries of the browsed directory matching the filter
+func (browser *FileBrowser) GetEntries() []FileBrowserEntry {
+	return browser.filtered
+}
+
+// Return the names of the entries matching the filter. The directory names are ending with the path separator
+func (browser *FileBrowser) GetEntryNames() []string {
+	names := make([]string, 0, len(browser.filtered))
+	for _, entry := range browser.filtered {
+		if entry.IsDirectory {
+			names = append(names, entry.Name+string(filepath.Separator))
+		} else {
+			names = append(names, entry.Name)
+		}
+	}
+
+	return names
+}
+
+// Open the entry specified by the index (inside the filtered entries). The directory is entered and the path of the file is
+// returned together with a bool value indicating if a file was opened
+func (browser *FileBrowser) Open(index int) (string, bool, error) {
+	if index < 0 || index >= len(browser.filtered) {
+		return "", false, errors.New("browser: invalid out of bound entry index requested to open")
+	}
+
+	entry := browser.filtered[index]
+	if entry.IsDirectory {
+		return "", false, browser.ChangeDirectory(entry.Name)
+	}
+
+	return filepath.Join(browser.directory, entry.Name), true, nil
+}
+
+// Return the path of a new file with the given name inside the browsed directory
+func (browser *FileBrowser) CreateFilePath(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || name == "." || name == fileBrowserParentEntry {
+		return "", errors.New("browser: invalid file name")
+	}
+
+	filePath := filepath.Join(browser.directory, name)
+	if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+		return "", errors.New("browser: a directory with the given name already exists")
+	}
+
+	return filePath, nil
+}
+
+// Complete the last element of the given path with the names of the matching directory entries (the entries starting with the
+// element). The path is extended by the common prefix of the matching names and the directory names are ending with the path
+// separator. The function returns the completed path and the sorted names of the matching entries
+func CompletePath(path string) (string, []string, error) {
+	// NOTE: The slash is accepted as the separator on every platform
+	separatorIndex := strings.LastIndexAny(path, "/"+string(filepath.Separator))
+
+	directoryPart := path[:separatorIndex+1]
+	prefix := path[separatorIndex+1:]
+
+	directory := directoryPart
+	if len(directory) == 0 {
+		directory = "."
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return path, nil, err
+	}
+
+	names := make([]string, 0)
+	for _, entry := range entries {
+		// NOTE: The hidden entries are only completed if the element is starting with a dot
+		if strings.HasPrefix(entry.Name(), ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+
+		if !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		isDirectory := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(directory, entry.Name())); err == nil {
+				isDirectory = info.IsDir()
+			}
+		}
+
+		if isDirectory {
+			names = append(names, entry.Name()+string(filepath.Separator))
+		} else {
+			names = append(names, entry.Name())
+		}
+	}
+
+	if len(names) == 0 {
+		return path, names, nil
+	}
+
+	sort.Strings(names)
+
+	commonPrefix := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, commonPrefix) {
+			commonPrefix = commonPrefix[:len(commonPrefix)-1]
+		}
+	}
+
+	// NOTE: The common prefix is shortened by bytes, so a partially matching multi-byte character is removed
+	for !utf8.ValidString(commonPrefix) {
+		commonPrefix = commonPrefix[:len(commonPrefix)-1]
+	}
+
+	return directoryPart + commonPrefix, names, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileBrowserShouldListDirectoriesFirst(t *testing.T) {
+	directory := createFileBrowserTestDirectory(t)
+
+	browser := new(FileBrowser)
+	if err := browser.Init(directory); err != nil {
+		t.FailNow()
+	}
+
+	entries := browser.GetEntries()
+	if len(entries) != 4 {
+		t.FailNow()
+	}
+
+	if entries[0].Name != fileBrowserParentEntry || entries[1].Name != "src" || !entries[1].IsDirectory {
+		t.Fail()
+	}
+
+	if entries[2].Name != "a.go" || entries[3].Name != "B.json" || entries[3].IsDirectory {
+		t.Fail()
+	}
+
+	names := browser.GetEntryNames()
+	if names[1] != "src"+string(filepath.Separator) {
+		t.Fail()
+	}
+}
+
+func TestFileBrowserShouldFilterEntries(t *testing.T) {
+	directory := createFileBrowserTestDirectory(t)
+
+	browser := new(FileBrowser)
+	if err := browser.Init(directory); err != nil {
+		t.FailNow()
+	}
+
+	browser.SetFilter("JS")
+
+	entries := browser.GetEntries()
+	if len(entries) != 1 || entries[0].Name != "B.json" {
+		t.Fail()
+	}
+
+	browser.SetFilter("")
+	if len(browser.GetEntries()) != 4 {
+		t.Fail()
+	}
+}
+
+func TestFileBrowserShouldOpenDirectoriesAndFiles(t *testing.T) {
+	directory := createFileBrowserTestDirectory(t)
+
+	browser := new(FileBrowser)
+	if err := browser.Init(directory); err != nil {
+		t.FailNow()
+	}
+
+	if _, opened, err := browser.Open(1); err != nil || opened {
+		t.FailNow()
+	}
+
+	if browser.GetDirectory() != filepath.Join(directory, "src") {
+		t.Fail()
+	}
+
+	filePath, opened, err := browser.Open(1)
+	if err != nil || !opened || filePath != filepath.Join(directory, "src", "main.go") {
+		t.Fail()
+	}
+
+	if err := browser.ChangeToParentDirectory(); err != nil {
+		t.FailNow()
+	}
+
+	if browser.GetDirectory() != directory {
+		t.Fail()
+	}
+
+	if _, _, err := browser.Open(10); err == nil {
+		t.Fail()
+	}
+}
+
+func TestFileBrowserShouldCreateFilePath(t *testing.T) {
+	directory := createFileBrowserTestDirectory(t)
+
+	browser := new(FileBrowser)
+	if err := browser.Init(directory); err != nil {
+		t.FailNow()
+	}
+
+	filePath, err := browser.CreateFilePath("new.txt")
+	if err != nil || filePath != filepath.Join(directory, "new.txt") {
+		t.Fail()
+	}
+
+	if _, err := browser.CreateFilePath("src"); err == nil {
+		t.Fail()
+	}
+
+	if _, err := browser.CreateFilePath(" "); err == nil {
+		t.Fail()
+	}
+}
+
+func TestFileBrowserShouldCompletePath(t *testing.T) {
+	directory := createFileBrowserTestDirectory(t) + string(filepath.Separator)
+
+	if err := os.WriteFile(filepath.Join(directory, "ab.txt"), []byte(""), 0644); err != nil {
+		t.FailNow()
+	}
+
+	completedPath, names, err := CompletePath(directory + "s")
+	if err != nil || completedPath != directory+"src"+string(filepath.Separator) || len(names) != 1 {
+		t.Fail()
+	}
+
+	completedPath, names, err = CompletePath(directory + "a")
+	if err != nil || completedPath != directory+"a" || len(names) != 2 {
+		t.FailNow()
+	}
+
+	if names[0] != "a.go" || names[1] != "ab.txt" {
+		t.Fail()
+	}
+
+	completedPath, names, err = CompletePath(directory + "x")
+	if err != nil || completedPath != directory+"x" || len(names) != 0 {
+		t.Fail()
+	}
+
+	if _, _, err := CompletePath(filepath.Join(directory, "missing", "file")); err == nil {
+		t.Fail()
+	}
+}
+
+func createFileBrowserTestDirectory(t *testing.T) string {
+	return createTestDirectory(t, map[string]string{"a.go": "", "B.json": "", "src/main.go": ""})
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	finderFileLimit      = 100000
+	finderMatchLimit     = 200
+	finderNotifyInterval = 500
+	finderBinaryProbe    = 8000
+	finderGitDirectory   = ".git"
+	finderGitignoreFile  = ".gitignore"
+)
+
+// NOTE: Errors used internally to stop the directory walk
+var (
+	errFinderCancelled    = errors.New("finder: the directory walk was cancelled")
+	errFinderLimitReached = errors.New("finder: the file limit was reached")
+)
+
+// Structure representing a single file matching the finder query together with the match score
+type FinderMatch struct {
+	Path  string
+	Score int
+}
+
+// Structure representing the fuzzy file finder. The files of the directory tree are collected by a background walk, which
+// is skipping the files ignored by the .gitignore patterns and the binary files. The collected files can be matched while
+// the walk is still in progress
+type FileFinder struct {
+	directory string
+	files     []string
+	done      bool
+	started   bool
+	notify    func()
+	cancel    chan struct{}
+	finished  chan struct{}
+	mutex     sync.Mutex
+}
+
+// File finder structure initialization function. The notify function is called (from the walking goroutine) after a batch
+// of files was collected and after the walk is finished
+func (finder *FileFinder) Init(directory string, notify func()) error {
+	absoluteDirectory, err := filepath.Abs(directory)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(absoluteDirectory)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return errors.New("finder: the given path is not a directory")
+	}
+
+	finder.directory = absoluteDirectory
+	finder.files = make([]string, 0)
+	finder.done = false
+	finder.started = false
+	finder.notify = notify
+	finder.cancel = make(chan struct{})
+	finder.finished = make(chan struct{})
+
+	return nil
+}
+
+// Return the absolute path of the directory searched by the finder
+func (finder *FileFinder) GetDirectory() string {
+	return finder.directory
+}
+
+// Start the background walk of the directory tree
+func (finder *FileFinder) Start() error {
+	if finder.started {
+		return errors.New("finder: the directory walk was already started")
+	}
+
+	finder.started = true
+	go finder.walk()
+
+	return nil
+}
+
+// Stop the background walk and wait until the walking goroutine is finished. The collected files are kept
+func (finder *FileFinder) Cancel() {
+	if !finder.started {
+		return
+	}
+
+	select {
+	case <-finder.cancel:
+	default:
+		close(finder.cancel)
+	}
+
+	<-finder.finished
+}
+
+// Return a bool value indicating if the walk of the directory tree is finished
+func (finder *FileFinder) IsDone() bool {
+	finder.mutex.Lock()
+	defer finder.mutex.Unlock()
+
+	return finder.done
+}
+
+// Return the count of the collected files
+func (finder *FileFinder) GetFileCount() int {
+	finder.mutex.Lock()
+	defer finder.mutex.Unlock()
+
+	return len(finder.files)
+}
+
+// Return the collected files (relative to the searched directory) matching the query. The matches are ranked by the score
+// (the best match first) and only the given count of matches is returned. All files are matching the empty query
+func (finder *FileFinder) Match(query string, limit int) []FinderMatch {
+	// NOTE: The walking goroutine is only appending the files, so the collected part can be read without holding the lock
+	finder.mutex.Lock()
+	files := finder.files
+	finder.mutex.Unlock()
+
+	queryRunes := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	matches := make([]FinderMatch, 0)
+
+	for _, file := range files {
+		score, matched := scoreFuzzyMatch(queryRunes, file)
+		if matched {
+			matches = append(matches, FinderMatch{Path: file, Score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+
+		if len(matches[i].Path) != len(matches[j].Path) {
+			return len(matches[i].Path) < len(matches[j].Path)
+		}
+
+		return matches[i].Path < matches[j].Path
+	})
+
+	if limit >= 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+// Return the given count of the first lines of the file (relative to the searched directory). The tabs are replaced with spaces
+func (finder *FileFinder) GetPreview(relativePath string, lineCount int) ([]string, error) {
+	file, err := os.Open(filepath.Join(finder.directory, relativePath))
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	lines := make([]string, 0, lineCount)
+	scanner := bufio.NewScanner(file)
+
+	for len(lines) < lineCount && scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		lines = append(lines, strings.ReplaceAll(line, "\t", "    "))
+	}
+
+	// NOTE: The preview is only informative, so the lines exceeding the scanner buffer are not treated as an error
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Helper function used to walk the directory tree and collect the files. The function is executed as a separate goroutine
+func (finder *FileFinder) walk() {
+	defer restoreBackgroundConsoleOnPanic()
+	defer close(finder.finished)
+
+	gitignore := new(Gitignore)
+	collected := 0
+
+	_ = filepath.WalkDir(finder.directory, func(filePath string, entry fs.DirEntry, err error) error {
+		select {
+		case <-finder.cancel:
+			return errFinderCancelled
+		default:
+		}
+
+		// NOTE: The unreadable entries are skipped without stopping the whole walk
+		if err != nil {
+			if entry != nil && entry.IsDir() && filePath != finder.directory {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(finder.directory, filePath)
+		if err != nil {
+			return nil
+		}
+
+		slashPath := filepath.ToSlash(relativePath)
+
+		if entry.IsDir() {
+			if slashPath != "." {
+				if entry.Name() == finderGitDirectory || gitignore.IsIgnored(slashPath, true) {
+					return filepath.SkipDir
+				}
+			} else {
+				slashPath = ""
+			}
+
+			if content, err := os.ReadFile(filepath.Join(filePath, finderGitignoreFile)); err == nil {
+				gitignore.AddPatterns(slashPath, string(content))
+			}
+
+			return nil
+		}
+
+		if gitignore.IsIgnored(slashPath, false) || !isRegularFile(filePath, entry) || isBinaryFile(filePath) {
+			return nil
+		}
+
+		finder.mutex.Lock()
+		finder.files = append(finder.files, slashPath)
+		finder.mutex.Unlock()
+
+		collected += 1
+		if collected >= finderFileLimit {
+			return errFinderLimitReached
+		}
+
+		if collected%finderNotifyInterval == 0 && finder.notify != nil {
+			finder.notify()
+		}
+
+		return nil
+	})
+
+	finder.mutex.Lock()
+	finder.done = true
+	finder.mutex.Unlock()
+
+	if finder.notify != nil {
+		finder.notify()
+	}
+}
+
+// Helper function used to calculate the score of the fuzzy match of the query (lower case runes) and the candidate path. The
+// query characters have to appear in the candidate in the same order. The consecutive characters, the characters at the start
+// of words and the matches inside the file name are increasing the score. The function returns the score and a bool value
+// indicating if the candidate is matching
+func scoreFuzzyMatch(query []rune, candidate string) (int, bool) {
+	candidateRunes := []rune(candidate)
+
+	score, matched := scoreFuzzySubsequence(query, candidateRunes)
+	if !matched {
+		return 0, false
+	}
+
+	nameStart := strings.LastIndex(candidate, "/") + 1
+	nameRunes := []rune(candidate[nameStart:])
+
+	// NOTE: The query matching only the file name is preferred over the query scattered through the directories
+	if nameScore, nameMatched := scoreFuzzySubsequence(query, nameRunes); nameMatched && len(query) > 0 {
+		score += nameScore + 10
+	}
+
+	return score, true
+}
+
+// Helper function used to score the first (leftmost) occurrence of the query as a subsequence of the candidate
+func scoreFuzzySubsequence(query []rune, candidate []rune) (int, bool) {
+	score := 0
+	queryIndex := 0
+	previousIndex := -2
+
+	for index := 0; index < len(candidate) && queryIndex < len(query); index += 1 {
+		if unicode.ToLower(candidate[index]) != query[queryIndex] {
+			continue
+		}
+
+		score += 1
+
+		if index == previousIndex+1 {
+			score += 5
+		}
+
+		if isWordStart(candidate, index) {
+			score += 8
+		}
+
+		previousIndex = index
+		queryIndex += 1
+	}
+
+	return score, queryIndex == len(query)
+}
+
+// Helper function used to check if the character at the given index is starting a word (after a separator or a camel case boundary)
+func isWordStart(candidate []rune, index int) bool {
+	if index == 0 {
+		return true
+	}
+
+	previous := candidate[index-1]
+	if strings.ContainsRune("/\\_-. ", previous) {
+		return true
+	}
+
+	return unicode.IsLower(previous) && unicode.IsUpper(candidate[index])
+}
+
+// Helper function used to check if the entry is a regular file. The symbolic links are resolved
+func isRegularFile(filePath string, entry fs.DirEntry) bool {
+	if entry.Type().IsRegular() {
+		return true
+	}
+
+	if entry.Type()&fs.ModeSymlink == 0 {
+		return false
+	}
+
+	info, err := os.Stat(filePath)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Helper function used to check if the file is a binary file. Similar to git, the file containing a null byte at the beginning
+// is considered binary. The unreadable files are also treated as binary
+func isBinaryFile(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return true
+	}
+
+	defer file.Close()
+
+	probe := make([]byte, finderBinaryProbe)
+	count, err := io.ReadFull(file, probe)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	return bytes.IndexByte(probe[:count], 0) >= 0
+}
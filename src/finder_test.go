@@ -0,0 +1,107 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFinderShouldCollectFilesRespectingGitignore(t *testing.T) {
+	directory := createFinderTestDirectory(t)
+
+	finder := new(FileFinder)
+	if err := finder.Init(directory, nil); err != nil {
+		t.FailNow()
+	}
+
+	if err := finder.Start(); err != nil {
+		t.FailNow()
+	}
+
+	<-finder.finished
+
+	if !finder.IsDone() {
+		t.Fail()
+	}
+
+	files := make(map[string]bool)
+	for _, match := range finder.Match("", -1) {
+		files[match.Path] = true
+	}
+
+	if len(files) != 5 || !files[".gitignore"] || !files["main.go"] || !files["src/.gitignore"] || !files["src/editor.go"] || !files["src/keep.log"] {
+		t.Fail()
+	}
+
+	if err := finder.Start(); err == nil {
+		t.Fail()
+	}
+}
+
+func TestFinderShouldRankMatches(t *testing.T) {
+	directory := createFinderTestDirectory(t)
+
+	finder := new(FileFinder)
+	if err := finder.Init(directory, nil); err != nil {
+		t.FailNow()
+	}
+
+	if err := finder.Start(); err != nil {
+		t.FailNow()
+	}
+
+	finder.Cancel()
+	<-finder.finished
+
+	finder.files = []string{"src/editor_main.go", "main.go", "docs/manual.txt", "mapping/ain.go"}
+
+	matches := finder.Match("main", 2)
+	if len(matches) != 2 || matches[0].Path != "main.go" || matches[1].Path != "src/editor_main.go" {
+		t.Fail()
+	}
+
+	if len(finder.Match("xyz", -1)) != 0 {
+		t.Fail()
+	}
+}
+
+func TestFinderShouldReturnPreview(t *testing.T) {
+	directory := createFinderTestDirectory(t)
+
+	finder := new(FileFinder)
+	if err := finder.Init(directory, nil); err != nil {
+		t.FailNow()
+	}
+
+	preview, err := finder.GetPreview("main.go", 2)
+	if err != nil || len(preview) != 2 || preview[1] != "    return" {
+		t.Fail()
+	}
+
+	if _, err := finder.GetPreview("missing.go", 2); err == nil {
+		t.Fail()
+	}
+}
+
+func TestFinderShouldNotInitForFile(t *testing.T) {
+	directory := createFinderTestDirectory(t)
+
+	finder := new(FileFinder)
+	if err := finder.Init(filepath.Join(directory, "main.go"), nil); err == nil {
+		t.Fail()
+	}
+}
+
+func createFinderTestDirectory(t *testing.T) string {
+	return createTestDirectory(t, map[string]string{
+		".gitignore":       "*.log\nbin/\n",
+		"main.go":          "func main() {\n\treturn\n}\n",
+		"debug.log":        "log",
+		"image.png":        "\x89PNG\x00\x00",
+		"bin/termpad":      "binary",
+		".git/config":      "config",
+		"src/editor.go":    "package main\n",
+		"src/.gitignore":   "!keep.log\nbuild/\n",
+		"src/keep.log":     "log",
+		"src/build/out.go": "package build\n",
+	})
+}
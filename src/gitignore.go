@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"path"
+	"strings"
+)
+
+// Structure representing a single pattern of the .gitignore file. The pattern is relative to the directory containing the file
+type gitignoreRule struct {
+	base          string
+	segments      []string
+	negate        bool
+	directoryOnly bool
+	anchored      bool
+}
+
+// Structure representing the set of .gitignore patterns collected from the directories of a tree. The patterns are evaluated
+// in the order of appearance and the last matching pattern decides if the path is ignored (the negated patterns are re-including
+// the paths)
+type Gitignore struct {
+	rules []gitignoreRule
+}
+
+// Add the patterns of a .gitignore file located in the given directory. The directory is specified as a slash separated path
+// relative to the root of the tree (empty for the root directory)
+func (gitignore *Gitignore) AddPatterns(base string, content string) {
+	base = strings.Trim(base, "/")
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		// NOTE: The trailing spaces are ignored unless they are escaped with a backslash
+		if !strings.HasSuffix(line, "\\ ") {
+			line = strings.TrimRight(line, " ")
+		}
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := gitignoreRule{base: base}
+
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.directoryOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		// NOTE: The pattern containing a separator (except the trailing one) is matched against the whole relative path
+		rule.anchored = strings.Contains(line, "/")
+		line = strings.TrimLeft(line, "/")
+
+		if len(line) == 0 {
+			continue
+		}
+
+		rule.segments = strings.Split(line, "/")
+		gitignore.rules = append(gitignore.rules, rule)
+	}
+}
+
+// Return a bool value indicating if the given path is ignored. The path is specified as a slash separated path relative
+// to the root of the tree
+func (gitignore *Gitignore) IsIgnored(relativePath string, isDirectory bool) bool {
+	relativePath = strings.Trim(relativePath, "/")
+	ignored := false
+
+	for _, rule := range gitignore.rules {
+		if rule.directoryOnly && !isDirectory {
+			continue
+		}
+
+		if rule.matches(relativePath) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+// Helper function used to check if the pattern of the rule is matching the given path relative to the root of the tree
+func (rule *gitignoreRule) matches(relativePath string) bool {
+	if len(rule.base) > 0 {
+		if !strings.HasPrefix(relativePath, rule.base+"/") {
+			return false
+		}
+
+		relativePath = relativePath[len(rule.base)+1:]
+	}
+
+	pathSegments := strings.Split(relativePath, "/")
+	if !rule.anchored {
+		matched, err := path.Match(rule.segments[0], pathSegments[len(pathSegments)-1])
+		return err == nil && matched
+	}
+
+	return matchGitignoreSegments(rule.segments, pathSegments)
+}
+
+// Helper function used to match the path segments against the pattern segments. The ,,**” segment is matching any count
+// (including zero) of path segments
+func matchGitignoreSegments(patternSegments []string, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+
+	if patternSegments[0] == "**" {
+		for index := 0; index <= len(pathSegments); index += 1 {
+			if matchGitignoreSegments(patternSegments[1:], pathSegments[index:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(pathSegments) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(patternSegments[0], pathSegments[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchGitignoreSegments(patternSegments[1:], pathSegments[1:])
+}
@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestGitignoreShouldIgnoreMatchingNames(t *testing.T) {
+	gitignore := new(Gitignore)
+	gitignore.AddPatterns("", "# comment\n*.log\nbuild/\n")
+
+	if !gitignore.IsIgnored("debug.log", false) || !gitignore.IsIgnored("src/debug.log", false) {
+		t.Fail()
+	}
+
+	if !gitignore.IsIgnored("build", true) || !gitignore.IsIgnored("src/build", true) {
+		t.Fail()
+	}
+
+	if gitignore.IsIgnored("build", false) || gitignore.IsIgnored("main.go", false) {
+		t.Fail()
+	}
+}
+
+func TestGitignoreShouldMatchAnchoredPatterns(t *testing.T) {
+	gitignore := new(Gitignore)
+	gitignore.AddPatterns("", "/vendor\ndocs/*.md\n")
+
+	if !gitignore.IsIgnored("vendor", true) || gitignore.IsIgnored("src/vendor", true) {
+		t.Fail()
+	}
+
+	if !gitignore.IsIgnored("docs/readme.md", false) || gitignore.IsIgnored("docs/api/readme.md", false) {
+		t.Fail()
+	}
+}
+
+func TestGitignoreShouldMatchDoubleAsterisk(t *testing.T) {
+	gitignore := new(Gitignore)
+	gitignore.AddPatterns("", "**/temp/**\n")
+
+	if !gitignore.IsIgnored("temp/a.txt", false) || !gitignore.IsIgnored("src/temp/b/c.txt", false) {
+		t.Fail()
+	}
+
+	if gitignore.IsIgnored("src/template.txt", false) {
+		t.Fail()
+	}
+}
+
+func TestGitignoreShouldApplyNegationAndBase(t *testing.T) {
+	gitignore := new(Gitignore)
+	gitignore.AddPatterns("", "*.txt\n")
+	gitignore.AddPatterns("docs", "!keep.txt\n")
+
+	if !gitignore.IsIgnored("notes.txt", false) || !gitignore.IsIgnored("keep.txt", false) {
+		t.Fail()
+	}
+
+	if gitignore.IsIgnored("docs/keep.txt", false) || !gitignore.IsIgnored("docs/notes.txt", false) {
+		t.Fail()
+	}
+}
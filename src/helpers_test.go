@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Helper function used to create the given files (slash separated paths relative to the directory mapped to the content) inside
+// a temporary directory, which is removed after the test. The parent directories are created if needed
+func createTestDirectory(t *testing.T, files map[string]string) string {
+	directory := t.TempDir()
+
+	for filePath, content := range files {
+		fullPath := filepath.Join(directory, filepath.FromSlash(filePath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.FailNow()
+		}
+
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.FailNow()
+		}
+	}
+
+	return directory
+}
+
+// Helper function used to create a single file with the given name and content inside a temporary directory and return the path
+func createTestFile(t *testing.T, fileName string, content string) string {
+	return filepath.Join(createTestDirectory(t, map[string]string{fileName: content}), fileName)
+}
@@ -0,0 +1,435 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Type representing the kind of a highlighted text token
+type TokenType int16
+
+const (
+	TokenNone TokenType = iota
+	TokenKeyword
+	TokenBuiltin
+	TokenString
+	TokenComment
+	TokenNumber
+	TokenSpecial
+)
+
+// Structure representing the tokenizer state at the line start, required to highlight the multi-line comments and strings
+type HighlightState struct {
+	BlockComment    bool
+	StringDelimiter string
+}
+
+// Structure representing a language specific pattern (e.g. YAML keys or Markdown headings). The expression must be anchored
+// with the ^ character. The first capture group (if present) is highlighted, otherwise the whole match is highlighted
+type LanguagePattern struct {
+	Expression *regexp.Regexp
+	Token      TokenType
+	LineStart  bool
+}
+
+// Structure representing the definition of a language used by the syntax highlighting
+type LanguageDefinition struct {
+	Name                      string
+	Extensions                []string
+	FileNames                 []string
+	Keywords                  []string
+	Types                     []string
+	LineComments              []string
+	BlockCommentStart         string
+	BlockCommentEnd           string
+	StringDelimiters          []string
+	MultiLineStringDelimiters []string
+	EscapeCharacter           rune
+	HighlightNumbers          bool
+	Patterns                  []LanguagePattern
+
+	words map[string]TokenType
+}
+
+// Return the language definition matching the extension or the name of the given file. Returns nil if the language is not supported
+func DetectLanguage(fileName string) *LanguageDefinition {
+	baseName := strings.ToLower(filepath.Base(fileName))
+	extension := strings.ToLower(filepath.Ext(fileName))
+
+	for _, language := range builtInLanguages {
+		for _, languageFileName := range language.FileNames {
+			if baseName == languageFileName {
+				return language
+			}
+		}
+
+		for _, languageExtension := range language.Extensions {
+			if len(extension) > 0 && extension == languageExtension {
+				return language
+			}
+		}
+	}
+
+	return nil
+}
+
+// Split the given line into tokens starting with the given tokenizer state. The function returns the token type of each
+// character and the tokenizer state at the end of the line (used as the state of the following line)
+func (language *LanguageDefinition) TokenizeLine(buffer []rune, state HighlightState) ([]TokenType, HighlightState) {
+	tokens := make([]TokenType, len(buffer))
+	xIndex := 0
+
+	// NOTE: Continuation of the multi-line comment or string started in one of the previous lines
+	if state.BlockComment {
+		xEnd := indexOfRunes(buffer, []rune(language.BlockCommentEnd), 0)
+		if xEnd < 0 {
+			fillTokens(tokens, 0, len(buffer), TokenComment)
+			return tokens, state
+		}
+
+		xIndex = xEnd + utf8.RuneCountInString(language.BlockCommentEnd)
+		fillTokens(tokens, 0, xIndex, TokenComment)
+		state.BlockComment = false
+	} else if len(state.StringDelimiter) > 0 {
+		xEnd := language.indexOfStringEnd(buffer, []rune(state.StringDelimiter), 0)
+		if xEnd < 0 {
+			fillTokens(tokens, 0, len(buffer), TokenString)
+			return tokens, state
+		}
+
+		xIndex = xEnd
+		fillTokens(tokens, 0, xIndex, TokenString)
+		state.StringDelimiter = ""
+	}
+
+	for xIndex < len(buffer) {
+		if len(language.BlockCommentStart) > 0 && hasRunesAtOffset(buffer, []rune(language.BlockCommentStart), xIndex) {
+			xStart := xIndex + utf8.RuneCountInString(language.BlockCommentStart)
+
+			xEnd := indexOfRunes(buffer, []rune(language.BlockCommentEnd), xStart)
+			if xEnd < 0 {
+				fillTokens(tokens, xIndex, len(buffer), TokenComment)
+				state.BlockComment = true
+				return tokens, state
+			}
+
+			xEnd += utf8.RuneCountInString(language.BlockCommentEnd)
+			fillTokens(tokens, xIndex, xEnd, TokenComment)
+			xIndex = xEnd
+			continue
+		}
+
+		if language.matchesAnyAtOffset(buffer, language.LineComments, xIndex) != "" {
+			fillTokens(tokens, xIndex, len(buffer), TokenComment)
+			return tokens, state
+		}
+
+		if delimiter := language.matchesAnyAtOffset(buffer, language.MultiLineStringDelimiters, xIndex); delimiter != "" {
+			xEnd := language.indexOfStringEnd(buffer, []rune(delimiter), xIndex+utf8.RuneCountInString(delimiter))
+			if xEnd < 0 {
+				fillTokens(tokens, xIndex, len(buffer), TokenString)
+				state.StringDelimiter = delimiter
+				return tokens, state
+			}
+
+			fillTokens(tokens, xIndex, xEnd, TokenString)
+			xIndex = xEnd
+			continue
+		}
+
+		if xEnd, matched := language.applyPatterns(buffer, tokens, xIndex); matched {
+			xIndex = xEnd
+			continue
+		}
+
+		if delimiter := language.matchesAnyAtOffset(buffer, language.StringDelimiters, xIndex); delimiter != "" {
+			// NOTE: The unterminated single-line string is highlighted to the end of the line
+			xEnd := language.indexOfStringEnd(buffer, []rune(delimiter), xIndex+utf8.RuneCountInString(delimiter))
+			if xEnd < 0 {
+				xEnd = len(buffer)
+			}
+
+			fillTokens(tokens, xIndex, xEnd, TokenString)
+			xIndex = xEnd
+			continue
+		}
+
+		wordStart := xIndex == 0 || !isWordCharacter(buffer[xIndex-1])
+
+		if language.HighlightNumbers && wordStart && buffer[xIndex] >= '0' && buffer[xIndex] <= '9' {
+			xEnd := xIndex
+			for xEnd < len(buffer) && (isWordCharacter(buffer[xEnd]) || buffer[xEnd] == '.') {
+				xEnd += 1
+			}
+
+			fillTokens(tokens, xIndex, xEnd, TokenNumber)
+			xIndex = xEnd
+			continue
+		}
+
+		if wordStart && isWordCharacter(buffer[xIndex]) {
+			xEnd := xIndex
+			for xEnd < len(buffer) && isWordCharacter(buffer[xEnd]) {
+				xEnd += 1
+			}
+
+			if token, ok := language.getWords()[string(buffer[xIndex:xEnd])]; ok {
+				fillTokens(tokens, xIndex, xEnd, token)
+			}
+
+			xIndex = xEnd
+			continue
+		}
+
+		xIndex += 1
+	}
+
+	return tokens, state
+}
+
+// Helper function used to return the keywords and types lookup, which is created on the first usage
+func (language *LanguageDefinition) getWords() map[string]TokenType {
+	if language.words == nil {
+		language.words = make(map[string]TokenType, len(language.Keywords)+len(language.Types))
+
+		for _, keyword := range language.Keywords {
+			language.words[keyword] = TokenKeyword
+		}
+
+		for _, typeName := range language.Types {
+			language.words[typeName] = TokenBuiltin
+		}
+	}
+
+	return language.words
+}
+
+// Helper function used to apply the language patterns at the given offset. The patterns are only checked at the word start.
+// Returns the offset after the match and a bool value indicating if any pattern matched
+func (language *LanguageDefinition) applyPatterns(buffer []rune, tokens []TokenType, xIndex int) (int, bool) {
+	if len(language.Patterns) == 0 || (xIndex > 0 && isWordCharacter(buffer[xIndex-1])) {
+		return xIndex, false
+	}
+
+	remainingString := string(buffer[xIndex:])
+
+	for _, pattern := range language.Patterns {
+		if pattern.LineStart && xIndex != 0 {
+			continue
+		}
+
+		location := pattern.Expression.FindStringSubmatchIndex(remainingString)
+		if location == nil || location[0] != 0 || location[1] == 0 {
+			continue
+		}
+
+		tokenStart, tokenEnd := location[0], location[1]
+		if len(location) >= 4 && location[2] >= 0 {
+			tokenStart, tokenEnd = location[2], location[3]
+		}
+
+		fillTokens(tokens,
+			xIndex+utf8.RuneCountInString(remainingString[:tokenStart]),
+			xIndex+utf8.RuneCountInString(remainingString[:tokenEnd]),
+			pattern.Token)
+
+		return xIndex + utf8.RuneCountInString(remainingString[:location[1]]), true
+	}
+
+	return xIndex, false
+}
+
+// Helper function used to return the first of the given sequences present at the given offset. Returns an empty string if none matches
+func (language *LanguageDefinition) matchesAnyAtOffset(buffer []rune, sequences []string, xIndex int) string {
+	for _, sequence := range sequences {
+		if hasRunesAtOffset(buffer, []rune(sequence), xIndex) {
+			return sequence
+		}
+	}
+
+	return ""
+}
+
+// Helper function used to find the offset after the closing string delimiter, skipping the escaped characters. Returns -1 if not found
+func (language *LanguageDefinition) indexOfStringEnd(buffer []rune, delimiter []rune, xStart int) int {
+	for xIndex := xStart; xIndex < len(buffer); xIndex += 1 {
+		if language.EscapeCharacter != 0 && buffer[xIndex] == language.EscapeCharacter && len(delimiter) == 1 {
+			xIndex += 1
+			continue
+		}
+
+		if hasRunesAtOffset(buffer, delimiter, xIndex) {
+			return xIndex + len(delimiter)
+		}
+	}
+
+	return -1
+}
+
+// Helper function used to find the offset of the given sequence starting from the given offset. Returns -1 if not found
+func indexOfRunes(buffer []rune, sequence []rune, xStart int) int {
+	if len(sequence) == 0 {
+		return -1
+	}
+
+	for xIndex := xStart; xIndex+len(sequence) <= len(buffer); xIndex += 1 {
+		if hasRunesAtOffset(buffer, sequence, xIndex) {
+			return xIndex
+		}
+	}
+
+	return -1
+}
+
+// Helper function used to check if the given sequence is present at the given offset
+func hasRunesAtOffset(buffer []rune, sequence []rune, xIndex int) bool {
+	if len(sequence) == 0 || xIndex+len(sequence) > len(buffer) {
+		return false
+	}
+
+	for index, char := range sequence {
+		if buffer[xIndex+index] != char {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Helper function used to set the given token type in the given range
+func fillTokens(tokens []TokenType, xStart int, xEnd int, token TokenType) {
+	for xIndex := xStart; xIndex < xEnd && xIndex < len(tokens); xIndex += 1 {
+		tokens[xIndex] = token
+	}
+}
+
+// Structure representing the syntax highlighter of a text. The tokens are cached per line and only the changed lines (or the
+// lines with a changed multi-line comment/string state) are tokenized again
+type Highlighter struct {
+	text           *Text
+	language       *LanguageDefinition
+	cache          map[*Line]highlightCacheEntry
+	states         []HighlightState
+	statesRevision int
+}
+
+// Helper structure representing the cached tokens of a single line
+type highlightCacheEntry struct {
+	revision   int
+	startState HighlightState
+	endState   HighlightState
+	tokens     []TokenType
+}
+
+// Highlighter structure initialization function. The language is selected based on the given file name
+func (highlighter *Highlighter) Init(text *Text, fileName string) error {
+	if text == nil {
+		return errors.New("highlighter: invalid text struct reference")
+	}
+
+	highlighter.text = text
+	highlighter.language = DetectLanguage(fileName)
+	highlighter.cache = make(map[*Line]highlightCacheEntry)
+	highlighter.states = make([]HighlightState, 0)
+	highlighter.statesRevision = text.GetRevision()
+
+	return nil
+}
+
+// Return the name of the highlighted language or an empty string if the language is not supported
+func (highlighter *Highlighter) GetLanguageName() string {
+	if highlighter.language == nil {
+		return ""
+	}
+
+	return highlighter.language.Name
+}
+
+// Return the token types of the characters of the line specified by the y (vertical) offset. Returns nil if the language is not supported
+func (highlighter *Highlighter) GetLineTokens(yOffset int) ([]TokenType, error) {
+	if highlighter.language == nil {
+		return nil, nil
+	}
+
+	if yOffset < 0 || yOffset >= highlighter.text.GetLineCount() {
+		return nil, errors.New("highlighter: invalid y (vertical) offset requested to highlight")
+	}
+
+	if err := highlighter.updateStates(yOffset); err != nil {
+		return nil, err
+	}
+
+	entry, err := highlighter.highlightLine(yOffset, highlighter.states[yOffset])
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.tokens, nil
+}
+
+// Helper function used to calculate the tokenizer states at the start of all lines up to the given y (vertical) offset. The states
+// are recalculated after the text has changed starting from the first changed line, the states of the lines above are kept
+func (highlighter *Highlighter) updateStates(yOffset int) error {
+	if highlighter.statesRevision != highlighter.text.GetRevision() {
+		// NOTE: The start state of the line depends only on the lines above, so the state of the first changed line is still valid
+		yChanged, ok := highlighter.text.GetFirstChangedOffset(highlighter.statesRevision)
+		if !ok {
+			yChanged = 0
+		}
+
+		if yChanged+1 < len(highlighter.states) {
+			highlighter.states = highlighter.states[:yChanged+1]
+		}
+
+		highlighter.statesRevision = highlighter.text.GetRevision()
+
+		// NOTE: Dropping the entries of the removed lines, the remaining entries are created again on demand
+		if len(highlighter.cache) > 2*highlighter.text.GetLineCount()+64 {
+			highlighter.cache = make(map[*Line]highlightCacheEntry)
+		}
+	}
+
+	if len(highlighter.states) == 0 {
+		highlighter.states = append(highlighter.states, HighlightState{})
+	}
+
+	for len(highlighter.states) <= yOffset {
+		yIndex := len(highlighter.states) - 1
+
+		entry, err := highlighter.highlightLine(yIndex, highlighter.states[yIndex])
+		if err != nil {
+			return err
+		}
+
+		highlighter.states = append(highlighter.states, entry.endState)
+	}
+
+	return nil
+}
+
+// Helper function used to return the cached tokens of the line or tokenize the line if it has changed
+func (highlighter *Highlighter) highlightLine(yOffset int, startState HighlightState) (highlightCacheEntry, error) {
+	line, err := highlighter.text.getLineByOffset(yOffset)
+	if err != nil {
+		return highlightCacheEntry{}, err
+	}
+
+	if entry, ok := highlighter.cache[line]; ok && entry.revision == line.GetRevision() && entry.startState == startState {
+		return entry, nil
+	}
+
+	tokens, endState := highlighter.language.TokenizeLine(line.GetBufferAsSlice(), startState)
+
+	entry := highlightCacheEntry{
+		revision:   line.GetRevision(),
+		startState: startState,
+		endState:   endState,
+		tokens:     tokens,
+	}
+
+	highlighter.cache[line] = entry
+	return entry, nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import "testing"
+
+func TestHighlightShouldDetectLanguageByExtension(t *testing.T) {
+	expected := map[string]string{
+		"main.go":           "Go",
+		"package.json":      "JSON",
+		"config.YML":        "YAML",
+		"docs/README.md":    "Markdown",
+		"install.sh":        "Shell",
+		"/home/user/.zshrc": "Shell",
+	}
+
+	for fileName, languageName := range expected {
+		language := DetectLanguage(fileName)
+		if language == nil || language.Name != languageName {
+			t.Fail()
+		}
+	}
+
+	if DetectLanguage("notes.txt") != nil {
+		t.Fail()
+	}
+}
+
+func TestHighlightShouldTokenizeGoLine(t *testing.T) {
+	language := DetectLanguage("main.go")
+	buffer := []rune(`func x() int { return 42 } // "c"`)
+
+	tokens, state := language.TokenizeLine(buffer, HighlightState{})
+
+	if tokens[0] != TokenKeyword || tokens[3] != TokenKeyword || tokens[4] != TokenNone {
+		t.Fail()
+	}
+
+	if tokens[9] != TokenBuiltin || tokens[15] != TokenKeyword || tokens[22] != TokenNumber {
+		t.Fail()
+	}
+
+	if tokens[27] != TokenComment || tokens[len(tokens)-1] != TokenComment {
+		t.Fail()
+	}
+
+	if state != (HighlightState{}) {
+		t.Fail()
+	}
+}
+
+func TestHighlightShouldTokenizeStringWithEscapedDelimiter(t *testing.T) {
+	language := DetectLanguage("main.go")
+	buffer := []rune(`a := "x\"y" + b`)
+
+	tokens, _ := language.TokenizeLine(buffer, HighlightState{})
+
+	for xIndex := 5; xIndex <= 10; xIndex += 1 {
+		if tokens[xIndex] != TokenString {
+			t.Fail()
+		}
+	}
+
+	if tokens[12] != TokenNone || tokens[14] != TokenNone {
+		t.Fail()
+	}
+}
+
+func TestHighlightShouldTrackMultiLineCommentState(t *testing.T) {
+	language := DetectLanguage("main.go")
+
+	tokens, state := language.TokenizeLine([]rune("x /* start"), HighlightState{})
+	if tokens[0] != TokenNone || tokens[2] != TokenComment || !state.BlockComment {
+		t.Fail()
+	}
+
+	tokens, state = language.TokenizeLine([]rune("inside"), state)
+	if tokens[0] != TokenComment || !state.BlockComment {
+		t.Fail()
+	}
+
+	tokens, state = language.TokenizeLine([]rune("end */ var"), state)
+	if tokens[5] != TokenComment || tokens[7] != TokenKeyword || state.BlockComment {
+		t.Fail()
+	}
+}
+
+func TestHighlightShouldTokenizeLanguagePatterns(t *testing.T) {
+	tokens, _ := DetectLanguage("a.json").TokenizeLine([]rune(`{"key": "value"}`), HighlightState{})
+	if tokens[1] != TokenSpecial || tokens[9] != TokenString {
+		t.Fail()
+	}
+
+	tokens, _ = DetectLanguage("a.yaml").TokenizeLine([]rune(`  name: true # note`), HighlightState{})
+	if tokens[2] != TokenSpecial || tokens[6] != TokenNone || tokens[8] != TokenKeyword || tokens[13] != TokenComment {
+		t.Fail()
+	}
+
+	tokens, _ = DetectLanguage("a.md").TokenizeLine([]rune(`## Title`), HighlightState{})
+	if tokens[0] != TokenKeyword || tokens[7] != TokenKeyword {
+		t.Fail()
+	}
+
+	tokens, _ = DetectLanguage("a.sh").TokenizeLine([]rune(`echo $HOME # x`), HighlightState{})
+	if tokens[0] != TokenNone || tokens[5] != TokenSpecial || tokens[12] != TokenComment {
+		t.Fail()
+	}
+}
+
+func TestHighlighterShouldUpdateStateOfFollowingLinesAfterChange(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("x := 1\ny := 2\nz := 3", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	highlighter := new(Highlighter)
+	if err := highlighter.Init(text, "main.go"); err != nil {
+		t.Fail()
+	}
+
+	tokens, err := highlighter.GetLineTokens(2)
+	if err != nil || tokens[5] != TokenNumber {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	if _, _, err := text.InsertText("/*", cursor); err != nil {
+		t.Fail()
+	}
+
+	tokens, err = highlighter.GetLineTokens(2)
+	if err != nil || tokens[5] != TokenComment {
+		t.Fail()
+	}
+}
+
+func TestHighlighterShouldKeepStatesAboveChangedLine(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("/* x\ny */\nz := 3\nw := 4", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	highlighter := new(Highlighter)
+	if err := highlighter.Init(text, "main.go"); err != nil {
+		t.FailNow()
+	}
+
+	if _, err := highlighter.GetLineTokens(3); err != nil || len(highlighter.states) != 4 {
+		t.FailNow()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 2, CreateConsoleMockup(), nil); err != nil {
+		t.FailNow()
+	}
+
+	if _, _, err := text.InsertText("/*", cursor); err != nil {
+		t.FailNow()
+	}
+
+	// NOTE: The states of the lines up to the changed line (including) are kept and the following states are recalculated
+	if err := highlighter.updateStates(0); err != nil || len(highlighter.states) != 3 || !highlighter.states[1].BlockComment {
+		t.Fail()
+	}
+
+	tokens, err := highlighter.GetLineTokens(3)
+	if err != nil || tokens[5] != TokenComment {
+		t.Fail()
+	}
+}
+
+func TestHighlighterShouldNotHighlightUnsupportedLanguage(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("func x() {}", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	highlighter := new(Highlighter)
+	if err := highlighter.Init(text, "notes.txt"); err != nil {
+		t.Fail()
+	}
+
+	tokens, err := highlighter.GetLineTokens(0)
+	if err != nil || tokens != nil {
+		t.Fail()
+	}
+}
@@ -2,10 +2,34 @@ package main
 
 import "errors"
 
-// Structure representing the edistors text history stack (LIFO)
+// Type representing the kind of a single text edit stored on the history stack
+type HistoryEditType int16
+
+const (
+	HistoryEditInsert HistoryEditType = iota
+	HistoryEditRemove
+)
+
+// Structure representing a single text edit. The content can span multiple lines separated by the LF (0x0A) character
+type HistoryEdit struct {
+	Type    HistoryEditType
+	XOffset int
+	YOffset int
+	Content []rune
+}
+
+// Helper structure representing a single undo/redo step, which can be build from multiple edits
+type historyEntry struct {
+	edits []HistoryEdit
+}
+
+// Structure representing the editors text history stack (LIFO). Instead of whole text snapshots, the history is
+// storing a log of edits which can be reverted (undo) and reapplied (redo)
 type History struct {
-	nodes []Text
-	count int
+	undoEntries  []historyEntry
+	redoEntries  []historyEntry
+	groupDepth   int
+	groupStarted bool
 
 	config *HistoryConfig
 }
@@ -23,42 +47,163 @@ func (history *History) Init(historyConfig *HistoryConfig) error {
 		return errors.New("history: invalid stack size specified in the configuration")
 	}
 
-	history.nodes = make([]Text, history.config.HistoryStackSize)
-	history.count = 0
+	history.undoEntries = make([]historyEntry, 0, history.config.HistoryStackSize)
+	history.redoEntries = make([]historyEntry, 0, history.config.HistoryStackSize)
 
 	return nil
 }
 
-// Add the given state of text to the history stack
-func (history *History) Push(text Text) error {
-	if history.count < history.config.HistoryStackSize {
-		history.nodes[history.count] = text
-		history.count += 1
+// Add the given edit to the history stack. Consecutive single-line edits (e.g. typing) are merged into a single step.
+// Pushing a new edit is discarding all edits that can be reapplied (redo)
+func (history *History) Push(edit HistoryEdit) error {
+	if len(edit.Content) == 0 {
+		return errors.New("history: can not push an edit without content")
+	}
+
+	if edit.XOffset < 0 || edit.YOffset < 0 {
+		return errors.New("history: invalid edit offsets")
+	}
+
+	history.redoEntries = history.redoEntries[:0]
+
+	// NOTE: Edits pushed inside a group are forming a single step
+	if history.groupDepth > 0 && history.groupStarted {
+		lastEntry := &history.undoEntries[len(history.undoEntries)-1]
+		lastEdit := &lastEntry.edits[len(lastEntry.edits)-1]
+
+		if !history.mergeEdits(lastEdit, edit) {
+			lastEntry.edits = append(lastEntry.edits, edit)
+		}
 
 		return nil
 	}
 
-	history.nodes = history.nodes[1:]
-	history.nodes[history.count-1] = text
+	if history.groupDepth > 0 {
+		history.groupStarted = true
+	} else if len(history.undoEntries) > 0 {
+		lastEntry := &history.undoEntries[len(history.undoEntries)-1]
+		lastEdit := &lastEntry.edits[len(lastEntry.edits)-1]
 
+		if history.mergeEdits(lastEdit, edit) {
+			return nil
+		}
+	}
+
+	history.pushEntry(&history.undoEntries, historyEntry{edits: []HistoryEdit{edit}})
 	return nil
 }
 
-// Return the next (LIFO) text state from the history stack
-func (history *History) Pop() (*Text, error) {
-	if history.count == 0 {
-		return nil, errors.New("history: can not retrieve text from empty history stack")
+// Start a group of edits. All edits pushed until the group is ended are reverted and reapplied as a single step. Groups can be nested
+func (history *History) BeginGroup() {
+	history.groupDepth += 1
+}
+
+// End the group of edits started with BeginGroup
+func (history *History) EndGroup() {
+	if history.groupDepth == 0 {
+		return
+	}
+
+	history.groupDepth -= 1
+	if history.groupDepth == 0 {
+		history.groupStarted = false
+	}
+}
+
+// Return the edits of the latest step and move the step to the redo stack. The edits are returned in the order they were applied
+func (history *History) PopUndo() ([]HistoryEdit, error) {
+	if len(history.undoEntries) == 0 {
+		return nil, errors.New("history: can not retrieve edits from empty undo stack")
+	}
+
+	entry := history.undoEntries[len(history.undoEntries)-1]
+	history.undoEntries = history.undoEntries[:len(history.undoEntries)-1]
+
+	history.pushEntry(&history.redoEntries, entry)
+	return entry.edits, nil
+}
+
+// Return the edits of the latest reverted step and move the step back to the undo stack. The edits are returned in the order they were applied
+func (history *History) PopRedo() ([]HistoryEdit, error) {
+	if len(history.redoEntries) == 0 {
+		return nil, errors.New("history: can not retrieve edits from empty redo stack")
 	}
 
-	text := history.nodes[history.count-1]
-	history.count -= 1
+	entry := history.redoEntries[len(history.redoEntries)-1]
+	history.redoEntries = history.redoEntries[:len(history.redoEntries)-1]
+
+	history.pushEntry(&history.undoEntries, entry)
+	return entry.edits, nil
+}
+
+// Return a bool value indicating if there are any steps that can be reverted
+func (history *History) CanUndo() bool {
+	return len(history.undoEntries) > 0
+}
+
+// Return a bool value indicating if there are any reverted steps that can be reapplied
+func (history *History) CanRedo() bool {
+	return len(history.redoEntries) > 0
+}
+
+// Helper function used to push the entry on the given stack. The oldest entry is dropped if the stack size limit is exceeded
+func (history *History) pushEntry(stack *[]historyEntry, entry historyEntry) {
+	if len(*stack) >= history.config.HistoryStackSize {
+		*stack = append((*stack)[:0], (*stack)[1:]...)
+	}
 
-	return &text, nil
+	*stack = append(*stack, entry)
 }
 
-// Return a bool value indicating if there are any text structs on the history stack
-func (history *History) CanPop() bool {
-	return history.count > 0
+// Helper function used to merge the next edit into the previous one. Only single-line edits that are directly
+// following each other (typing, backspace and delete sequences) are merged. Returns a bool value indicating if merged.
+func (history *History) mergeEdits(previous *HistoryEdit, next HistoryEdit) bool {
+	if previous.Type != next.Type || previous.YOffset != next.YOffset {
+		return false
+	}
+
+	if containsLineFeed(previous.Content) || containsLineFeed(next.Content) {
+		return false
+	}
+
+	switch next.Type {
+	case HistoryEditInsert:
+		{
+			// NOTE: Typing, the next insert is placed directly after the previous one
+			if next.XOffset == previous.XOffset+len(previous.Content) {
+				previous.Content = append(previous.Content, next.Content...)
+				return true
+			}
+		}
+	case HistoryEditRemove:
+		{
+			// NOTE: Backspace sequence, the next remove is placed directly before the previous one
+			if next.XOffset+len(next.Content) == previous.XOffset {
+				previous.Content = append(append([]rune{}, next.Content...), previous.Content...)
+				previous.XOffset = next.XOffset
+				return true
+			}
+
+			// NOTE: Delete sequence, the next remove is placed at the same offset as the previous one
+			if next.XOffset == previous.XOffset {
+				previous.Content = append(previous.Content, next.Content...)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Helper function used to check if the given content contains the LF (0x0A) character
+func containsLineFeed(content []rune) bool {
+	for _, char := range content {
+		if char == '\n' {
+			return true
+		}
+	}
+
+	return false
 }
 
 // A structure containing the configuration for the history structure
@@ -16,100 +16,192 @@ func TestHistoryShouldNotInitForInvalidConfig(t *testing.T) {
 	}
 }
 
-func TestHistoryShouldPushText(t *testing.T) {
+func TestHistoryShouldPushEdit(t *testing.T) {
 	history := new(History)
 	if err := history.Init(nil); err != nil {
 		t.Fail()
 	}
 
-	text1 := new(Text)
-	if err := text1.Init("Hello World!", false, GetHistoryTestTextConfigMockup()); err != nil {
+	if history.CanUndo() {
 		t.Fail()
 	}
 
-	if history.CanPop() {
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 0, YOffset: 0, Content: []rune("a")}); err != nil {
 		t.Fail()
 	}
 
-	if err := history.Push(*text1); err != nil {
+	if !history.CanUndo() {
 		t.Fail()
 	}
 
-	if !history.CanPop() {
+	if history.CanRedo() {
+		t.Fail()
+	}
+}
+
+func TestHistoryShouldNotPushInvalidEdit(t *testing.T) {
+	history := new(History)
+	if err := history.Init(nil); err != nil {
 		t.Fail()
 	}
 
-	text2 := new(Text)
-	if err := text2.Init("Hello again!", false, GetHistoryTestTextConfigMockup()); err != nil {
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 0, YOffset: 0, Content: []rune{}}); err == nil {
 		t.Fail()
 	}
 
-	if err := history.Push(*text2); err != nil {
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: -1, YOffset: 0, Content: []rune("a")}); err == nil {
 		t.Fail()
 	}
 }
 
-func TestHistoryShouldPopText(t *testing.T) {
+func TestHistoryShouldMergeConsecutiveTyping(t *testing.T) {
 	history := new(History)
 	if err := history.Init(nil); err != nil {
 		t.Fail()
 	}
 
-	text1 := new(Text)
-	text1String := "Hello World!"
-	if err := text1.Init(text1String, false, GetHistoryTestTextConfigMockup()); err != nil {
+	for index, char := range "Hello" {
+		if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: index, YOffset: 2, Content: []rune{char}}); err != nil {
+			t.Fail()
+		}
+	}
+
+	edits, err := history.PopUndo()
+	if err != nil {
 		t.Fail()
 	}
 
-	if err := history.Push(*text1); err != nil {
+	if len(edits) != 1 || string(edits[0].Content) != "Hello" {
 		t.Fail()
 	}
 
-	text2 := new(Text)
-	text2String := "Hello again!"
-	if err := text2.Init(text2String, false, GetHistoryTestTextConfigMockup()); err != nil {
+	if history.CanUndo() {
 		t.Fail()
 	}
+}
 
-	if err := history.Push(*text2); err != nil {
+func TestHistoryShouldMergeConsecutiveBackspaces(t *testing.T) {
+	history := new(History)
+	if err := history.Init(nil); err != nil {
 		t.Fail()
 	}
 
-	var err error
-	var targetText *Text
-	var expectedText *string
+	for index, char := range "cba" {
+		if err := history.Push(HistoryEdit{Type: HistoryEditRemove, XOffset: 2 - index, YOffset: 0, Content: []rune{char}}); err != nil {
+			t.Fail()
+		}
+	}
 
-	targetText, err = history.Pop()
+	edits, err := history.PopUndo()
 	if err != nil {
 		t.Fail()
 	}
 
-	expectedText, err = targetText.GetTextAsString()
-	if err != nil {
+	if len(edits) != 1 || string(edits[0].Content) != "abc" || edits[0].XOffset != 0 {
 		t.Fail()
 	}
+}
 
-	if *expectedText != text2String {
+func TestHistoryShouldNotMergeLineBreaks(t *testing.T) {
+	history := new(History)
+	if err := history.Init(nil); err != nil {
 		t.Fail()
 	}
 
-	targetText, err = history.Pop()
-	if err != nil {
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 0, YOffset: 0, Content: []rune("a")}); err != nil {
 		t.Fail()
 	}
 
-	expectedText, err = targetText.GetTextAsString()
-	if err != nil {
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 1, YOffset: 0, Content: []rune("\n")}); err != nil {
+		t.Fail()
+	}
+
+	if _, err := history.PopUndo(); err != nil {
+		t.Fail()
+	}
+
+	if !history.CanUndo() {
+		t.Fail()
+	}
+}
+
+func TestHistoryShouldMoveEditsBetweenUndoAndRedo(t *testing.T) {
+	history := new(History)
+	if err := history.Init(nil); err != nil {
+		t.Fail()
+	}
+
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 0, YOffset: 0, Content: []rune("a")}); err != nil {
+		t.Fail()
+	}
+
+	if _, err := history.PopUndo(); err != nil {
+		t.Fail()
+	}
+
+	if history.CanUndo() || !history.CanRedo() {
+		t.Fail()
+	}
+
+	if _, err := history.PopRedo(); err != nil {
+		t.Fail()
+	}
+
+	if !history.CanUndo() || history.CanRedo() {
 		t.Fail()
 	}
 
-	if *expectedText != text1String {
+	if _, err := history.PopRedo(); err == nil {
 		t.Fail()
 	}
 }
 
-// Test helper function which is creating a text config mockup
-func GetHistoryTestTextConfigMockup() *TextConfig {
-	config := CreateDefaultTextConfig()
-	return &config
+func TestHistoryShouldDiscardRedoOnPush(t *testing.T) {
+	history := new(History)
+	if err := history.Init(nil); err != nil {
+		t.Fail()
+	}
+
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 0, YOffset: 0, Content: []rune("a")}); err != nil {
+		t.Fail()
+	}
+
+	if _, err := history.PopUndo(); err != nil {
+		t.Fail()
+	}
+
+	if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 5, YOffset: 1, Content: []rune("b")}); err != nil {
+		t.Fail()
+	}
+
+	if history.CanRedo() {
+		t.Fail()
+	}
+}
+
+func TestHistoryShouldDropOldestEditOnOverflow(t *testing.T) {
+	history := new(History)
+	if err := history.Init(&HistoryConfig{HistoryStackSize: 2}); err != nil {
+		t.Fail()
+	}
+
+	for yOffset := 0; yOffset < 3; yOffset += 1 {
+		if err := history.Push(HistoryEdit{Type: HistoryEditInsert, XOffset: 0, YOffset: yOffset, Content: []rune("a")}); err != nil {
+			t.Fail()
+		}
+	}
+
+	edits, err := history.PopUndo()
+	if err != nil || edits[0].YOffset != 2 {
+		t.Fail()
+	}
+
+	edits, err = history.PopUndo()
+	if err != nil || edits[0].YOffset != 1 {
+		t.Fail()
+	}
+
+	if history.CanUndo() {
+		t.Fail()
+	}
 }
@@ -2,15 +2,47 @@ package main
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
-// Structure representing the editor keyboard key-bindings for various operations
+// Structure representing the key sequence bound to the command. The sequence contains a single key press or multiple key
+// presses (chord) which have to be pressed one after another
+type KeyBinding struct {
+	Sequence []ConsoleEventKeyPress
+	Command  string
+}
+
+// Structure representing the editor keyboard key-bindings for various operations. The single rune keybinds (entered with the
+// [Ctrl] key) are combined with the keymap, which is mapping the key sequences to the command names
 type Keybinds struct {
-	save   rune
-	exit   rune
-	keyMap map[rune]bool
-	config *KeybindsConfig
+	save     rune
+	saveAs   rune
+	exit     rune
+	undo     rune
+	redo     rune
+	copy     rune
+	cut      rune
+	paste    rune
+	find     rune
+	replace  rune
+	goToLine rune
+	softWrap rune
+	theme    rune
+	next     rune
+	previous rune
+	buffers  rune
+	splitV   rune
+	splitH   rune
+	nextPane rune
+	close    rune
+	finder   rune
+	warnings []string
+	bindings []KeyBinding
+	config   *KeybindsConfig
 }
 
 // Editor keybinds structure initialization function
@@ -22,15 +54,39 @@ func (keybinds *Keybinds) Init(keybindsConfig *KeybindsConfig) error {
 		keybinds.config = keybindsConfig
 	}
 
-	keybinds.keyMap = make(map[rune]bool)
+	defaultConfig := CreateDefaultKeybindsConfig()
+	keybindTargets := []keybindTarget{
+		{&keybinds.save, "save", keybinds.config.SaveKeybind, defaultConfig.SaveKeybind},
+		{&keybinds.saveAs, "save-as", keybinds.config.SaveAsKeybind, defaultConfig.SaveAsKeybind},
+		{&keybinds.exit, "exit", keybinds.config.ExitKeybind, defaultConfig.ExitKeybind},
+		{&keybinds.undo, "undo", keybinds.config.UndoKeybind, defaultConfig.UndoKeybind},
+		{&keybinds.redo, "redo", keybinds.config.RedoKeybind, defaultConfig.RedoKeybind},
+		{&keybinds.copy, "copy", keybinds.config.CopyKeybind, defaultConfig.CopyKeybind},
+		{&keybinds.cut, "cut", keybinds.config.CutKeybind, defaultConfig.CutKeybind},
+		{&keybinds.paste, "paste", keybinds.config.PasteKeybind, defaultConfig.PasteKeybind},
+		{&keybinds.find, "find", keybinds.config.FindKeybind, defaultConfig.FindKeybind},
+		{&keybinds.replace, "replace", keybinds.config.ReplaceKeybind, defaultConfig.ReplaceKeybind},
+		{&keybinds.goToLine, "go-to-line", keybinds.config.GoToLineKeybind, defaultConfig.GoToLineKeybind},
+		{&keybinds.softWrap, "soft-wrap", keybinds.config.SoftWrapKeybind, defaultConfig.SoftWrapKeybind},
+		{&keybinds.theme, "theme", keybinds.config.ThemeKeybind, defaultConfig.ThemeKeybind},
+		{&keybinds.next, "next-buffer", keybinds.config.NextBufferKeybind, defaultConfig.NextBufferKeybind},
+		{&keybinds.previous, "previous-buffer", keybinds.config.PreviousBufferKeybind, defaultConfig.PreviousBufferKeybind},
+		{&keybinds.buffers, "buffer-list", keybinds.config.BufferListKeybind, defaultConfig.BufferListKeybind},
+		{&keybinds.splitV, "split-vertical", keybinds.config.SplitVerticalKeybind, defaultConfig.SplitVerticalKeybind},
+		{&keybinds.splitH, "split-horizontal", keybinds.config.SplitHorizontalKeybind, defaultConfig.SplitHorizontalKeybind},
+		{&keybinds.nextPane, "next-pane", keybinds.config.NextPaneKeybind, defaultConfig.NextPaneKeybind},
+		{&keybinds.close, "close-pane", keybinds.config.ClosePaneKeybind, defaultConfig.ClosePaneKeybind},
+		{&keybinds.finder, "file-finder", keybinds.config.FileFinderKeybind, defaultConfig.FileFinderKeybind},
+	}
+
 	var err error = nil
 
-	keybinds.save, err = keybinds.parseKeybindString(keybinds.config.SaveKeybind)
+	keybinds.warnings, err = keybinds.assignKeybinds(keybindTargets)
 	if err != nil {
 		return err
 	}
 
-	keybinds.exit, err = keybinds.parseKeybindString(keybinds.config.ExitKeybind)
+	keybinds.bindings, err = keybinds.createBindings()
 	if err != nil {
 		return err
 	}
@@ -38,25 +94,290 @@ func (keybinds *Keybinds) Init(keybindsConfig *KeybindsConfig) error {
 	return nil
 }
 
-// Helper funcation used to validate and extract the keybind rune from string value
-func (keybind *Keybinds) parseKeybindString(keybindValue string) (rune, error) {
-	if len(keybindValue) != 1 {
-		return 0, errors.New("keybinds: can not parse the keybind configuration")
+// Helper function used to combine the single rune keybinds and the keymap into the list of key bindings. The keymap entries
+// are overriding the single rune keybinds bound to the same key and the entries with an empty command name are removing the
+// binding. The conflicting entries (the same key bound to different commands or a key sequence being a prefix of a different
+// key sequence) are rejected
+func (keybinds *Keybinds) createBindings() ([]KeyBinding, error) {
+	bindings := make(map[string]KeyBinding)
+	order := make([]string, 0)
+
+	bind := func(sequence []ConsoleEventKeyPress, command string) {
+		id := FormatKeySequence(sequence)
+		if _, exists := bindings[id]; !exists {
+			order = append(order, id)
+		}
+
+		bindings[id] = KeyBinding{Sequence: sequence, Command: command}
+	}
+
+	legacyBindings := []struct {
+		char    rune
+		command string
+	}{
+		{keybinds.save, "save"},
+		{keybinds.saveAs, "save-as"},
+		{keybinds.exit, "exit"},
+		{keybinds.undo, "undo"},
+		{keybinds.redo, "redo"},
+		{keybinds.copy, "copy"},
+		{keybinds.cut, "cut"},
+		{keybinds.paste, "paste"},
+		{keybinds.find, "find"},
+		{keybinds.replace, "replace"},
+		{keybinds.goToLine, "go-to-line"},
+		{keybinds.softWrap, "toggle-soft-wrap"},
+		{keybinds.theme, "select-theme"},
+		{keybinds.next, "next-buffer"},
+		{keybinds.previous, "previous-buffer"},
+		{keybinds.buffers, "buffer-list"},
+		{keybinds.splitV, "split-vertical"},
+		{keybinds.splitH, "split-horizontal"},
+		{keybinds.nextPane, "next-pane"},
+		{keybinds.close, "close-pane"},
+		{keybinds.finder, "file-finder"},
 	}
 
-	targetRune := rune(strings.ToLower(keybindValue)[0])
+	for _, legacyBinding := range legacyBindings {
+		if legacyBinding.char == 0 {
+			continue
+		}
+
+		bind([]ConsoleEventKeyPress{{Char: legacyBinding.char, Key: KeyPrintable, Modifier: ModifierCtrl}}, legacyBinding.command)
+	}
+
+	// NOTE: The keymap keys are processed in order, so the resulting bindings and errors are deterministic
+	keys := make([]string, 0, len(keybinds.config.Keymap))
+	for key := range keybinds.config.Keymap {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	keymapCommands := make(map[string]string)
+	for _, key := range keys {
+		command := strings.TrimSpace(keybinds.config.Keymap[key])
+
+		sequence, err := ParseKeySequence(key)
+		if err != nil {
+			return nil, err
+		}
+
+		id := FormatKeySequence(sequence)
+		if previousCommand, exists := keymapCommands[id]; exists && previousCommand != command {
+			return nil, fmt.Errorf("keybinds: the key %s is bound to multiple commands", id)
+		}
+
+		keymapCommands[id] = command
+
+		if len(command) == 0 {
+			delete(bindings, id)
+			continue
+		}
+
+		bind(sequence, command)
+	}
+
+	result := make([]KeyBinding, 0, len(bindings))
+	for _, id := range order {
+		if binding, exists := bindings[id]; exists {
+			result = append(result, binding)
+		}
+	}
+
+	for _, binding := range result {
+		for _, otherBinding := range result {
+			if len(binding.Sequence) < len(otherBinding.Sequence) && isKeySequencePrefix(binding.Sequence, otherBinding.Sequence) {
+				return nil, fmt.Errorf("keybinds: the key %s conflicts with the chord %s", FormatKeySequence(binding.Sequence), FormatKeySequence(otherBinding.Sequence))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Helper function used to check if the key sequence is starting with the given prefix
+func isKeySequencePrefix(prefix []ConsoleEventKeyPress, sequence []ConsoleEventKeyPress) bool {
+	if len(prefix) > len(sequence) {
+		return false
+	}
+
+	for index := range prefix {
+		if prefix[index] != sequence[index] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NOTE: Names of the named keys accepted by the keymap (in lower case)
+var keymapNamedKeys = map[string]NamedKey{
+	"up":        KeyUp,
+	"down":      KeyDown,
+	"right":     KeyRight,
+	"left":      KeyLeft,
+	"pgup":      KeyPgUp,
+	"pageup":    KeyPgUp,
+	"pgdn":      KeyPgDn,
+	"pagedown":  KeyPgDn,
+	"home":      KeyHome,
+	"end":       KeyEnd,
+	"insert":    KeyInsert,
+	"delete":    KeyDelete,
+	"del":       KeyDelete,
+	"pause":     KeyPause,
+	"backtab":   KeyBacktab,
+	"enter":     KeyEnter,
+	"return":    KeyEnter,
+	"tab":       KeyTab,
+	"esc":       KeyEscape,
+	"escape":    KeyEscape,
+	"backspace": KeyBackspace,
+}
+
+// Parse the key sequence specified as the keys separated by spaces (e.g. ,,ctrl+k ctrl+c”). Every key is specified as the
+// modifiers (ctrl, shift, alt) and the key name (a single character, a named key like ,,left” or ,,f5”) joined with the plus sign
+func ParseKeySequence(value string) ([]ConsoleEventKeyPress, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return nil, errors.New("keybinds: can not parse the empty key sequence")
+	}
+
+	sequence := make([]ConsoleEventKeyPress, 0, len(fields))
+	for _, field := range fields {
+		keyPress, err := ParseKeyPress(field)
+		if err != nil {
+			return nil, err
+		}
+
+		sequence = append(sequence, keyPress)
+	}
+
+	return sequence, nil
+}
+
+// Parse a single key specified as the modifiers and the key name joined with the plus sign (e.g. ,,ctrl+shift+s”, ,,alt+left”
+// or ,,f5”). The names are case insensitive and the ,,plus” and ,,space” names are used for the corresponding characters
+func ParseKeyPress(value string) (ConsoleEventKeyPress, error) {
+	keyPress := ConsoleEventKeyPress{}
+
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(value)), "+")
+	for _, modifier := range parts[:len(parts)-1] {
+		var modifierKey ModifierKey
+
+		switch modifier {
+		case "ctrl", "control":
+			modifierKey = ModifierCtrl
+		case "shift":
+			modifierKey = ModifierShift
+		case "alt":
+			modifierKey = ModifierAlt
+		default:
+			return ConsoleEventKeyPress{}, fmt.Errorf("keybinds: invalid modifier in the key: %s", value)
+		}
+
+		if keyPress.Modifier&modifierKey != 0 {
+			return ConsoleEventKeyPress{}, fmt.Errorf("keybinds: duplicated modifier in the key: %s", value)
+		}
+
+		keyPress.Modifier |= modifierKey
+	}
+
+	name := parts[len(parts)-1]
+	if namedKey, exists := keymapNamedKeys[name]; exists {
+		keyPress.Key = namedKey
+		return keyPress, nil
+	}
+
+	if strings.HasPrefix(name, "f") && len(name) > 1 {
+		if number, err := strconv.Atoi(name[1:]); err == nil && number >= 1 && number <= 12 {
+			keyPress.Key = KeyF1 + NamedKey(number-1)
+			return keyPress, nil
+		}
+	}
+
+	switch name {
+	case "plus":
+		name = "+"
+	case "space":
+		name = " "
+	}
+
+	if utf8.RuneCountInString(name) != 1 {
+		return ConsoleEventKeyPress{}, fmt.Errorf("keybinds: invalid key name in the key: %s", value)
+	}
+
+	keyPress.Key = KeyPrintable
+	keyPress.Char, _ = utf8.DecodeRuneInString(name)
+
+	return keyPress, nil
+}
+
+// Structure representing a single rune keybind during the initialization, the configured and default values are compared to
+// resolve the conflicts between the keybinds
+type keybindTarget struct {
+	target       *rune
+	name         string
+	value        string
+	defaultValue string
+}
+
+// Helper function used to parse the single rune keybinds and assign the runes to the targets. The keybinds changed by the user
+// are winning over the default keybinds, so the default keybind using the same rune is removed (e.g. the configuration created
+// by an older version, which is binding a rune used by a newly added default keybind). The function returns the descriptions of
+// the removed keybinds. The conflicts between the keybinds changed by the user are rejected
+func (keybinds *Keybinds) assignKeybinds(targets []keybindTarget) ([]string, error) {
+	warnings := make([]string, 0)
+	owners := make(map[rune]keybindTarget)
+
+	for _, target := range targets {
+		targetRune, err := keybinds.parseKeybindString(target.value)
+		if err != nil {
+			return nil, err
+		}
+
+		*target.target = targetRune
+
+		owner, exists := owners[targetRune]
+		if !exists {
+			owners[targetRune] = target
+			continue
+		}
+
+		ownerChanged := !strings.EqualFold(owner.value, owner.defaultValue)
+		targetChanged := !strings.EqualFold(target.value, target.defaultValue)
 
-	if len(keybind.keyMap) == 0 {
-		keybind.keyMap[targetRune] = true
-		return targetRune, nil
+		if ownerChanged == targetChanged {
+			return nil, errors.New("keybinds: ambiguous keybind configuration")
+		}
+
+		removed, kept := target, owner
+		if targetChanged {
+			removed, kept = owner, target
+			owners[targetRune] = target
+		}
+
+		// NOTE: The rune zero is not bound to any key, the command is still available inside the command palette
+		*removed.target = 0
+		warnings = append(warnings, fmt.Sprintf("The default %s keybind ([Ctrl] + [%c]) was removed, because the key is used by the %s keybind.", removed.name, targetRune, kept.name))
 	}
 
-	if _, exist := keybind.keyMap[targetRune]; exist {
-		return 0, errors.New("keybinds: ambiguous keybind configuration")
+	return warnings, nil
+}
+
+// Helper funcation used to validate and extract the keybind rune from string value
+func (keybind *Keybinds) parseKeybindString(keybindValue string) (rune, error) {
+	if len(keybindValue) != 1 {
+		return 0, errors.New("keybinds: can not parse the keybind configuration")
 	}
 
-	keybind.keyMap[targetRune] = true
-	return targetRune, nil
+	return rune(strings.ToLower(keybindValue)[0]), nil
+}
+
+// Return the descriptions of the default keybinds removed because of the conflicts with the keybinds changed by the user
+func (keybind *Keybinds) GetWarnings() []string {
+	return keybind.warnings
 }
 
 // Return the rune (that entered with [Ctrl] key) will affect in saving the editor changes
@@ -64,21 +385,198 @@ func (keybind *Keybinds) GetSaveKeybind() rune {
 	return keybind.save
 }
 
+// Return the rune (that entered with [Ctrl] key) will affect in saving the buffer as a different file
+func (keybind *Keybinds) GetSaveAsKeybind() rune {
+	return keybind.saveAs
+}
+
 // Return the rune (that entered with [Ctrl] key) will affect in exiting the program
 func (keybind *Keybinds) GetExitKeybind() rune {
 	return keybind.exit
 }
 
+// Return the rune (that entered with [Ctrl] key) will affect in reverting the latest text changes
+func (keybind *Keybinds) GetUndoKeybind() rune {
+	return keybind.undo
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in reapplying the latest reverted text changes
+func (keybind *Keybinds) GetRedoKeybind() rune {
+	return keybind.redo
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in copying the selection or current line to the clipboard
+func (keybind *Keybinds) GetCopyKeybind() rune {
+	return keybind.copy
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in moving the selection or current line to the clipboard
+func (keybind *Keybinds) GetCutKeybind() rune {
+	return keybind.cut
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in inserting the clipboard content
+func (keybind *Keybinds) GetPasteKeybind() rune {
+	return keybind.paste
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in opening the search prompt
+func (keybind *Keybinds) GetFindKeybind() rune {
+	return keybind.find
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in opening the replace prompt
+func (keybind *Keybinds) GetReplaceKeybind() rune {
+	return keybind.replace
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in opening the go-to-line prompt
+func (keybind *Keybinds) GetGoToLineKeybind() rune {
+	return keybind.goToLine
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in toggling the soft wrap mode
+func (keybind *Keybinds) GetSoftWrapKeybind() rune {
+	return keybind.softWrap
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in opening the theme selection prompt
+func (keybind *Keybinds) GetThemeKeybind() rune {
+	return keybind.theme
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in switching to the next buffer
+func (keybind *Keybinds) GetNextBufferKeybind() rune {
+	return keybind.next
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in switching to the previous buffer
+func (keybind *Keybinds) GetPreviousBufferKeybind() rune {
+	return keybind.previous
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in opening the buffer list popup
+func (keybind *Keybinds) GetBufferListKeybind() rune {
+	return keybind.buffers
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in splitting the focused pane vertically (side by side)
+func (keybind *Keybinds) GetSplitVerticalKeybind() rune {
+	return keybind.splitV
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in splitting the focused pane horizontally (one under another)
+func (keybind *Keybinds) GetSplitHorizontalKeybind() rune {
+	return keybind.splitH
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in focusing the next pane
+func (keybind *Keybinds) GetNextPaneKeybind() rune {
+	return keybind.nextPane
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in closing the focused pane
+func (keybind *Keybinds) GetClosePaneKeybind() rune {
+	return keybind.close
+}
+
+// Return the rune (that entered with [Ctrl] key) will affect in opening the file finder popup
+func (keybind *Keybinds) GetFileFinderKeybind() rune {
+	return keybind.finder
+}
+
+// Return the key bindings resulting from the single rune keybinds and the keymap
+func (keybind *Keybinds) GetBindings() []KeyBinding {
+	return keybind.bindings
+}
+
 // A structure containing the configuration for the keybinds structure
 type KeybindsConfig struct {
-	SaveKeybind string `json:"keybind-save"`
-	ExitKeybind string `json:"keybind-exit"`
+	SaveKeybind            string            `json:"keybind-save"`
+	SaveAsKeybind          string            `json:"keybind-save-as"`
+	ExitKeybind            string            `json:"keybind-exit"`
+	UndoKeybind            string            `json:"keybind-undo"`
+	RedoKeybind            string            `json:"keybind-redo"`
+	CopyKeybind            string            `json:"keybind-copy"`
+	CutKeybind             string            `json:"keybind-cut"`
+	PasteKeybind           string            `json:"keybind-paste"`
+	FindKeybind            string            `json:"keybind-find"`
+	ReplaceKeybind         string            `json:"keybind-replace"`
+	GoToLineKeybind        string            `json:"keybind-go-to-line"`
+	SoftWrapKeybind        string            `json:"keybind-soft-wrap"`
+	ThemeKeybind           string            `json:"keybind-theme"`
+	NextBufferKeybind      string            `json:"keybind-next-buffer"`
+	PreviousBufferKeybind  string            `json:"keybind-previous-buffer"`
+	BufferListKeybind      string            `json:"keybind-buffer-list"`
+	SplitVerticalKeybind   string            `json:"keybind-split-vertical"`
+	SplitHorizontalKeybind string            `json:"keybind-split-horizontal"`
+	NextPaneKeybind        string            `json:"keybind-next-pane"`
+	ClosePaneKeybind       string            `json:"keybind-close-pane"`
+	FileFinderKeybind      string            `json:"keybind-file-finder"`
+	Keymap                 map[string]string `json:"keymap"`
 }
 
 // Return a new isntance of the keybinds configuration with default values
 func CreateDefaultKeybindsConfig() KeybindsConfig {
 	return KeybindsConfig{
-		SaveKeybind: "s",
-		ExitKeybind: "x",
+		SaveKeybind:            "s",
+		SaveAsKeybind:          "o",
+		ExitKeybind:            "x",
+		UndoKeybind:            "z",
+		RedoKeybind:            "y",
+		CopyKeybind:            "c",
+		CutKeybind:             "q",
+		PasteKeybind:           "v",
+		FindKeybind:            "f",
+		ReplaceKeybind:         "r",
+		GoToLineKeybind:        "g",
+		SoftWrapKeybind:        "w",
+		ThemeKeybind:           "t",
+		NextBufferKeybind:      "n",
+		PreviousBufferKeybind:  "b",
+		BufferListKeybind:      "e",
+		SplitVerticalKeybind:   "d",
+		SplitHorizontalKeybind: "u",
+		NextPaneKeybind:        "l",
+		ClosePaneKeybind:       "k",
+		FileFinderKeybind:      "p",
+		Keymap:                 CreateDefaultKeymap(),
+	}
+}
+
+// Return a new instance of the default keymap. The keymap is binding the keys which can not be configured with the single rune
+// keybinds (e.g. the cursor movement)
+func CreateDefaultKeymap() map[string]string {
+	return map[string]string{
+		"left":             "cursor-left",
+		"right":            "cursor-right",
+		"up":               "cursor-up",
+		"down":             "cursor-down",
+		"home":             "cursor-line-start",
+		"end":              "cursor-line-end",
+		"ctrl+left":        "cursor-word-left",
+		"ctrl+right":       "cursor-word-right",
+		"shift+left":       "select-left",
+		"shift+right":      "select-right",
+		"shift+up":         "select-up",
+		"shift+down":       "select-down",
+		"shift+home":       "select-line-start",
+		"shift+end":        "select-line-end",
+		"ctrl+shift+left":  "select-word-left",
+		"ctrl+shift+right": "select-word-right",
+		"enter":            "new-line",
+		"backspace":        "delete-backward",
+		"delete":           "delete-forward",
+		"f3":               "find-next",
+		"shift+f3":         "find-previous",
+		"ctrl+pgdn":        "next-buffer",
+		"ctrl+pgup":        "previous-buffer",
+		"alt+right":        "pane-grow-width",
+		"alt+left":         "pane-shrink-width",
+		"alt+down":         "pane-grow-height",
+		"alt+up":           "pane-shrink-height",
+		"f1":               "command-palette",
+		"ctrl+shift+p":     "command-palette",
+		"alt+z":            "suspend",
 	}
 }
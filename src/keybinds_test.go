@@ -21,6 +21,41 @@ func TestKeybindsShouldNotInitializeForInvalidConfigAmbiguousKeys(t *testing.T)
 	}
 }
 
+func TestKeybindsShouldNotInitializeForConflictingChangedKeybinds(t *testing.T) {
+	config := CreateDefaultKeybindsConfig()
+	config.SaveKeybind = "a"
+	config.ExitKeybind = "a"
+
+	keybinds := new(Keybinds)
+	if err := keybinds.Init(&config); err == nil {
+		t.Fail()
+	}
+}
+
+func TestKeybindsShouldRemoveDefaultKeybindConflictingWithChangedKeybind(t *testing.T) {
+	config := CreateDefaultKeybindsConfig()
+	config.ExitKeybind = "p"
+
+	keybinds := new(Keybinds)
+	if err := keybinds.Init(&config); err != nil {
+		t.FailNow()
+	}
+
+	if keybinds.GetExitKeybind() != 'p' || keybinds.GetFileFinderKeybind() != 0 {
+		t.Fail()
+	}
+
+	if len(keybinds.GetWarnings()) != 1 {
+		t.Fail()
+	}
+
+	for _, binding := range keybinds.GetBindings() {
+		if binding.Command == "file-finder" {
+			t.Fail()
+		}
+	}
+}
+
 func TestKeybindsShouldNotInitializeForInvalidConfigParsingFailed(t *testing.T) {
 	config := KeybindsConfig{
 		SaveKeybind: "hello",
@@ -35,8 +70,27 @@ func TestKeybindsShouldNotInitializeForInvalidConfigParsingFailed(t *testing.T)
 
 func TestKeybindsGettersShouldReturnCorrectValue(t *testing.T) {
 	config := KeybindsConfig{
-		SaveKeybind: "s",
-		ExitKeybind: "x",
+		SaveKeybind:            "s",
+		SaveAsKeybind:          "o",
+		ExitKeybind:            "x",
+		UndoKeybind:            "z",
+		RedoKeybind:            "y",
+		CopyKeybind:            "c",
+		CutKeybind:             "q",
+		PasteKeybind:           "v",
+		FindKeybind:            "f",
+		ReplaceKeybind:         "r",
+		GoToLineKeybind:        "g",
+		SoftWrapKeybind:        "w",
+		ThemeKeybind:           "t",
+		NextBufferKeybind:      "n",
+		PreviousBufferKeybind:  "b",
+		BufferListKeybind:      "e",
+		SplitVerticalKeybind:   "d",
+		SplitHorizontalKeybind: "u",
+		NextPaneKeybind:        "l",
+		ClosePaneKeybind:       "k",
+		FileFinderKeybind:      "p",
 	}
 
 	keybinds := new(Keybinds)
@@ -48,4 +102,119 @@ func TestKeybindsGettersShouldReturnCorrectValue(t *testing.T) {
 	if keybind != 's' {
 		t.Fail()
 	}
+
+	if keybinds.GetSaveAsKeybind() != 'o' {
+		t.Fail()
+	}
+
+	if keybinds.GetUndoKeybind() != 'z' {
+		t.Fail()
+	}
+
+	if keybinds.GetRedoKeybind() != 'y' {
+		t.Fail()
+	}
+
+	if keybinds.GetNextBufferKeybind() != 'n' {
+		t.Fail()
+	}
+
+	if keybinds.GetPreviousBufferKeybind() != 'b' {
+		t.Fail()
+	}
+
+	if keybinds.GetBufferListKeybind() != 'e' {
+		t.Fail()
+	}
+
+	if keybinds.GetSplitVerticalKeybind() != 'd' || keybinds.GetSplitHorizontalKeybind() != 'u' {
+		t.Fail()
+	}
+
+	if keybinds.GetNextPaneKeybind() != 'l' || keybinds.GetClosePaneKeybind() != 'k' {
+		t.Fail()
+	}
+
+	if keybinds.GetFileFinderKeybind() != 'p' {
+		t.Fail()
+	}
+}
+
+func TestKeybindsShouldParseKeySequences(t *testing.T) {
+	cases := map[string][]ConsoleEventKeyPress{
+		"ctrl+shift+s":  {{Char: 's', Key: KeyPrintable, Modifier: ModifierCtrl | ModifierShift}},
+		"Alt+Left":      {{Key: KeyLeft, Modifier: ModifierAlt}},
+		"f5":            {{Key: KeyF5}},
+		"ctrl+k ctrl+c": {{Char: 'k', Key: KeyPrintable, Modifier: ModifierCtrl}, {Char: 'c', Key: KeyPrintable, Modifier: ModifierCtrl}},
+		"ctrl+plus":     {{Char: '+', Key: KeyPrintable, Modifier: ModifierCtrl}},
+	}
+
+	for value, expected := range cases {
+		sequence, err := ParseKeySequence(value)
+		if err != nil || len(sequence) != len(expected) {
+			t.FailNow()
+		}
+
+		for index := range expected {
+			if sequence[index] != expected[index] {
+				t.Fail()
+			}
+		}
+	}
+
+	for _, value := range []string{"", "hyper+s", "ctrl+ctrl+s", "ctrl+enterr", "f13"} {
+		if _, err := ParseKeySequence(value); err == nil {
+			t.Fail()
+		}
+	}
+}
+
+func TestKeybindsShouldApplyKeymap(t *testing.T) {
+	config := CreateDefaultKeybindsConfig()
+	config.Keymap["ctrl+s"] = "find"
+	config.Keymap["f1"] = ""
+	config.Keymap["ctrl+j ctrl+j"] = "save"
+
+	keybinds := new(Keybinds)
+	if err := keybinds.Init(&config); err != nil {
+		t.FailNow()
+	}
+
+	bindings := make(map[string]string)
+	for _, binding := range keybinds.GetBindings() {
+		bindings[FormatKeySequence(binding.Sequence)] = binding.Command
+	}
+
+	if bindings["Ctrl+S"] != "find" || bindings["Ctrl+J Ctrl+J"] != "save" || bindings["Left"] != "cursor-left" {
+		t.Fail()
+	}
+
+	if _, exists := bindings["F1"]; exists {
+		t.Fail()
+	}
+}
+
+func TestKeybindsShouldNotInitializeForConflictingKeymap(t *testing.T) {
+	config := CreateDefaultKeybindsConfig()
+	config.Keymap["ctrl+k ctrl+c"] = "copy"
+
+	keybinds := new(Keybinds)
+	if err := keybinds.Init(&config); err == nil {
+		t.Fail()
+	}
+
+	config = CreateDefaultKeybindsConfig()
+	config.Keymap["CTRL+J"] = "save"
+	config.Keymap["ctrl+j"] = "exit"
+
+	if err := keybinds.Init(&config); err == nil {
+		t.Fail()
+	}
+
+	config = CreateDefaultKeybindsConfig()
+	config.Keymap["super+j"] = "save"
+
+	if err := keybinds.Init(&config); err == nil {
+		t.Fail()
+	}
 }
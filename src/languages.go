@@ -0,0 +1,84 @@
+package main
+
+import "regexp"
+
+// The language definitions supported by the syntax highlighting
+var builtInLanguages = []*LanguageDefinition{
+	{
+		Name:       "Go",
+		Extensions: []string{".go"},
+		Keywords: []string{
+			"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func", "go", "goto",
+			"if", "import", "interface", "map", "package", "range", "return", "select", "struct", "switch", "type", "var",
+			"true", "false", "nil", "iota",
+		},
+		Types: []string{
+			"any", "bool", "byte", "complex64", "complex128", "error", "float32", "float64", "int", "int8", "int16", "int32",
+			"int64", "rune", "string", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		},
+		LineComments:              []string{"//"},
+		BlockCommentStart:         "/*",
+		BlockCommentEnd:           "*/",
+		StringDelimiters:          []string{"\"", "'"},
+		MultiLineStringDelimiters: []string{"`"},
+		EscapeCharacter:           '\\',
+		HighlightNumbers:          true,
+	},
+	{
+		Name:             "JSON",
+		Extensions:       []string{".json"},
+		Keywords:         []string{"true", "false", "null"},
+		StringDelimiters: []string{"\""},
+		EscapeCharacter:  '\\',
+		HighlightNumbers: true,
+		Patterns: []LanguagePattern{
+			{Expression: regexp.MustCompile(`^("(?:[^"\\]|\\.)*")\s*:`), Token: TokenSpecial},
+		},
+	},
+	{
+		Name:             "YAML",
+		Extensions:       []string{".yaml", ".yml"},
+		Keywords:         []string{"true", "false", "null", "yes", "no", "on", "off"},
+		LineComments:     []string{"#"},
+		StringDelimiters: []string{"\"", "'"},
+		EscapeCharacter:  '\\',
+		HighlightNumbers: true,
+		Patterns: []LanguagePattern{
+			{Expression: regexp.MustCompile(`^(?:---|\.\.\.)\s*$`), Token: TokenKeyword, LineStart: true},
+			{Expression: regexp.MustCompile(`^\s*(?:-\s+)?([^\s#'"\-][^:#]*?):(?:\s|$)`), Token: TokenSpecial, LineStart: true},
+			{Expression: regexp.MustCompile(`^[&*][\w\-]+`), Token: TokenBuiltin},
+		},
+	},
+	{
+		Name:                      "Markdown",
+		Extensions:                []string{".md", ".markdown"},
+		BlockCommentStart:         "<!--",
+		BlockCommentEnd:           "-->",
+		MultiLineStringDelimiters: []string{"```"},
+		Patterns: []LanguagePattern{
+			{Expression: regexp.MustCompile(`^#{1,6}\s.*`), Token: TokenKeyword, LineStart: true},
+			{Expression: regexp.MustCompile(`^\s*([-*+]|\d+\.)\s`), Token: TokenSpecial, LineStart: true},
+			{Expression: regexp.MustCompile("^`[^`]+`"), Token: TokenString},
+			{Expression: regexp.MustCompile(`^(\*\*[^*]+\*\*|__[^_]+__)`), Token: TokenBuiltin},
+			{Expression: regexp.MustCompile(`^!?\[[^\]]*\]\([^)]*\)`), Token: TokenSpecial},
+		},
+	},
+	{
+		Name:       "Shell",
+		Extensions: []string{".sh", ".bash", ".zsh"},
+		FileNames:  []string{".bashrc", ".bash_profile", ".zshrc", ".profile"},
+		Keywords: []string{
+			"if", "then", "else", "elif", "fi", "for", "while", "until", "do", "done", "case", "esac", "in", "function",
+			"return", "local", "export", "readonly", "declare", "unset", "shift", "break", "continue", "exit", "source", "alias",
+		},
+		LineComments:     []string{"#"},
+		StringDelimiters: []string{"\"", "'", "`"},
+		EscapeCharacter:  '\\',
+		HighlightNumbers: true,
+		Patterns: []LanguagePattern{
+			{Expression: regexp.MustCompile(`^\$\{[^}]*\}`), Token: TokenSpecial},
+			{Expression: regexp.MustCompile(`^\$[A-Za-z_][A-Za-z0-9_]*`), Token: TokenSpecial},
+			{Expression: regexp.MustCompile(`^\$[0-9#?@*$!\-]`), Token: TokenSpecial},
+		},
+	},
+}
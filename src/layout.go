@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	layoutMinimalRatio = 0.1
+	layoutMaximalRatio = 0.9
+)
+
+// Type representing the orientation of a split. The vertical split places the panes side by side (separated by a vertical line)
+// and the horizontal split places the panes one above another (separated by a horizontal line)
+type SplitOrientation int16
+
+const (
+	SplitVertical SplitOrientation = iota
+	SplitHorizontal
+)
+
+// Structure representing the area of the console (in console positions) assigned to a pane
+type PaneArea struct {
+	Pane   *Pane
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Structure representing the separator line between two panes of a split
+type SeparatorArea struct {
+	X        int
+	Y        int
+	Length   int
+	Vertical bool
+}
+
+// Structure representing a node of the layout tree. The leaf node contains a pane and the inner node contains two child nodes
+// splitted in the given orientation. The ratio specifies the part of the space assigned to the first child node
+type layoutNode struct {
+	pane        *Pane
+	orientation SplitOrientation
+	ratio       float64
+	first       *layoutNode
+	second      *layoutNode
+	parent      *layoutNode
+}
+
+// Structure representing the arrangement of the panes. The panes are stored as a binary tree of splits, so the space of the
+// console is assigned proportionally to the split ratios
+type Layout struct {
+	root *layoutNode
+}
+
+// Layout structure initialization function. The layout contains the single given pane
+func (layout *Layout) Init(pane *Pane) error {
+	if pane == nil {
+		return errors.New("layout: invalid pane struct reference")
+	}
+
+	layout.root = &layoutNode{pane: pane}
+	return nil
+}
+
+// Split the area of the given pane in the given orientation. The new pane is placed to the right or below the given pane and
+// the space is divided equally
+func (layout *Layout) Split(pane *Pane, newPane *Pane, orientation SplitOrientation) error {
+	if newPane == nil {
+		return errors.New("layout: invalid pane struct reference")
+	}
+
+	node := layout.findNode(layout.root, pane)
+	if node == nil {
+		return errors.New("layout: the pane is not part of the layout")
+	}
+
+	if layout.findNode(layout.root, newPane) != nil {
+		return errors.New("layout: the pane is already part of the layout")
+	}
+
+	node.first = &layoutNode{pane: node.pane, parent: node}
+	node.second = &layoutNode{pane: newPane, parent: node}
+	node.pane = nil
+	node.orientation = orientation
+	node.ratio = 0.5
+
+	return nil
+}
+
+// Remove the given pane from the layout. The space of the pane is assigned to the other pane (or split) of the parent split
+func (layout *Layout) Remove(pane *Pane) error {
+	node := layout.findNode(layout.root, pane)
+	if node == nil {
+		return errors.New("layout: the pane is not part of the layout")
+	}
+
+	if node.parent == nil {
+		return errors.New("layout: the last pane can not be removed")
+	}
+
+	parent := node.parent
+	sibling := parent.first
+	if sibling == node {
+		sibling = parent.second
+	}
+
+	// NOTE: The sibling node is replacing the parent node, so the ancestors split ratios are preserved
+	parent.pane = sibling.pane
+	parent.orientation = sibling.orientation
+	parent.ratio = sibling.ratio
+	parent.first = sibling.first
+	parent.second = sibling.second
+
+	if parent.first != nil {
+		parent.first.parent = parent
+	}
+
+	if parent.second != nil {
+		parent.second.parent = parent
+	}
+
+	return nil
+}
+
+// Change the size of the given pane by moving the separator of the nearest split with the given orientation. A positive delta
+// (part of the split space) grows the pane and a negative delta shrinks it. Returns a bool value indicating if any split was affected
+func (layout *Layout) Resize(pane *Pane, orientation SplitOrientation, delta float64) (bool, error) {
+	node := layout.findNode(layout.root, pane)
+	if node == nil {
+		return false, errors.New("layout: the pane is not part of the layout")
+	}
+
+	for child, parent := node, node.parent; parent != nil; child, parent = parent, parent.parent {
+		if parent.orientation != orientation {
+			continue
+		}
+
+		ratio := parent.ratio + delta
+		if parent.second == child {
+			ratio = parent.ratio - delta
+		}
+
+		parent.ratio = math.Max(layoutMinimalRatio, math.Min(layoutMaximalRatio, ratio))
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Return the panes of the layout, ordered from the top-left to the bottom-right
+func (layout *Layout) GetPanes() []*Pane {
+	panes := make([]*Pane, 0)
+	layout.collectPanes(layout.root, &panes)
+
+	return panes
+}
+
+// Return the count of panes in the layout
+func (layout *Layout) GetPaneCount() int {
+	return len(layout.GetPanes())
+}
+
+// Calculate the console areas of all panes and the separators between them for the given area of the console
+func (layout *Layout) Arrange(x int, y int, width int, height int) ([]PaneArea, []SeparatorArea) {
+	paneAreas := make([]PaneArea, 0)
+	separatorAreas := make([]SeparatorArea, 0)
+
+	layout.arrangeNode(layout.root, x, y, width, height, &paneAreas, &separatorAreas)
+	return paneAreas, separatorAreas
+}
+
+// Helper function used to recursively assign the given area to the node. The separator takes a single row or column
+func (layout *Layout) arrangeNode(node *layoutNode, x int, y int, width int, height int, paneAreas *[]PaneArea, separatorAreas *[]SeparatorArea) {
+	if node.pane != nil {
+		*paneAreas = append(*paneAreas, PaneArea{Pane: node.pane, X: x, Y: y, Width: width, Height: height})
+		return
+	}
+
+	size := width
+	if node.orientation == SplitHorizontal {
+		size = height
+	}
+
+	firstSize, secondSize := splitSize(size, node.ratio)
+
+	if node.orientation == SplitVertical {
+		layout.arrangeNode(node.first, x, y, firstSize, height, paneAreas, separatorAreas)
+		layout.arrangeNode(node.second, x+firstSize+1, y, secondSize, height, paneAreas, separatorAreas)
+
+		*separatorAreas = append(*separatorAreas, SeparatorArea{X: x + firstSize, Y: y, Length: height, Vertical: true})
+		return
+	}
+
+	layout.arrangeNode(node.first, x, y, width, firstSize, paneAreas, separatorAreas)
+	layout.arrangeNode(node.second, x, y+firstSize+1, width, secondSize, paneAreas, separatorAreas)
+
+	*separatorAreas = append(*separatorAreas, SeparatorArea{X: x, Y: y + firstSize, Length: width, Vertical: false})
+}
+
+// Helper function used to divide the given size (without the separator) according to the ratio. Both parts are at least one
+// unit long if the size allows it
+func splitSize(size int, ratio float64) (int, int) {
+	available := size - 1
+	if available <= 1 {
+		if available < 0 {
+			available = 0
+		}
+
+		return available, 0
+	}
+
+	firstSize := int(math.Round(float64(available) * ratio))
+	if firstSize < 1 {
+		firstSize = 1
+	}
+
+	if firstSize > available-1 {
+		firstSize = available - 1
+	}
+
+	return firstSize, available - firstSize
+}
+
+// Helper function used to find the leaf node containing the given pane
+func (layout *Layout) findNode(node *layoutNode, pane *Pane) *layoutNode {
+	if node == nil || pane == nil {
+		return nil
+	}
+
+	if node.pane != nil {
+		if node.pane == pane {
+			return node
+		}
+
+		return nil
+	}
+
+	if found := layout.findNode(node.first, pane); found != nil {
+		return found
+	}
+
+	return layout.findNode(node.second, pane)
+}
+
+// Helper function used to collect the panes of the leaf nodes in order
+func (layout *Layout) collectPanes(node *layoutNode, panes *[]*Pane) {
+	if node == nil {
+		return
+	}
+
+	if node.pane != nil {
+		*panes = append(*panes, node.pane)
+		return
+	}
+
+	layout.collectPanes(node.first, panes)
+	layout.collectPanes(node.second, panes)
+}
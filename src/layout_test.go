@@ -0,0 +1,210 @@
+package main
+
+import "testing"
+
+func TestLayoutShouldInit(t *testing.T) {
+	layout := new(Layout)
+	if err := layout.Init(new(Pane)); err != nil {
+		t.Fail()
+	}
+
+	if layout.GetPaneCount() != 1 {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldNotInitForInvalidPane(t *testing.T) {
+	layout := new(Layout)
+	if err := layout.Init(nil); err == nil {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldArrangeVerticalSplit(t *testing.T) {
+	first := new(Pane)
+	second := new(Pane)
+
+	layout := new(Layout)
+	if err := layout.Init(first); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(first, second, SplitVertical); err != nil {
+		t.FailNow()
+	}
+
+	paneAreas, separatorAreas := layout.Arrange(0, 0, 21, 10)
+	if len(paneAreas) != 2 || len(separatorAreas) != 1 {
+		t.FailNow()
+	}
+
+	if paneAreas[0].Pane != first || paneAreas[0].X != 0 || paneAreas[0].Width != 10 || paneAreas[0].Height != 10 {
+		t.Fail()
+	}
+
+	if paneAreas[1].Pane != second || paneAreas[1].X != 11 || paneAreas[1].Width != 10 || paneAreas[1].Height != 10 {
+		t.Fail()
+	}
+
+	if !separatorAreas[0].Vertical || separatorAreas[0].X != 10 || separatorAreas[0].Length != 10 {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldArrangeNestedSplits(t *testing.T) {
+	first := new(Pane)
+	second := new(Pane)
+	third := new(Pane)
+
+	layout := new(Layout)
+	if err := layout.Init(first); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(first, second, SplitVertical); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(second, third, SplitHorizontal); err != nil {
+		t.FailNow()
+	}
+
+	panes := layout.GetPanes()
+	if len(panes) != 3 || panes[0] != first || panes[1] != second || panes[2] != third {
+		t.FailNow()
+	}
+
+	paneAreas, separatorAreas := layout.Arrange(0, 0, 21, 11)
+	if len(paneAreas) != 3 || len(separatorAreas) != 2 {
+		t.FailNow()
+	}
+
+	if paneAreas[1].X != 11 || paneAreas[1].Y != 0 || paneAreas[1].Height != 5 {
+		t.Fail()
+	}
+
+	if paneAreas[2].X != 11 || paneAreas[2].Y != 6 || paneAreas[2].Height != 5 || paneAreas[2].Width != 10 {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldRedistributeSpaceProportionally(t *testing.T) {
+	first := new(Pane)
+	second := new(Pane)
+
+	layout := new(Layout)
+	if err := layout.Init(first); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(first, second, SplitHorizontal); err != nil {
+		t.FailNow()
+	}
+
+	if resized, err := layout.Resize(second, SplitHorizontal, 0.25); err != nil || !resized {
+		t.FailNow()
+	}
+
+	paneAreas, _ := layout.Arrange(0, 0, 10, 21)
+	if paneAreas[0].Height != 5 || paneAreas[1].Height != 15 {
+		t.Fail()
+	}
+
+	paneAreas, _ = layout.Arrange(0, 0, 10, 41)
+	if paneAreas[0].Height != 10 || paneAreas[1].Height != 30 {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldNotResizeWithoutMatchingSplit(t *testing.T) {
+	first := new(Pane)
+	second := new(Pane)
+
+	layout := new(Layout)
+	if err := layout.Init(first); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(first, second, SplitVertical); err != nil {
+		t.FailNow()
+	}
+
+	if resized, err := layout.Resize(first, SplitHorizontal, 0.1); err != nil || resized {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldLimitSplitRatio(t *testing.T) {
+	first := new(Pane)
+	second := new(Pane)
+
+	layout := new(Layout)
+	if err := layout.Init(first); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(first, second, SplitVertical); err != nil {
+		t.FailNow()
+	}
+
+	if _, err := layout.Resize(first, SplitVertical, 5); err != nil {
+		t.FailNow()
+	}
+
+	paneAreas, _ := layout.Arrange(0, 0, 101, 10)
+	if paneAreas[0].Width != 90 || paneAreas[1].Width != 10 {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldRemovePane(t *testing.T) {
+	first := new(Pane)
+	second := new(Pane)
+	third := new(Pane)
+
+	layout := new(Layout)
+	if err := layout.Init(first); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(first, second, SplitVertical); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(second, third, SplitHorizontal); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Remove(first); err != nil {
+		t.FailNow()
+	}
+
+	panes := layout.GetPanes()
+	if len(panes) != 2 || panes[0] != second || panes[1] != third {
+		t.FailNow()
+	}
+
+	paneAreas, separatorAreas := layout.Arrange(0, 0, 20, 11)
+	if paneAreas[0].Width != 20 || paneAreas[0].Height != 5 || len(separatorAreas) != 1 || separatorAreas[0].Vertical {
+		t.Fail()
+	}
+
+	if err := layout.Remove(third); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Remove(second); err == nil {
+		t.Fail()
+	}
+}
+
+func TestLayoutShouldNotSplitUnknownPane(t *testing.T) {
+	layout := new(Layout)
+	if err := layout.Init(new(Pane)); err != nil {
+		t.FailNow()
+	}
+
+	if err := layout.Split(new(Pane), new(Pane), SplitVertical); err == nil {
+		t.Fail()
+	}
+}
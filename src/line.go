@@ -1,28 +1,89 @@
 package main
 
 import (
+	"bytes"
 	"errors"
+	"os"
 	"strings"
 )
 
-// Structure representing a single line of text in the editor
+// Structure representing a single line of text in the editor. The line loaded from a large file is only referencing the bytes
+// of the file (the offset and size) and the characters are decoded on demand, until the line is changed
 type Line struct {
-	buffer []rune
+	buffer    []rune
+	revision  int
+	source    *LineSource
+	offset    int64
+	size      int
+	runeCount int
+}
+
+// Structure representing the file referenced by the lines, which are decoded on demand. The file is kept open as long as
+// the lines are referencing it
+type LineSource struct {
+	file *os.File
+}
+
+// Line source structure initialization function. The file is opened for reading
+func (source *LineSource) Init(filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	source.file = file
+	return nil
+}
+
+// Close the file of the line source. The lines referencing the source can not be decoded afterwards
+func (source *LineSource) Close() error {
+	return source.file.Close()
 }
 
 // Line structure initialization funcation
 func (line *Line) Init(stringLine string) error {
-	line.buffer = make([]rune, len(stringLine))
+	line.buffer = []rune(stringLine)
+	line.source = nil
+
+	return nil
+}
 
-	for index, char := range stringLine {
-		line.buffer[index] = char
+// Line structure initialization function based on the bytes of the given source. The size is the count of bytes (without
+// the LF line break) and the rune count is the count of characters without the CR characters, which are removed on decoding
+func (line *Line) InitSource(source *LineSource, offset int64, size int, runeCount int) error {
+	if source == nil {
+		return errors.New("line: invalid line source struct reference")
 	}
 
+	if offset < 0 || size < 0 || runeCount < 0 {
+		return errors.New("line: invalid line source range")
+	}
+
+	line.buffer = nil
+	line.source = source
+	line.offset = offset
+	line.size = size
+	line.runeCount = runeCount
+
+	return nil
+}
+
+// Line structure initialization funcation based on the given rune slice. The characters are copied, so the given slice can be
+// reused by the caller
+func (line *Line) InitBuffer(buffer []rune) error {
+	line.buffer = make([]rune, len(buffer))
+	copy(line.buffer, buffer)
+	line.source = nil
+
 	return nil
 }
 
 // Return the line (buffer) length
 func (line *Line) GetBufferLength() int {
+	if line.source != nil {
+		return line.runeCount
+	}
+
 	return len(line.buffer)
 }
 
@@ -30,7 +91,7 @@ func (line *Line) GetBufferLength() int {
 func (line *Line) GetBufferAsString() *string {
 	builder := strings.Builder{}
 
-	for _, char := range line.buffer {
+	for _, char := range line.getBuffer() {
 		builder.WriteRune(char)
 	}
 
@@ -38,13 +99,52 @@ func (line *Line) GetBufferAsString() *string {
 	return &lineString
 }
 
-// Return the line in rune slice representation
+// Return the revision of the line. The revision is incremented on every change of the line buffer
+func (line *Line) GetRevision() int {
+	return line.revision
+}
+
+// Return the line in rune slice representation. The line referencing the source is decoded, but the decoded characters are
+// not kept, so the slice must not be changed
 func (line *Line) GetBufferAsSlice() []rune {
-	return line.buffer
+	return line.getBuffer()
+}
+
+// Helper function used to return the characters of the line. The line referencing the source is decoded from the file
+func (line *Line) getBuffer() []rune {
+	if line.source == nil {
+		return line.buffer
+	}
+
+	data := make([]byte, line.size)
+	readSize, _ := line.source.file.ReadAt(data, line.offset)
+
+	buffer := []rune(string(bytes.ReplaceAll(data[:readSize], []byte{'\r'}, nil)))
+
+	// NOTE: The file could be truncated by a different program, the missing characters are replaced with the replacement
+	// character, so the length of the line is not changing
+	for len(buffer) < line.runeCount {
+		buffer = append(buffer, '\uFFFD')
+	}
+
+	return buffer[:line.runeCount]
+}
+
+// Helper function used to decode the line referencing the source and keep the decoded characters, so the line can be changed
+// or accessed repeatedly
+func (line *Line) materialize() {
+	if line.source == nil {
+		return
+	}
+
+	line.buffer = line.getBuffer()
+	line.source = nil
 }
 
 // Insert a given rune at the position specified by the given cursor
 func (line *Line) InsertBufferCharacter(char rune, cursor *Cursor) error {
+	line.materialize()
+
 	xOffset := cursor.GetOffsetX()
 	if xOffset < 0 {
 		return errors.New("line: invalid x (horizontal) negative offset requested to insert")
@@ -54,6 +154,8 @@ func (line *Line) InsertBufferCharacter(char rune, cursor *Cursor) error {
 		return errors.New("line: invalid x (horizontal) out of bound offset requested to insert")
 	}
 
+	line.revision += 1
+
 	if len(line.buffer) == xOffset {
 		line.buffer = append(line.buffer, char)
 		return nil
@@ -70,6 +172,8 @@ func (line *Line) InsertBufferCharacter(char rune, cursor *Cursor) error {
 
 // Remove a rune at the position before the position specified by the given cursor
 func (line *Line) RemoveBufferCharacterHead(cursor *Cursor) error {
+	line.materialize()
+
 	xOffset := cursor.GetOffsetX()
 	if xOffset <= 0 {
 		return errors.New("line: invalid x (horizontal) negative offset requested to remove")
@@ -83,12 +187,15 @@ func (line *Line) RemoveBufferCharacterHead(cursor *Cursor) error {
 	bufferTail := line.buffer[xOffset:]
 
 	line.buffer = append(bufferHead, bufferTail...)
+	line.revision += 1
 
 	return nil
 }
 
 // Remove a rune at the postion behind the position specified by the given cursor
 func (line *Line) RemoveBufferCharacterTail(cursor *Cursor) error {
+	line.materialize()
+
 	xOffset := cursor.GetOffsetX()
 	if xOffset < 0 {
 		return errors.New("line: invalid x (horizontal) negative offset requested to remove")
@@ -102,6 +209,7 @@ func (line *Line) RemoveBufferCharacterTail(cursor *Cursor) error {
 	bufferTail := line.buffer[xOffset+1:]
 
 	line.buffer = append(bufferHead, bufferTail...)
+	line.revision += 1
 
 	return nil
 }
@@ -112,11 +220,11 @@ func (line *Line) GetBufferCharacterByOffset(xOffset int) (rune, error) {
 		return 0, errors.New("line: invalid x (horizontal) negative offset requested to get")
 	}
 
-	if xOffset >= len(line.buffer) {
+	if xOffset >= line.GetBufferLength() {
 		return 0, errors.New("line: invalid x (horizontal) out of bound offset requested to get")
 	}
 
-	targetChar := line.buffer[xOffset]
+	targetChar := line.getBuffer()[xOffset]
 	return targetChar, nil
 }
 
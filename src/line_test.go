@@ -337,3 +337,52 @@ func TestLineShouldNotReturCharacterAtInvalidPosition(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestLineShouldDecodeCharactersFromSource(t *testing.T) {
+	filePath := createTestFile(t, "file.txt", "first\nzażółć\r\n")
+
+	source := new(LineSource)
+	if err := source.Init(filePath); err != nil {
+		t.FailNow()
+	}
+
+	defer source.Close()
+
+	line := new(Line)
+	if err := line.InitSource(source, 6, 11, 6); err != nil {
+		t.FailNow()
+	}
+
+	if line.GetBufferLength() != 6 || *line.GetBufferAsString() != "zażółć" {
+		t.Fail()
+	}
+
+	if char, err := line.GetBufferCharacterByOffset(2); err != nil || char != 'ż' {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(6, 0, CreateConsoleMockup(), nil); err != nil {
+		t.FailNow()
+	}
+
+	if err := line.InsertBufferCharacter('!', cursor); err != nil {
+		t.FailNow()
+	}
+
+	if line.GetBufferLength() != 7 || *line.GetBufferAsString() != "zażółć!" {
+		t.Fail()
+	}
+}
+
+func TestLineShouldNotInitializeForInvalidSource(t *testing.T) {
+	line := new(Line)
+
+	if err := line.InitSource(nil, 0, 0, 0); err == nil {
+		t.Fail()
+	}
+
+	if err := line.InitSource(new(LineSource), -1, 0, 0); err == nil {
+		t.Fail()
+	}
+}
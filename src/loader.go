@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	loaderInitialSize    = 512 * 1024
+	loaderBatchSize      = 4 * 1024 * 1024
+	loaderReadBufferSize = 1024 * 1024
+)
+
+// NOTE: Error used internally to stop the background loading
+var errLoaderCancelled = errors.New("loader: the loading was cancelled")
+
+// Structure representing the loading of a large file in chunks. The beginning of the file is read synchronously, so the first
+// screen can be presented immediately, and the remaining lines are read by a background goroutine. The lines are collected in
+// batches, which are taken over by the owner of the text, so the text is never modified from a different goroutine. The lines
+// read in the background are only referencing the line source (the offset and size of the line in the file) and are decoded on
+// demand, so the memory usage is not growing with the length of the lines
+type FileLoader struct {
+	file     *os.File
+	source   *LineSource
+	reader   *bufio.Reader
+	size     int64
+	loaded   int64
+	lines    []*Line
+	done     bool
+	err      error
+	started  bool
+	notify   func()
+	cancel   chan struct{}
+	finished chan struct{}
+	mutex    sync.Mutex
+}
+
+// File loader structure initialization function. The file is opened and kept open until the loading is finished. The line
+// source is opened separately and must be closed by the owner of the loaded lines (see GetLineSource)
+func (loader *FileLoader) Init(filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	source := new(LineSource)
+	if err := source.Init(filePath); err != nil {
+		file.Close()
+		return err
+	}
+
+	loader.file = file
+	loader.source = source
+	loader.reader = bufio.NewReaderSize(file, loaderReadBufferSize)
+	loader.size = info.Size()
+	loader.loaded = 0
+	loader.lines = make([]*Line, 0)
+	loader.done = false
+	loader.err = nil
+	loader.started = false
+	loader.cancel = make(chan struct{})
+	loader.finished = make(chan struct{})
+
+	return nil
+}
+
+// Read the complete lines from the beginning of the file until at least the given count of bytes is read. The function returns
+// the content without the line break of the last line and a bool value indicating if the whole file was read. If the whole file
+// was read, the content is returned unchanged (including the trailing line break) and the file is closed
+func (loader *FileLoader) ReadInitialContent(size int) (string, bool, error) {
+	content := bytes.Buffer{}
+
+	for content.Len() < size {
+		line, err := readLoaderLine(loader.reader)
+		content.Write(line)
+
+		if errors.Is(err, io.EOF) {
+			loader.loaded = int64(content.Len())
+			loader.done = true
+
+			// NOTE: The line source is not needed, because all lines are decoded from the content
+			loader.source.Close()
+			return content.String(), true, loader.file.Close()
+		}
+
+		if err != nil {
+			loader.source.Close()
+			loader.file.Close()
+			return "", false, err
+		}
+	}
+
+	loader.loaded = int64(content.Len())
+
+	// NOTE: The content is ending with the line break, which is separating the content from the lines loaded in the background
+	return string(bytes.TrimSuffix(content.Bytes(), []byte{'\n'})), false, nil
+}
+
+// Start the background loading of the remaining lines. The notify function is called (from the loading goroutine) after a batch
+// of lines was collected and after the loading is finished
+func (loader *FileLoader) Start(notify func()) error {
+	if loader.started {
+		return errors.New("loader: the loading was already started")
+	}
+
+	if loader.done {
+		return errors.New("loader: the file is already loaded")
+	}
+
+	loader.started = true
+	loader.notify = notify
+	go loader.load()
+
+	return nil
+}
+
+// Stop the background loading and wait until the loading goroutine is finished. The collected lines are kept
+func (loader *FileLoader) Cancel() {
+	if !loader.started {
+		return
+	}
+
+	select {
+	case <-loader.cancel:
+	default:
+		close(loader.cancel)
+	}
+
+	<-loader.finished
+}
+
+// Return the lines collected since the previous call, a bool value indicating if the loading is finished (all lines were
+// returned) and the error which stopped the loading
+func (loader *FileLoader) TakeLines() ([]*Line, bool, error) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	lines := loader.lines
+	loader.lines = make([]*Line, 0)
+
+	return lines, loader.done, loader.err
+}
+
+// Return the line source referenced by the loaded lines. The source must be closed after the lines are no longer used
+func (loader *FileLoader) GetLineSource() *LineSource {
+	return loader.source
+}
+
+// Return the loading progress as the percentage of the file size
+func (loader *FileLoader) GetProgress() int {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	if loader.size <= 0 {
+		return 100
+	}
+
+	// NOTE: The file could grow while being loaded
+	if loader.loaded >= loader.size {
+		return 100
+	}
+
+	return int(loader.loaded * 100 / loader.size)
+}
+
+// Helper function used to read the remaining lines of the file. The function is executed as a separate goroutine
+func (loader *FileLoader) load() {
+	defer restoreBackgroundConsoleOnPanic()
+	defer close(loader.finished)
+	defer loader.file.Close()
+
+	batch := make([]*Line, 0)
+	batchSize := 0
+
+	// NOTE: The loaded count is only changed by the loading goroutine after the start
+	offset := loader.loaded
+
+	for {
+		select {
+		case <-loader.cancel:
+			loader.finish(batch, batchSize, errLoaderCancelled)
+			return
+		default:
+		}
+
+		lineData, err := readLoaderLine(loader.reader)
+		if err != nil && !errors.Is(err, io.EOF) {
+			loader.finish(batch, batchSize, err)
+			return
+		}
+
+		lineOffset := offset
+		offset += int64(len(lineData))
+		batchSize += len(lineData)
+
+		// NOTE: Excluding the LF line break and the 0x0D CR (Carriage Return) characters, similar to the text initialization
+		lineData = bytes.TrimSuffix(lineData, []byte{'\n'})
+		runeCount := utf8.RuneCount(lineData) - bytes.Count(lineData, []byte{'\r'})
+
+		line := new(Line)
+		if lineErr := line.InitSource(loader.source, lineOffset, len(lineData), runeCount); lineErr != nil {
+			loader.finish(batch, batchSize, lineErr)
+			return
+		}
+
+		batch = append(batch, line)
+
+		if errors.Is(err, io.EOF) {
+			loader.finish(batch, batchSize, nil)
+			return
+		}
+
+		if batchSize >= loaderBatchSize {
+			loader.mutex.Lock()
+			loader.lines = append(loader.lines, batch...)
+			loader.loaded += int64(batchSize)
+			loader.mutex.Unlock()
+
+			batch = make([]*Line, 0)
+			batchSize = 0
+
+			if loader.notify != nil {
+				loader.notify()
+			}
+		}
+	}
+}
+
+// Helper function used to hand over the last batch of lines and mark the loading as finished
+func (loader *FileLoader) finish(batch []*Line, batchSize int, err error) {
+	loader.mutex.Lock()
+	loader.lines = append(loader.lines, batch...)
+	loader.loaded += int64(batchSize)
+	loader.done = true
+	loader.err = err
+	loader.mutex.Unlock()
+
+	if loader.notify != nil {
+		loader.notify()
+	}
+}
+
+// Helper function used to read the next line including the LF line break (if present). The lines longer than the reader buffer
+// are accumulated. The io.EOF error is returned together with the last line
+func readLoaderLine(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadSlice('\n')
+	if !errors.Is(err, bufio.ErrBufferFull) {
+		return line, err
+	}
+
+	// NOTE: The slice returned by the reader is only valid until the next read, so the long line is copied
+	longLine := append([]byte{}, line...)
+	for errors.Is(err, bufio.ErrBufferFull) {
+		line, err = reader.ReadSlice('\n')
+		longLine = append(longLine, line...)
+	}
+
+	return longLine, err
+}
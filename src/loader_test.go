@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileLoaderShouldReadWholeSmallFile(t *testing.T) {
+	filePath := createTestFile(t, "file.txt", "Hello\r\nWorld\n")
+
+	loader := new(FileLoader)
+	if err := loader.Init(filePath); err != nil {
+		t.FailNow()
+	}
+
+	content, complete, err := loader.ReadInitialContent(1024)
+	if err != nil || !complete {
+		t.FailNow()
+	}
+
+	if content != "Hello\r\nWorld\n" {
+		t.Fail()
+	}
+
+	if loader.GetProgress() != 100 {
+		t.Fail()
+	}
+
+	if err := loader.Start(nil); err == nil {
+		t.Fail()
+	}
+}
+
+func TestFileLoaderShouldLoadRemainingLinesInBackground(t *testing.T) {
+	filePath := createTestFile(t, "file.txt", "first\nsecond\r\nthird\n\nlast")
+
+	loader := new(FileLoader)
+	if err := loader.Init(filePath); err != nil {
+		t.FailNow()
+	}
+
+	content, complete, err := loader.ReadInitialContent(8)
+	if err != nil || complete {
+		t.FailNow()
+	}
+
+	defer loader.GetLineSource().Close()
+
+	if content != "first\nsecond\r" {
+		t.Fail()
+	}
+
+	notified := make(chan struct{}, 16)
+	if err := loader.Start(func() { notified <- struct{}{} }); err != nil {
+		t.FailNow()
+	}
+
+	lines := waitForLoaderTestLines(t, loader, notified)
+
+	expected := []string{"third", "", "last"}
+	if len(lines) != len(expected) {
+		t.FailNow()
+	}
+
+	for index, line := range lines {
+		if *line.GetBufferAsString() != expected[index] {
+			t.Fail()
+		}
+	}
+
+	if loader.GetProgress() != 100 {
+		t.Fail()
+	}
+}
+
+func TestFileLoaderShouldStopLoadingAfterCancel(t *testing.T) {
+	filePath := createTestFile(t, "file.txt", strings.Repeat("line\n", 1024))
+
+	loader := new(FileLoader)
+	if err := loader.Init(filePath); err != nil {
+		t.FailNow()
+	}
+
+	if _, complete, err := loader.ReadInitialContent(10); err != nil || complete {
+		t.FailNow()
+	}
+
+	defer loader.GetLineSource().Close()
+
+	if err := loader.Start(nil); err != nil {
+		t.FailNow()
+	}
+
+	loader.Cancel()
+
+	_, done, err := loader.TakeLines()
+	if !done {
+		t.Fail()
+	}
+
+	// NOTE: The loading could be finished before the cancellation was noticed
+	if err != nil && err != errLoaderCancelled {
+		t.Fail()
+	}
+}
+
+func TestFileLoaderShouldReadLinesLongerThanReaderBuffer(t *testing.T) {
+	longLine := strings.Repeat("x", 100)
+	reader := bufio.NewReaderSize(strings.NewReader(longLine+"\nshort"), 16)
+
+	line, err := readLoaderLine(reader)
+	if err != nil || string(line) != longLine+"\n" {
+		t.Fail()
+	}
+
+	line, err = readLoaderLine(reader)
+	if err == nil || string(line) != "short" {
+		t.Fail()
+	}
+}
+
+func waitForLoaderTestLines(t *testing.T, loader *FileLoader, notified chan struct{}) []*Line {
+	lines := make([]*Line, 0)
+
+	for {
+		select {
+		case <-notified:
+		case <-time.After(5 * time.Second):
+			t.FailNow()
+		}
+
+		takenLines, done, err := loader.TakeLines()
+		if err != nil {
+			t.FailNow()
+		}
+
+		lines = append(lines, takenLines...)
+		if done {
+			return lines
+		}
+	}
+}
@@ -1,20 +1,34 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
 )
 
+// NOTE: The command line flag opening all files in the read-only mode
+const readOnlyFlag = "--read-only"
+
+// NOTE: The console disposed after a panic inside the background goroutines, which can not be recovered by the main goroutine
+var backgroundPanicConsole Console
+
 func main() {
-	if len(os.Args) != 2 {
-		// TODO: Implement title screen
-		printErrorMessage(errors.New("args: invalid program arguments"))
-		os.Exit(1)
-		return
-	}
+	readOnly := false
+	targetFilePaths := make([]string, 0, len(os.Args))
+	targetPositions := make([]string, 0, len(os.Args))
+	for _, argument := range os.Args[1:] {
+		if argument == readOnlyFlag {
+			readOnly = true
+			continue
+		}
 
-	targetFilePath := os.Args[1]
+		targetFilePath, targetPosition := parseTargetFileArgument(argument)
+
+		targetFilePaths = append(targetFilePaths, targetFilePath)
+		targetPositions = append(targetPositions, targetPosition)
+	}
 
 	config := new(Config)
 	if err := config.Init(); err != nil {
@@ -23,6 +37,12 @@ func main() {
 		return
 	}
 
+	// NOTE: The state is not essential, so the unreadable state file is reported and the empty state is used instead
+	state := new(State)
+	if err := initState(state); err != nil {
+		printErrorMessage(err)
+	}
+
 	console, err := CreateConsole()
 	if err != nil {
 		printErrorMessage(err)
@@ -30,14 +50,61 @@ func main() {
 		return
 	}
 
+	// NOTE: The terminal state is restored if the program is terminated by a signal or a panic occurs (also inside the
+	// background goroutines)
+	defer restoreConsoleOnPanic(console)
+	backgroundPanicConsole = console
+	restoreConsoleOnSignal(console)
+
+	// NOTE: The title screen is displayed if no file is specified in the command line
+	if len(targetFilePaths) == 0 {
+		targetFilePath, selected, err := showTitleScreen(console, state, config)
+		if err != nil {
+			printErrorMessage(err)
+			console.Dispose()
+			os.Exit(1)
+			return
+		}
+
+		if !selected {
+			if err := console.Dispose(); err != nil {
+				printErrorMessage(err)
+				os.Exit(1)
+			}
+
+			os.Exit(0)
+			return
+		}
+
+		targetFilePaths = append(targetFilePaths, targetFilePath)
+		targetPositions = append(targetPositions, "")
+	}
+
 	editor := new(Editor)
-	if err := editor.Init(targetFilePath, console, config); err != nil {
+	if err := editor.Init(targetFilePaths, console, config); err != nil {
 		printErrorMessage(err)
 		console.Dispose()
 		os.Exit(1)
 		return
 	}
 
+	if err := editor.SetReadOnly(readOnly); err != nil {
+		printErrorMessage(err)
+		console.Dispose()
+		os.Exit(1)
+		return
+	}
+
+	if err := applyTargetPositions(editor, targetPositions); err != nil {
+		printErrorMessage(err)
+		console.Dispose()
+		os.Exit(1)
+		return
+	}
+
+	// NOTE: The failure of the recent files update is not preventing the editing
+	_ = updateRecentFiles(state, targetFilePaths)
+
 	if err := editor.Start(); err != nil {
 		printErrorMessage(err)
 		console.Dispose()
@@ -53,6 +120,127 @@ func main() {
 	os.Exit(0)
 }
 
+// Split the file argument into the file path and the position string (file.txt:120:15). The argument is treated as a plain
+// path if a file with the exact name exists
+func parseTargetFileArgument(argument string) (string, string) {
+	if _, err := os.Stat(argument); err == nil {
+		return argument, ""
+	}
+
+	return SplitPathPosition(argument)
+}
+
+// Display the title screen and return the path of the file selected to open and a bool value indicating if a file was selected
+func showTitleScreen(console Console, state *State, config *Config) (string, bool, error) {
+	themes := new(Themes)
+	if err := themes.Init(&config.ThemeConfiguration); err != nil {
+		return "", false, err
+	}
+
+	titleScreen := new(TitleScreen)
+	if err := titleScreen.Init(console, state, themes.GetCurrent()); err != nil {
+		return "", false, err
+	}
+
+	targetFilePath, selected, err := titleScreen.Start()
+	if err != nil || !selected {
+		return "", false, err
+	}
+
+	return targetFilePath, true, console.Clear()
+}
+
+// Initialize the state from the state file inside the user configuration directory. The state is empty if the file can not be
+// located or read (the state without the file path is not saved)
+func initState(state *State) error {
+	stateFilePath, err := GetStateFilePath()
+	if err != nil {
+		state.RecentFiles = make([]string, 0)
+		return err
+	}
+
+	return state.Init(stateFilePath)
+}
+
+// Add the opened files to the recently opened files and save the state
+func updateRecentFiles(state *State, filePaths []string) error {
+	// NOTE: The files are added in reverse order, so the first opened file is the latest one
+	for index := len(filePaths) - 1; index >= 0; index -= 1 {
+		if err := state.AddRecentFile(filePaths[index]); err != nil {
+			return err
+		}
+	}
+
+	return state.Save()
+}
+
+// Move the cursor of every buffer to the position specified in the command line. The first buffer is active afterwards
+func applyTargetPositions(editor *Editor, targetPositions []string) error {
+	for index := len(targetPositions) - 1; index >= 0; index -= 1 {
+		if len(targetPositions[index]) == 0 && index != 0 {
+			continue
+		}
+
+		if err := editor.SwitchBuffer(index); err != nil {
+			return err
+		}
+
+		if len(targetPositions[index]) == 0 {
+			continue
+		}
+
+		if err := editor.GoToPosition(targetPositions[index]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Helper function used to dispose the console and terminate the program after a panic. The function has to be deferred
+func restoreConsoleOnPanic(console Console) {
+	if recovered := recover(); recovered != nil {
+		terminateAfterPanic(console, recovered)
+	}
+}
+
+// Helper function used to dispose the console (see restoreConsoleOnPanic) and terminate the program after a panic inside
+// the background goroutines (e.g. the file loading). The function has to be deferred by the goroutine
+func restoreBackgroundConsoleOnPanic() {
+	if recovered := recover(); recovered != nil {
+		terminateAfterPanic(backgroundPanicConsole, recovered)
+	}
+}
+
+// Helper function used to dispose the console, print the panic value with the stack trace and terminate the program
+func terminateAfterPanic(console Console, recovered interface{}) {
+	if console != nil {
+		console.Dispose()
+	}
+
+	printErrorMessage(fmt.Errorf("%v", recovered))
+	fmt.Printf("%s\n", debug.Stack())
+	os.Exit(2)
+}
+
+// Helper function used to dispose the console and terminate the program after the termination signal (SIGTERM, SIGHUP or SIGINT)
+func restoreConsoleOnSignal(console Console) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGINT)
+
+	go func() {
+		receivedSignal := <-signals
+		console.Dispose()
+
+		exitCode := 1
+		if systemSignal, ok := receivedSignal.(syscall.Signal); ok {
+			exitCode = 128 + int(systemSignal)
+		}
+
+		os.Exit(exitCode)
+	}()
+}
+
 const (
 	redColorCode   = "\033[31m"
 	resetColorCode = "\033[0m"
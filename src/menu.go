@@ -18,8 +18,17 @@ type Menu struct {
 	notificationText   string
 	cursorPositionText string
 	fileNameText       string
+	bufferIndexText    string
+	loadingText        string
 	eolSequenceText    string
 	fileModified       bool
+	fileReadOnly       bool
+	inputActive        bool
+	inputLabel         string
+	inputValue         []rune
+	inputCursor        int
+	inputCaretIndex    int
+	inputStatusText    string
 }
 
 // Menu widget structure initialization funcation
@@ -35,9 +44,13 @@ func (menu *Menu) Init(fileName string, eolSequenceName string) error {
 	menu.fileNameText = fileName
 	menu.eolSequenceText = eolSequenceName
 
+	menu.bufferIndexText = ""
+	menu.loadingText = ""
 	menu.notificationText = ""
 	menu.cursorPositionText = ""
 	menu.fileModified = false
+	menu.fileReadOnly = false
+	menu.inputActive = false
 
 	return nil
 }
@@ -57,18 +70,78 @@ func (menu *Menu) SetCursorPositionText(cursor Cursor) error {
 	return nil
 }
 
+// Function used to update the displayed file name (e.g. after switching the buffer)
+func (menu *Menu) SetFileName(fileName string) error {
+	if len(fileName) <= 0 {
+		return errors.New("menu: invalid file name specified")
+	}
+
+	menu.fileNameText = fileName
+	return nil
+}
+
+// Function used to update the displayed end-of-line sequence name
+func (menu *Menu) SetEndOfLineSequenceName(eolSequenceName string) error {
+	if len(eolSequenceName) <= 0 {
+		return errors.New("menu: invalid end-of-line sequence name specified")
+	}
+
+	menu.eolSequenceText = eolSequenceName
+	return nil
+}
+
+// Function used to update the buffer index text. The index is only displayed if more than one buffer is open
+func (menu *Menu) SetBufferIndexText(index int, count int) error {
+	if index < 0 || index >= count {
+		return errors.New("menu: invalid buffer index specified")
+	}
+
+	if count == 1 {
+		menu.bufferIndexText = ""
+		return nil
+	}
+
+	menu.bufferIndexText = fmt.Sprintf("[%d/%d]", index+1, count)
+	return nil
+}
+
+// Function used to update the file loading progress text. The progress is only displayed while the file is being loaded
+func (menu *Menu) SetLoadingProgressText(loading bool, progress int) error {
+	if progress < 0 || progress > 100 {
+		return errors.New("menu: invalid loading progress specified")
+	}
+
+	if !loading {
+		menu.loadingText = ""
+		return nil
+	}
+
+	menu.loadingText = fmt.Sprintf("[Loading %d%%]", progress)
+	return nil
+}
+
 // Function used to update the file modifiation indication variable
 func (menu *Menu) SetFileModificationState(modified bool) error {
 	menu.fileModified = modified
 	return nil
 }
 
+// Function used to update the file read-only indication variable
+func (menu *Menu) SetFileReadOnlyState(readOnly bool) error {
+	menu.fileReadOnly = readOnly
+	return nil
+}
+
 // Return a buffer containg the content of the menu, ready for rendering
 func (menu *Menu) GenerateOutputBuffer(width int) ([]rune, error) {
 	if width <= 0 {
 		return nil, errors.New("menu: invalid width specified to generate output buffer")
 	}
 
+	if menu.inputActive {
+		return menu.generateInputOutputBuffer(width), nil
+	}
+
 	mlNotification := width / 2
 	mlInfo := width - mlNotification
 
@@ -89,6 +162,20 @@ func (menu *Menu) GenerateOutputBuffer(width int) ([]rune, error) {
 	const separator = " | "
 
 	informationContentBuilder := strings.Builder{}
+	if len(menu.bufferIndexText) > 0 {
+		informationContentBuilder.WriteString(menu.bufferIndexText)
+		informationContentBuilder.WriteRune(' ')
+	}
+
+	if len(menu.loadingText) > 0 {
+		informationContentBuilder.WriteString(menu.loadingText)
+		informationContentBuilder.WriteRune(' ')
+	}
+
+	if menu.fileReadOnly {
+		informationContentBuilder.WriteString("[RO] ")
+	}
+
 	informationContentBuilder.WriteString(menu.fileNameText)
 	informationContentBuilder.WriteString(separator)
 	informationContentBuilder.WriteString(menu.eolSequenceText)
@@ -113,5 +200,174 @@ func (menu *Menu) GenerateOutputBuffer(width int) ([]rune, error) {
 	}
 
 	outputBuffer := append([]rune(notificationPart), []rune(informationPart)...)
+
+	// NOTE: The information part is skipped if it does not fit, so the buffer is filled up to the requested width
+	for len(outputBuffer) < width {
+		outputBuffer = append(outputBuffer, ' ')
+	}
+
 	return outputBuffer, nil
 }
+
+// Switch the menu into the input mode. The label and the editable value are displayed instead of the notification and
+// the information is replaced with the input status text
+func (menu *Menu) StartInput(label string, value string) error {
+	menu.inputActive = true
+	menu.inputLabel = label
+	menu.inputValue = []rune(value)
+	menu.inputCursor = len(menu.inputValue)
+	menu.inputCaretIndex = 0
+	menu.inputStatusText = ""
+
+	return nil
+}
+
+// Switch the menu back from the input mode
+func (menu *Menu) StopInput() error {
+	menu.inputActive = false
+	menu.inputLabel = ""
+	menu.inputValue = nil
+	menu.inputCursor = 0
+	menu.inputStatusText = ""
+
+	return nil
+}
+
+// Return a bool value indicating if the menu is in the input mode
+func (menu *Menu) IsInputActive() bool {
+	return menu.inputActive
+}
+
+// Return the current value of the input
+func (menu *Menu) GetInputValue() string {
+	return string(menu.inputValue)
+}
+
+// Replace the value of the input and place the input cursor at the end of the value
+func (menu *Menu) SetInputValue(value string) error {
+	if !menu.inputActive {
+		return errors.New("menu: the input mode is not active")
+	}
+
+	menu.inputValue = []rune(value)
+	menu.inputCursor = len(menu.inputValue)
+	return nil
+}
+
+// Function used to update the text displayed next to the input (e.g. search results)
+func (menu *Menu) SetInputStatusText(status string) error {
+	menu.inputStatusText = status
+	return nil
+}
+
+// Insert the given character at the input cursor position
+func (menu *Menu) InsertInputCharacter(char rune) error {
+	if !menu.inputActive {
+		return errors.New("menu: the input mode is not active")
+	}
+
+	value := make([]rune, 0, len(menu.inputValue)+1)
+	value = append(value, menu.inputValue[:menu.inputCursor]...)
+	value = append(value, char)
+	value = append(value, menu.inputValue[menu.inputCursor:]...)
+
+	menu.inputValue = value
+	menu.inputCursor += 1
+	return nil
+}
+
+// Remove the character before the input cursor position. The function returns a bool value indicating if a character was removed
+func (menu *Menu) RemoveInputCharacterHead() (bool, error) {
+	if !menu.inputActive {
+		return false, errors.New("menu: the input mode is not active")
+	}
+
+	if menu.inputCursor == 0 {
+		return false, nil
+	}
+
+	menu.inputValue = append(menu.inputValue[:menu.inputCursor-1], menu.inputValue[menu.inputCursor:]...)
+	menu.inputCursor -= 1
+	return true, nil
+}
+
+// Remove the character behind the input cursor position. The function returns a bool value indicating if a character was removed
+func (menu *Menu) RemoveInputCharacterTail() (bool, error) {
+	if !menu.inputActive {
+		return false, errors.New("menu: the input mode is not active")
+	}
+
+	if menu.inputCursor == len(menu.inputValue) {
+		return false, nil
+	}
+
+	menu.inputValue = append(menu.inputValue[:menu.inputCursor], menu.inputValue[menu.inputCursor+1:]...)
+	return true, nil
+}
+
+// Set the input cursor position. The position is limited to the input value length
+func (menu *Menu) SetInputCursorPosition(position int) error {
+	if !menu.inputActive {
+		return errors.New("menu: the input mode is not active")
+	}
+
+	if position < 0 {
+		position = 0
+	}
+
+	if position > len(menu.inputValue) {
+		position = len(menu.inputValue)
+	}
+
+	menu.inputCursor = position
+	return nil
+}
+
+// Return the input cursor position inside the input value
+func (menu *Menu) GetInputCursorPosition() int {
+	return menu.inputCursor
+}
+
+// Return the x (horizontal) index of the input cursor inside the latest generated output buffer
+func (menu *Menu) GetInputCaretIndex() int {
+	return menu.inputCaretIndex
+}
+
+// Helper function used to generate the output buffer in the input mode. The input is scrolled to keep the cursor visible
+func (menu *Menu) generateInputOutputBuffer(width int) []rune {
+	status := []rune(menu.inputStatusText)
+	if len(status) > width/2 {
+		status = status[:width/2]
+	}
+
+	inputWidth := width - len(status)
+	if len(status) > 0 {
+		inputWidth -= 1
+	}
+
+	content := append([]rune(menu.inputLabel), menu.inputValue...)
+	caretIndex := len([]rune(menu.inputLabel)) + menu.inputCursor
+
+	contentShift := 0
+	if inputWidth > 0 && caretIndex >= inputWidth {
+		contentShift = caretIndex - inputWidth + 1
+	}
+
+	outputBuffer := make([]rune, width)
+	for xIndex := range outputBuffer {
+		outputBuffer[xIndex] = ' '
+	}
+
+	for xIndex := 0; xIndex < inputWidth && xIndex+contentShift < len(content); xIndex += 1 {
+		outputBuffer[xIndex] = content[xIndex+contentShift]
+	}
+
+	copy(outputBuffer[width-len(status):], status)
+
+	menu.inputCaretIndex = caretIndex - contentShift
+	if menu.inputCaretIndex >= width {
+		menu.inputCaretIndex = width - 1
+	}
+
+	return outputBuffer
+}
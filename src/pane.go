@@ -0,0 +1,238 @@
+package main
+
+import "errors"
+
+const (
+	paneMinimalWidth  = 10
+	paneMinimalHeight = 3
+	paneResizeStep    = 0.05
+)
+
+// Structure representing a single pane of the editor. Every pane has its own display (viewport), cursor, selection and search
+// state. The panes presenting the same buffer are sharing the text, so the changes are visible in all of them
+type Pane struct {
+	bufferIndex  int
+	buffer       *Buffer
+	textRevision int
+	xArea        int
+	yArea        int
+	widthArea    int
+	heightArea   int
+	cursor       *Cursor
+	selection    *Selection
+	search       *Search
+	display      *Display
+}
+
+// Pane structure initialization function. The cursor is placed at the stored cursor position of the given buffer
+func (pane *Pane) Init(buffer *Buffer, bufferIndex int, console Console, theme *Theme, config *Config) error {
+	if buffer == nil {
+		return errors.New("pane: invalid buffer struct reference")
+	}
+
+	if config == nil {
+		return errors.New("pane: invalid config reference")
+	}
+
+	pane.cursor = new(Cursor)
+	if err := pane.cursor.Init(0, 0, console, &config.CursorConfiguration); err != nil {
+		return err
+	}
+
+	pane.selection = new(Selection)
+	if err := pane.selection.Init(pane.cursor); err != nil {
+		return err
+	}
+
+	pane.search = new(Search)
+	if err := pane.search.Init(buffer.GetText()); err != nil {
+		return err
+	}
+
+	panePadding := new(Padding)
+	if err := panePadding.Init(0, MenuHeight, 0, 0); err != nil {
+		return err
+	}
+
+	pane.display = new(Display)
+	if err := pane.display.Init(pane.cursor, panePadding, console, &config.DisplayConfiguration); err != nil {
+		return err
+	}
+
+	consoleWidth, consoleHeight := pane.display.GetFullDisplaySize()
+	pane.xArea = 0
+	pane.yArea = 0
+	pane.widthArea = consoleWidth
+	pane.heightArea = consoleHeight - MenuHeight
+
+	if err := pane.display.SetTheme(theme); err != nil {
+		return err
+	}
+
+	if err := pane.display.AttachSelection(pane.selection); err != nil {
+		return err
+	}
+
+	if err := pane.display.AttachSearch(pane.search); err != nil {
+		return err
+	}
+
+	return pane.AttachBuffer(buffer, bufferIndex)
+}
+
+// Present the given buffer inside the pane. The cursor position of the previous buffer is stored and the stored cursor position
+// of the given buffer is restored. The selection and search state are dropped
+func (pane *Pane) AttachBuffer(buffer *Buffer, bufferIndex int) error {
+	if buffer == nil {
+		return errors.New("pane: invalid buffer struct reference")
+	}
+
+	if pane.buffer != nil {
+		pane.buffer.StoreCursorPosition(pane.cursor.GetOffsetX(), pane.cursor.GetOffsetY())
+	}
+
+	pane.buffer = buffer
+	pane.bufferIndex = bufferIndex
+	pane.textRevision = buffer.GetText().GetRevision()
+
+	pane.selection.Clear()
+	if err := pane.search.Init(buffer.GetText()); err != nil {
+		return err
+	}
+
+	if err := pane.display.AttachText(buffer.GetText()); err != nil {
+		return err
+	}
+
+	if err := pane.display.AttachHighlighter(buffer.GetHighlighter()); err != nil {
+		return err
+	}
+
+	if err := pane.cursor.SetOffsets(buffer.GetCursorPosition()); err != nil {
+		return err
+	}
+
+	// NOTE: The stored position could be removed by the changes made in a different pane
+	return pane.limitCursorPosition()
+}
+
+// Return the buffer presented inside the pane
+func (pane *Pane) GetBuffer() *Buffer {
+	return pane.buffer
+}
+
+// Return the index of the buffer presented inside the pane
+func (pane *Pane) GetBufferIndex() int {
+	return pane.bufferIndex
+}
+
+// Return the cursor of the pane
+func (pane *Pane) GetCursor() *Cursor {
+	return pane.cursor
+}
+
+// Return the selection of the pane
+func (pane *Pane) GetSelection() *Selection {
+	return pane.selection
+}
+
+// Return the search state of the pane
+func (pane *Pane) GetSearch() *Search {
+	return pane.search
+}
+
+// Return the display of the pane
+func (pane *Pane) GetDisplay() *Display {
+	return pane.display
+}
+
+// Set the console area of the pane. The area is specified by the console position and size, the remaining space of the
+// console is used as the display padding
+func (pane *Pane) SetArea(xIndex int, yIndex int, width int, height int) error {
+	consoleWidth, consoleHeight := pane.display.GetFullDisplaySize()
+
+	panePadding := new(Padding)
+	if err := panePadding.Init(yIndex, consoleHeight-yIndex-height, xIndex, consoleWidth-xIndex-width); err != nil {
+		return err
+	}
+
+	pane.xArea = xIndex
+	pane.yArea = yIndex
+	pane.widthArea = width
+	pane.heightArea = height
+
+	return pane.display.SetPadding(panePadding)
+}
+
+// Return the console area of the pane as the console position (x, y) and size (width, height)
+func (pane *Pane) GetArea() (int, int, int, int) {
+	return pane.xArea, pane.yArea, pane.widthArea, pane.heightArea
+}
+
+// Return a bool value indicating if the given console position is placed inside the console area of the pane
+func (pane *Pane) ContainsConsolePosition(xIndex int, yIndex int) bool {
+	if xIndex < pane.xArea || xIndex >= pane.xArea+pane.widthArea {
+		return false
+	}
+
+	return yIndex >= pane.yArea && yIndex < pane.yArea+pane.heightArea
+}
+
+// Mark the current text revision of the pane buffer as presented. This function is used for the focused pane, which is
+// redrawing the changes on its own
+func (pane *Pane) MarkTextSynchronized() {
+	pane.textRevision = pane.buffer.GetText().GetRevision()
+}
+
+// Return a bool value indicating if the text of the pane buffer was changed (e.g. in a different pane) since the text was
+// presented. The selection is dropped, the cursor and the boundaries are moved by the lines inserted or removed above them
+// and the cursor is moved inside the text if the changes removed the cursor position
+func (pane *Pane) SynchronizeText() (bool, error) {
+	text := pane.buffer.GetText()
+	if pane.textRevision == text.GetRevision() {
+		return false, nil
+	}
+
+	yCursor, cursorShifted := text.GetShiftedOffset(pane.textRevision, pane.cursor.GetOffsetY())
+	yBoundary, boundaryShifted := text.GetShiftedOffset(pane.textRevision, pane.display.GetYOffsetShift())
+
+	pane.textRevision = text.GetRevision()
+	pane.selection.Clear()
+
+	// NOTE: The cursor is only limited to the text if the changes are not known (only the latest changes are kept by the text)
+	if cursorShifted {
+		if err := pane.cursor.SetOffsets(pane.cursor.GetOffsetX(), yCursor); err != nil {
+			return true, err
+		}
+	}
+
+	if boundaryShifted {
+		if err := pane.display.SetYOffsetShift(yBoundary); err != nil {
+			return true, err
+		}
+	}
+
+	return true, pane.limitCursorPosition()
+}
+
+// Helper function used to move the cursor to the nearest position inside the text of the pane buffer
+func (pane *Pane) limitCursorPosition() error {
+	text := pane.buffer.GetText()
+
+	yOffset := pane.cursor.GetOffsetY()
+	if yOffset >= text.GetLineCount() {
+		yOffset = text.GetLineCount() - 1
+	}
+
+	lineLength, err := text.GetLineLengthByOffset(yOffset)
+	if err != nil {
+		return err
+	}
+
+	xOffset := pane.cursor.GetOffsetX()
+	if xOffset > lineLength {
+		xOffset = lineLength
+	}
+
+	return pane.cursor.SetOffsets(xOffset, yOffset)
+}
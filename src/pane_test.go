@@ -0,0 +1,207 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestPaneShouldInit(t *testing.T) {
+	buffer := createPaneTestBuffer(t, "First line\nSecond line")
+	config := createBufferTestConfig()
+	theme := CreateDefaultTheme()
+
+	pane := new(Pane)
+	if err := pane.Init(buffer, 0, CreateConsoleMockup(), &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	if pane.GetBuffer() != buffer || pane.GetBufferIndex() != 0 {
+		t.Fail()
+	}
+
+	if _, _, width, height := pane.GetArea(); width != MockConsoleWidth || height != MockConsoleHeight-MenuHeight {
+		t.Fail()
+	}
+}
+
+func TestPaneShouldSetArea(t *testing.T) {
+	buffer := createPaneTestBuffer(t, "First line\nSecond line")
+	config := createBufferTestConfig()
+	theme := CreateDefaultTheme()
+
+	pane := new(Pane)
+	if err := pane.Init(buffer, 0, CreateConsoleMockup(), &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	if err := pane.SetArea(2, 3, 5, 4); err != nil {
+		t.FailNow()
+	}
+
+	display := pane.GetDisplay()
+	if display.GetXLeftOffsetPadding() != 2 || display.GetXRightOffsetPadding() != 3 {
+		t.Fail()
+	}
+
+	if display.GetYTopOffsetPadding() != 3 || display.GetYBottomOffsetPadding() != 3 {
+		t.Fail()
+	}
+
+	if width, height := display.GetTextDisplaySize(); width != 5 || height != 4 {
+		t.Fail()
+	}
+}
+
+func TestPaneShouldContainConsolePositionInsideArea(t *testing.T) {
+	buffer := createPaneTestBuffer(t, "First line\nSecond line")
+	config := createBufferTestConfig()
+	theme := CreateDefaultTheme()
+
+	pane := new(Pane)
+	if err := pane.Init(buffer, 0, CreateConsoleMockup(), &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	if err := pane.SetArea(2, 3, 5, 4); err != nil {
+		t.FailNow()
+	}
+
+	if !pane.ContainsConsolePosition(2, 3) || !pane.ContainsConsolePosition(6, 6) {
+		t.Fail()
+	}
+
+	if pane.ContainsConsolePosition(1, 3) || pane.ContainsConsolePosition(7, 3) || pane.ContainsConsolePosition(2, 7) {
+		t.Fail()
+	}
+}
+
+func TestPaneShouldLimitCursorAfterChangesInDifferentPane(t *testing.T) {
+	buffer := createPaneTestBuffer(t, "First line\nSecond line\nThird line")
+	config := createBufferTestConfig()
+	theme := CreateDefaultTheme()
+	console := CreateConsoleMockup()
+
+	first := new(Pane)
+	if err := first.Init(buffer, 0, console, &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	second := new(Pane)
+	if err := second.Init(buffer, 0, console, &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	if err := second.GetCursor().SetOffsets(10, 2); err != nil {
+		t.FailNow()
+	}
+
+	if changed, err := second.SynchronizeText(); err != nil || changed {
+		t.Fail()
+	}
+
+	if err := buffer.GetText().RemoveRange(5, 0, 10, 2); err != nil {
+		t.FailNow()
+	}
+
+	changed, err := second.SynchronizeText()
+	if err != nil || !changed {
+		t.FailNow()
+	}
+
+	if second.GetCursor().GetOffsetX() != 5 || second.GetCursor().GetOffsetY() != 0 {
+		t.Fail()
+	}
+
+	if changed, err := second.SynchronizeText(); err != nil || changed {
+		t.Fail()
+	}
+}
+
+func TestPaneShouldShiftCursorAfterLinesInsertedAboveInDifferentPane(t *testing.T) {
+	buffer := createPaneTestBuffer(t, "First line\nSecond line\nThird line")
+	config := createBufferTestConfig()
+	theme := CreateDefaultTheme()
+	console := CreateConsoleMockup()
+
+	first := new(Pane)
+	if err := first.Init(buffer, 0, console, &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	second := new(Pane)
+	if err := second.Init(buffer, 0, console, &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	if err := second.GetCursor().SetOffsets(3, 2); err != nil {
+		t.FailNow()
+	}
+
+	if _, _, err := buffer.GetText().InsertText("New line\nOther line\n", first.GetCursor()); err != nil {
+		t.FailNow()
+	}
+
+	changed, err := second.SynchronizeText()
+	if err != nil || !changed {
+		t.FailNow()
+	}
+
+	if second.GetCursor().GetOffsetX() != 3 || second.GetCursor().GetOffsetY() != 4 {
+		t.Fail()
+	}
+
+	if err := buffer.GetText().RemoveRange(0, 0, 0, 3); err != nil {
+		t.FailNow()
+	}
+
+	if changed, err := second.SynchronizeText(); err != nil || !changed {
+		t.FailNow()
+	}
+
+	if second.GetCursor().GetOffsetX() != 3 || second.GetCursor().GetOffsetY() != 1 {
+		t.Fail()
+	}
+}
+
+func TestPaneShouldRestoreBufferCursorPosition(t *testing.T) {
+	firstBuffer := createPaneTestBuffer(t, "First line\nSecond line")
+	secondBuffer := createPaneTestBuffer(t, "Other")
+	config := createBufferTestConfig()
+	theme := CreateDefaultTheme()
+
+	pane := new(Pane)
+	if err := pane.Init(firstBuffer, 0, CreateConsoleMockup(), &theme, &config); err != nil {
+		t.FailNow()
+	}
+
+	if err := pane.GetCursor().SetOffsets(3, 1); err != nil {
+		t.FailNow()
+	}
+
+	if err := pane.AttachBuffer(secondBuffer, 1); err != nil {
+		t.FailNow()
+	}
+
+	if pane.GetCursor().GetOffsetX() != 0 || pane.GetCursor().GetOffsetY() != 0 {
+		t.Fail()
+	}
+
+	if err := pane.AttachBuffer(firstBuffer, 0); err != nil {
+		t.FailNow()
+	}
+
+	if pane.GetCursor().GetOffsetX() != 3 || pane.GetCursor().GetOffsetY() != 1 {
+		t.Fail()
+	}
+}
+
+func createPaneTestBuffer(t *testing.T, content string) *Buffer {
+	filePath := createTestFile(t, "file.txt", content)
+	config := createBufferTestConfig()
+
+	buffer := new(Buffer)
+	if err := buffer.Init(filePath, &config); err != nil {
+		t.FailNow()
+	}
+
+	return buffer
+}
@@ -0,0 +1,138 @@
+package main
+
+import "errors"
+
+const (
+	popupMaxVisibleItems   = 12
+	popupMaxVisiblePreview = 10
+	popupMinimalWidth      = 24
+)
+
+// Structure representing the popup widget which is rendered above the text. The popup contains a titled list of items with
+// a single selected item. The list is scrolled to keep the selected item visible. The optional preview lines are rendered
+// below the list
+type Popup struct {
+	title         string
+	items         []string
+	preview       []string
+	selectedIndex int
+	scrollIndex   int
+}
+
+// Popup widget structure initialization function
+func (popup *Popup) Init(title string, items []string) error {
+	if len(title) <= 0 {
+		return errors.New("popup: invalid title specified")
+	}
+
+	popup.title = title
+	popup.SetItems(items)
+
+	return nil
+}
+
+// Return the title of the popup
+func (popup *Popup) GetTitle() string {
+	return popup.title
+}
+
+// Replace the title of the popup. The title is used to present additional information (e.g. the progress of the items loading)
+func (popup *Popup) SetTitle(title string) error {
+	if len(title) <= 0 {
+		return errors.New("popup: invalid title specified")
+	}
+
+	popup.title = title
+	return nil
+}
+
+// Replace the items of the popup. The first item is selected and the list is scrolled to the top
+func (popup *Popup) SetItems(items []string) {
+	popup.items = items
+	popup.selectedIndex = 0
+	popup.scrollIndex = 0
+}
+
+// Return the items of the popup
+func (popup *Popup) GetItems() []string {
+	return popup.items
+}
+
+// Replace the preview lines of the popup. The preview is hidden if no lines are specified
+func (popup *Popup) SetPreview(preview []string) {
+	popup.preview = preview
+}
+
+// Return the preview lines of the popup
+func (popup *Popup) GetPreview() []string {
+	return popup.preview
+}
+
+// Return the index of the selected item or -1 if the popup has no items
+func (popup *Popup) GetSelectedIndex() int {
+	if len(popup.items) == 0 {
+		return -1
+	}
+
+	return popup.selectedIndex
+}
+
+// Select the item specified by the given index
+func (popup *Popup) SetSelectedIndex(index int) error {
+	if index < 0 || index >= len(popup.items) {
+		return errors.New("popup: invalid out of bound item index requested to select")
+	}
+
+	popup.selectedIndex = index
+	return nil
+}
+
+// Select the next item. The selection is moved to the first item after the last one
+func (popup *Popup) SelectNext() {
+	if len(popup.items) == 0 {
+		return
+	}
+
+	popup.selectedIndex = (popup.selectedIndex + 1) % len(popup.items)
+}
+
+// Select the previous item. The selection is moved to the last item before the first one
+func (popup *Popup) SelectPrevious() {
+	if len(popup.items) == 0 {
+		return
+	}
+
+	popup.selectedIndex = (popup.selectedIndex - 1 + len(popup.items)) % len(popup.items)
+}
+
+// Return the range (start inclusive, end exclusive) of the items visible in the given count of rows. The scroll position
+// is updated to keep the selected item visible
+func (popup *Popup) GetVisibleRange(rows int) (int, int) {
+	if rows <= 0 || len(popup.items) == 0 {
+		return 0, 0
+	}
+
+	if popup.selectedIndex < popup.scrollIndex {
+		popup.scrollIndex = popup.selectedIndex
+	}
+
+	if popup.selectedIndex >= popup.scrollIndex+rows {
+		popup.scrollIndex = popup.selectedIndex - rows + 1
+	}
+
+	// NOTE: The scroll is reduced if the items were replaced with a shorter list
+	if popup.scrollIndex+rows > len(popup.items) {
+		popup.scrollIndex = len(popup.items) - rows
+	}
+
+	if popup.scrollIndex < 0 {
+		popup.scrollIndex = 0
+	}
+
+	end := popup.scrollIndex + rows
+	if end > len(popup.items) {
+		end = len(popup.items)
+	}
+
+	return popup.scrollIndex, end
+}
@@ -0,0 +1,103 @@
+package main
+
+import "testing"
+
+func TestPopupShouldInit(t *testing.T) {
+	popup := new(Popup)
+	if err := popup.Init("Title", []string{"a", "b"}); err != nil {
+		t.Fail()
+	}
+
+	if popup.GetSelectedIndex() != 0 {
+		t.Fail()
+	}
+}
+
+func TestPopupShouldNotInitForInvalidTitle(t *testing.T) {
+	popup := new(Popup)
+	if err := popup.Init("", []string{"a"}); err == nil {
+		t.Fail()
+	}
+}
+
+func TestPopupShouldWrapSelection(t *testing.T) {
+	popup := new(Popup)
+	if err := popup.Init("Title", []string{"a", "b", "c"}); err != nil {
+		t.FailNow()
+	}
+
+	popup.SelectPrevious()
+	if popup.GetSelectedIndex() != 2 {
+		t.Fail()
+	}
+
+	popup.SelectNext()
+	if popup.GetSelectedIndex() != 0 {
+		t.Fail()
+	}
+
+	if err := popup.SetSelectedIndex(3); err == nil {
+		t.Fail()
+	}
+}
+
+func TestPopupShouldReturnNoSelectionForEmptyItems(t *testing.T) {
+	popup := new(Popup)
+	if err := popup.Init("Title", nil); err != nil {
+		t.FailNow()
+	}
+
+	popup.SelectNext()
+	if popup.GetSelectedIndex() != -1 {
+		t.Fail()
+	}
+
+	if start, end := popup.GetVisibleRange(5); start != 0 || end != 0 {
+		t.Fail()
+	}
+}
+
+func TestPopupShouldScrollToSelectedItem(t *testing.T) {
+	popup := new(Popup)
+	if err := popup.Init("Title", []string{"a", "b", "c", "d", "e"}); err != nil {
+		t.FailNow()
+	}
+
+	if err := popup.SetSelectedIndex(4); err != nil {
+		t.FailNow()
+	}
+
+	if start, end := popup.GetVisibleRange(2); start != 3 || end != 5 {
+		t.Fail()
+	}
+
+	popup.SelectNext()
+	if start, end := popup.GetVisibleRange(2); start != 0 || end != 2 {
+		t.Fail()
+	}
+
+	popup.SetItems([]string{"a"})
+	if start, end := popup.GetVisibleRange(2); start != 0 || end != 1 {
+		t.Fail()
+	}
+}
+
+func TestPopupShouldSetTitleAndPreview(t *testing.T) {
+	popup := new(Popup)
+	if err := popup.Init("Title", []string{"a"}); err != nil {
+		t.FailNow()
+	}
+
+	if err := popup.SetTitle("Other"); err != nil || popup.GetTitle() != "Other" {
+		t.Fail()
+	}
+
+	if err := popup.SetTitle(""); err == nil {
+		t.Fail()
+	}
+
+	popup.SetPreview([]string{"line"})
+	if len(popup.GetPreview()) != 1 || popup.GetPreview()[0] != "line" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Structure representing a text position requested by the user via the go-to-line prompt or the command line. The line and
+// column values are 1-based. The column is equal to zero if it was not specified
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Parse the position string in one of the following formats: [line], [line]:[column], +[lines], -[lines] or $ (last line).
+// The relative formats are calculated from the given current line. The line is validated against the given line count
+func ParsePosition(value string, currentLine int, lineCount int) (Position, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return Position{}, errors.New("position: empty position string")
+	}
+
+	lineValue, columnValue, hasColumn := strings.Cut(value, ":")
+
+	position := Position{}
+
+	switch {
+	case lineValue == "$":
+		position.Line = lineCount
+	case strings.HasPrefix(lineValue, "+") || strings.HasPrefix(lineValue, "-"):
+		{
+			lineShift, err := parsePositionNumber(lineValue[1:])
+			if err != nil {
+				return Position{}, err
+			}
+
+			if lineValue[0] == '-' {
+				lineShift = -lineShift
+			}
+
+			position.Line = currentLine + lineShift
+		}
+	default:
+		{
+			line, err := parsePositionNumber(lineValue)
+			if err != nil {
+				return Position{}, err
+			}
+
+			position.Line = line
+		}
+	}
+
+	if position.Line < 1 || position.Line > lineCount {
+		return Position{}, errors.New("position: line out of range")
+	}
+
+	if hasColumn {
+		column, err := parsePositionNumber(columnValue)
+		if err != nil {
+			return Position{}, err
+		}
+
+		if column < 1 {
+			return Position{}, errors.New("position: column out of range")
+		}
+
+		position.Column = column
+	}
+
+	return position, nil
+}
+
+// Helper function used to parse a non-negative decimal number of the position string
+func parsePositionNumber(value string) (int, error) {
+	if len(value) == 0 {
+		return 0, errors.New("position: missing number in the position string")
+	}
+
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return 0, errors.New("position: invalid number in the position string")
+		}
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("position: invalid number in the position string")
+	}
+
+	return number, nil
+}
+
+var pathPositionSuffix = regexp.MustCompile(`:(\d+(?::\d+)?)$`)
+
+// Split the file path argument in the [path]:[line] or [path]:[line]:[column] format into the path and the position string.
+// If the path has no position suffix, the position string is empty
+func SplitPathPosition(path string) (string, string) {
+	location := pathPositionSuffix.FindStringSubmatchIndex(path)
+	if location == nil || location[0] == 0 {
+		return path, ""
+	}
+
+	return path[:location[0]], path[location[2]:location[3]]
+}
@@ -0,0 +1,92 @@
+package main
+
+import "testing"
+
+func TestPositionShouldParseLine(t *testing.T) {
+	position, err := ParsePosition("120", 1, 200)
+	if err != nil {
+		t.Fail()
+	}
+
+	if position.Line != 120 || position.Column != 0 {
+		t.Fail()
+	}
+}
+
+func TestPositionShouldParseLineAndColumn(t *testing.T) {
+	position, err := ParsePosition("120:15", 1, 200)
+	if err != nil {
+		t.Fail()
+	}
+
+	if position.Line != 120 || position.Column != 15 {
+		t.Fail()
+	}
+}
+
+func TestPositionShouldParseRelativeLines(t *testing.T) {
+	position, err := ParsePosition("+10", 20, 200)
+	if err != nil || position.Line != 30 {
+		t.Fail()
+	}
+
+	position, err = ParsePosition("-10", 20, 200)
+	if err != nil || position.Line != 10 {
+		t.Fail()
+	}
+}
+
+func TestPositionShouldParseLastLine(t *testing.T) {
+	position, err := ParsePosition("$", 1, 200)
+	if err != nil || position.Line != 200 {
+		t.Fail()
+	}
+}
+
+func TestPositionShouldNotParseOutOfRangeLine(t *testing.T) {
+	if _, err := ParsePosition("201", 1, 200); err == nil {
+		t.Fail()
+	}
+
+	if _, err := ParsePosition("0", 1, 200); err == nil {
+		t.Fail()
+	}
+
+	if _, err := ParsePosition("-10", 5, 200); err == nil {
+		t.Fail()
+	}
+}
+
+func TestPositionShouldNotParseInvalidString(t *testing.T) {
+	invalidValues := []string{"", "abc", "12:", "12:x", ":5", "+", "1:0", "--1"}
+
+	for _, value := range invalidValues {
+		if _, err := ParsePosition(value, 1, 200); err == nil {
+			t.Fail()
+		}
+	}
+}
+
+func TestPositionShouldSplitPathWithPosition(t *testing.T) {
+	path, position := SplitPathPosition("file.txt:120:15")
+	if path != "file.txt" || position != "120:15" {
+		t.Fail()
+	}
+
+	path, position = SplitPathPosition("file.txt:120")
+	if path != "file.txt" || position != "120" {
+		t.Fail()
+	}
+}
+
+func TestPositionShouldNotSplitPathWithoutPosition(t *testing.T) {
+	path, position := SplitPathPosition("file.txt")
+	if path != "file.txt" || position != "" {
+		t.Fail()
+	}
+
+	path, position = SplitPathPosition("file:name.txt")
+	if path != "file:name.txt" || position != "" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import "errors"
+
+// Structure representing a single node of the line rope. Every node is holding one line and the count of lines in its subtree,
+// which is used to locate the lines by the index
+type lineRopeNode struct {
+	line     *Line
+	priority uint32
+	size     int
+	left     *lineRopeNode
+	right    *lineRopeNode
+}
+
+// Structure representing the sequence of text lines stored as a balanced tree (an implicit treap ordered by the line index). The
+// lines can be accessed, inserted and removed at any index in O(log n) time, so the edits of large texts are not shifting the
+// whole sequence of lines
+type LineRope struct {
+	root *lineRopeNode
+	seed uint32
+}
+
+// Line rope structure initialization function. The rope is built from the given lines in linear time
+func (rope *LineRope) Init(lines []*Line) error {
+	for _, line := range lines {
+		if line == nil {
+			return errors.New("rope: invalid line struct reference")
+		}
+	}
+
+	rope.seed = 0x9e3779b9
+	rope.root = rope.build(lines)
+
+	return nil
+}
+
+// Return the count of lines stored in the rope
+func (rope *LineRope) GetLength() int {
+	return rope.root.getSize()
+}
+
+// Return the line at the given index
+func (rope *LineRope) Get(index int) (*Line, error) {
+	if index < 0 || index >= rope.GetLength() {
+		return nil, errors.New("rope: invalid out of bound line index requested to get")
+	}
+
+	node := rope.root
+	for {
+		leftSize := node.left.getSize()
+
+		switch {
+		case index < leftSize:
+			node = node.left
+		case index == leftSize:
+			return node.line, nil
+		default:
+			index -= leftSize + 1
+			node = node.right
+		}
+	}
+}
+
+// Replace the lines between the start and end (both inclusive) indexes with the given lines. The end index lower than the start
+// index (by one) is inserting the lines at the start index without removing any line
+func (rope *LineRope) Replace(start int, end int, lines []*Line) error {
+	if start < 0 || start > rope.GetLength() || end < start-1 || end >= rope.GetLength() {
+		return errors.New("rope: invalid line indexes requested to replace")
+	}
+
+	for _, line := range lines {
+		if line == nil {
+			return errors.New("rope: invalid line struct reference")
+		}
+	}
+
+	head, rest := splitLineRope(rope.root, start)
+	_, tail := splitLineRope(rest, end-start+1)
+
+	rope.root = mergeLineRope(mergeLineRope(head, rope.build(lines)), tail)
+	return nil
+}
+
+// Insert the given line at the given index, the following lines are moved down
+func (rope *LineRope) Insert(index int, line *Line) error {
+	return rope.Replace(index, index-1, []*Line{line})
+}
+
+// Remove the line at the given index, the following lines are moved up
+func (rope *LineRope) Remove(index int) error {
+	if index < 0 || index >= rope.GetLength() {
+		return errors.New("rope: invalid out of bound line index requested to remove")
+	}
+
+	return rope.Replace(index, index, nil)
+}
+
+// Call the handler for every line starting at the given index in the order of the lines. The iteration is stopped if the handler
+// returns false
+func (rope *LineRope) ForEach(start int, handler func(index int, line *Line) bool) {
+	if start < 0 {
+		start = 0
+	}
+
+	// NOTE: The stack is holding the nodes whose line and right subtree are not visited yet
+	stack := make([]*lineRopeNode, 0, 64)
+	node := rope.root
+	skipped := start
+
+	for node != nil {
+		leftSize := node.left.getSize()
+
+		if skipped < leftSize {
+			stack = append(stack, node)
+			node = node.left
+		} else if skipped == leftSize {
+			stack = append(stack, node)
+			node = nil
+		} else {
+			skipped -= leftSize + 1
+			node = node.right
+		}
+	}
+
+	index := start
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !handler(index, node.line) {
+			return
+		}
+
+		index += 1
+
+		for child := node.right; child != nil; child = child.left {
+			stack = append(stack, child)
+		}
+	}
+}
+
+// Helper function used to build a balanced subtree of the given lines. The random priorities are heapified afterwards, so the
+// subtree is a valid treap
+func (rope *LineRope) build(lines []*Line) *lineRopeNode {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	middle := len(lines) / 2
+
+	node := &lineRopeNode{
+		line:     lines[middle],
+		priority: rope.nextPriority(),
+		left:     rope.build(lines[:middle]),
+		right:    rope.build(lines[middle+1:]),
+	}
+
+	node.update()
+	node.siftDown()
+
+	return node
+}
+
+// Helper function used to generate the pseudo-random node priority (xorshift)
+func (rope *LineRope) nextPriority() uint32 {
+	rope.seed ^= rope.seed << 13
+	rope.seed ^= rope.seed >> 17
+	rope.seed ^= rope.seed << 5
+
+	return rope.seed
+}
+
+// Helper function used to return the count of lines in the subtree, the empty subtree is represented by nil
+func (node *lineRopeNode) getSize() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.size
+}
+
+// Helper function used to recalculate the count of lines in the subtree after the children have changed
+func (node *lineRopeNode) update() {
+	node.size = node.left.getSize() + 1 + node.right.getSize()
+}
+
+// Helper function used to restore the heap order of the priorities by moving the priority of the node down. Only the priorities
+// are swapped, so the order of the lines and the shape of the subtree are not changed
+func (node *lineRopeNode) siftDown() {
+	for {
+		largest := node
+
+		if node.left != nil && node.left.priority > largest.priority {
+			largest = node.left
+		}
+
+		if node.right != nil && node.right.priority > largest.priority {
+			largest = node.right
+		}
+
+		if largest == node {
+			return
+		}
+
+		node.priority, largest.priority = largest.priority, node.priority
+		node = largest
+	}
+}
+
+// Helper function used to split the subtree into the subtree of the given count of first lines and the subtree of the remaining lines
+func splitLineRope(node *lineRopeNode, count int) (*lineRopeNode, *lineRopeNode) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if node.left.getSize() >= count {
+		left, right := splitLineRope(node.left, count)
+		node.left = right
+		node.update()
+
+		return left, node
+	}
+
+	left, right := splitLineRope(node.right, count-node.left.getSize()-1)
+	node.right = left
+	node.update()
+
+	return node, right
+}
+
+// Helper function used to join two subtrees, the lines of the left subtree are placed before the lines of the right subtree
+func mergeLineRope(left *lineRopeNode, right *lineRopeNode) *lineRopeNode {
+	if left == nil {
+		return right
+	}
+
+	if right == nil {
+		return left
+	}
+
+	if left.priority > right.priority {
+		left.right = mergeLineRope(left.right, right)
+		left.update()
+
+		return left
+	}
+
+	right.left = mergeLineRope(left, right.left)
+	right.update()
+
+	return right
+}
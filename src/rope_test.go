@@ -0,0 +1,239 @@
+package main
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestLineRopeShouldInitAndGetLines(t *testing.T) {
+	lines := createLineRopeTestLines(t, 100)
+
+	rope := new(LineRope)
+	if err := rope.Init(lines); err != nil {
+		t.FailNow()
+	}
+
+	if rope.GetLength() != 100 {
+		t.FailNow()
+	}
+
+	for index, expected := range lines {
+		line, err := rope.Get(index)
+		if err != nil || line != expected {
+			t.Fail()
+		}
+	}
+
+	if _, err := rope.Get(100); err == nil {
+		t.Fail()
+	}
+
+	if _, err := rope.Get(-1); err == nil {
+		t.Fail()
+	}
+}
+
+func TestLineRopeShouldNotInitForInvalidLines(t *testing.T) {
+	rope := new(LineRope)
+	if err := rope.Init([]*Line{nil}); err == nil {
+		t.Fail()
+	}
+}
+
+func TestLineRopeShouldReplaceLines(t *testing.T) {
+	lines := createLineRopeTestLines(t, 5)
+	replacement := createLineRopeTestLines(t, 3)
+
+	rope := new(LineRope)
+	if err := rope.Init(lines); err != nil {
+		t.FailNow()
+	}
+
+	if err := rope.Replace(1, 3, replacement[:1]); err != nil {
+		t.FailNow()
+	}
+
+	expected := []*Line{lines[0], replacement[0], lines[4]}
+	if !lineRopeEquals(rope, expected) {
+		t.Fail()
+	}
+
+	if err := rope.Insert(3, replacement[1]); err != nil {
+		t.FailNow()
+	}
+
+	if err := rope.Insert(0, replacement[2]); err != nil {
+		t.FailNow()
+	}
+
+	expected = []*Line{replacement[2], lines[0], replacement[0], lines[4], replacement[1]}
+	if !lineRopeEquals(rope, expected) {
+		t.Fail()
+	}
+
+	if err := rope.Remove(4); err != nil {
+		t.FailNow()
+	}
+
+	if err := rope.Remove(4); err == nil {
+		t.Fail()
+	}
+
+	if err := rope.Replace(2, 5, nil); err == nil {
+		t.Fail()
+	}
+
+	if !lineRopeEquals(rope, expected[:4]) {
+		t.Fail()
+	}
+}
+
+func TestLineRopeShouldIterateFromGivenIndex(t *testing.T) {
+	lines := createLineRopeTestLines(t, 50)
+
+	rope := new(LineRope)
+	if err := rope.Init(lines); err != nil {
+		t.FailNow()
+	}
+
+	visited := make([]*Line, 0)
+	rope.ForEach(20, func(index int, line *Line) bool {
+		if index != 20+len(visited) {
+			t.Fail()
+		}
+
+		visited = append(visited, line)
+		return index < 29
+	})
+
+	if len(visited) != 10 {
+		t.FailNow()
+	}
+
+	for index, line := range visited {
+		if line != lines[20+index] {
+			t.Fail()
+		}
+	}
+
+	rope.ForEach(50, func(index int, line *Line) bool {
+		t.Fail()
+		return true
+	})
+}
+
+func TestLineRopeShouldMatchSliceAfterRandomEdits(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	lines := createLineRopeTestLines(t, 200)
+
+	rope := new(LineRope)
+	if err := rope.Init(lines); err != nil {
+		t.FailNow()
+	}
+
+	reference := append([]*Line{}, lines...)
+
+	for step := 0; step < 2000; step += 1 {
+		start := random.Intn(len(reference) + 1)
+		end := start - 1
+		if start < len(reference) {
+			end = start - 1 + random.Intn(3)
+			if end >= len(reference) {
+				end = len(reference) - 1
+			}
+		}
+
+		replacement := createLineRopeTestLines(t, random.Intn(3))
+
+		if err := rope.Replace(start, end, replacement); err != nil {
+			t.FailNow()
+		}
+
+		reference = replaceLineSlice(reference, start, end, replacement)
+	}
+
+	if !lineRopeEquals(rope, reference) {
+		t.Fail()
+	}
+}
+
+func BenchmarkLineRopeInsertLine(b *testing.B) {
+	lines := createLineRopeTestLines(b, 500000)
+
+	rope := new(LineRope)
+	if err := rope.Init(lines); err != nil {
+		b.FailNow()
+	}
+
+	b.ResetTimer()
+	for index := 0; index < b.N; index += 1 {
+		if err := rope.Insert(rope.GetLength()/2, new(Line)); err != nil {
+			b.FailNow()
+		}
+	}
+}
+
+func BenchmarkLineSliceInsertLine(b *testing.B) {
+	lines := createLineRopeTestLines(b, 500000)
+
+	b.ResetTimer()
+	for index := 0; index < b.N; index += 1 {
+		lines = replaceLineSlice(lines, len(lines)/2, len(lines)/2-1, []*Line{new(Line)})
+	}
+}
+
+func BenchmarkLineRopeGet(b *testing.B) {
+	lines := createLineRopeTestLines(b, 500000)
+
+	rope := new(LineRope)
+	if err := rope.Init(lines); err != nil {
+		b.FailNow()
+	}
+
+	b.ResetTimer()
+	for index := 0; index < b.N; index += 1 {
+		if _, err := rope.Get(index % len(lines)); err != nil {
+			b.FailNow()
+		}
+	}
+}
+
+// NOTE: The lines were stored inside a slice before the line rope was introduced, the helper is reproducing the previous
+// implementation of replacing the lines to compare the results and the performance
+func replaceLineSlice(lines []*Line, start int, end int, replacement []*Line) []*Line {
+	updatedLines := make([]*Line, 0, len(lines)-(end-start+1)+len(replacement))
+	updatedLines = append(updatedLines, lines[:start]...)
+	updatedLines = append(updatedLines, replacement...)
+	updatedLines = append(updatedLines, lines[end+1:]...)
+
+	return updatedLines
+}
+
+func lineRopeEquals(rope *LineRope, expected []*Line) bool {
+	if rope.GetLength() != len(expected) {
+		return false
+	}
+
+	equals := true
+	rope.ForEach(0, func(index int, line *Line) bool {
+		equals = line == expected[index]
+		return equals
+	})
+
+	return equals
+}
+
+func createLineRopeTestLines(tb testing.TB, count int) []*Line {
+	lines := make([]*Line, 0, count)
+	for index := 0; index < count; index += 1 {
+		line := new(Line)
+		if err := line.Init("line " + strconv.Itoa(index)); err != nil {
+			tb.FailNow()
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// NOTE: The permissions of the newly created files before applying the umask (see getNewFileMode), the same as used by the
+// os.Create function. The mode is applied explicitly, because the temporary files are created with the 0600 permissions
+const saveNewFileMode os.FileMode = 0666
+
+// Write the content to the file atomically. The content is written to a temporary file placed in the same directory, flushed
+// to the storage and renamed over the target file, so the original file is left untouched if any step fails. The symbolic links
+// are followed, so the real target file is replaced, and the mode and owner (if permitted) of the original file are preserved
+func WriteFileAtomically(filePath string, content string) error {
+	targetPath, err := resolveSaveTargetPath(filePath)
+	if err != nil {
+		return err
+	}
+
+	fileMode := getNewFileMode()
+	targetInfo, err := os.Stat(targetPath)
+	if err == nil {
+		if !targetInfo.Mode().IsRegular() {
+			return errors.New("save: the target is not a regular file")
+		}
+
+		fileMode = targetInfo.Mode() & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	targetDirectory := filepath.Dir(targetPath)
+	tempFile, err := os.CreateTemp(targetDirectory, "."+filepath.Base(targetPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if err := writeTempFile(tempFile, content, fileMode, targetInfo); err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempFile.Name())
+		return err
+	}
+
+	if err := os.Rename(tempFile.Name(), targetPath); err != nil {
+		os.Remove(tempFile.Name())
+		return err
+	}
+
+	// NOTE: The file is already replaced, the failure only means that the rename may not survive a system crash
+	_ = syncDirectory(targetDirectory)
+
+	return nil
+}
+
+// Helper function used to write the content to the temporary file, apply the mode and owner of the original file (the info is
+// nil for a new file) and flush the file to the storage
+func writeTempFile(tempFile *os.File, content string, fileMode os.FileMode, targetInfo os.FileInfo) error {
+	if _, err := tempFile.WriteString(content); err != nil {
+		return err
+	}
+
+	// NOTE: Changing the owner requires privileges, so the temporary file owned by the current user is kept on failure. The
+	// owner is changed before the mode, because the change of the owner is clearing the setuid and setgid bits
+	if targetInfo != nil {
+		_ = preserveFileOwner(tempFile, targetInfo)
+	}
+
+	if err := tempFile.Chmod(fileMode); err != nil {
+		return err
+	}
+
+	return tempFile.Sync()
+}
+
+// Helper function used to resolve the path of the file, which should be replaced by the save. The symbolic links are followed,
+// including a link pointing to a not existing file, which is created
+func resolveSaveTargetPath(filePath string) (string, error) {
+	linkInfo, err := os.Lstat(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return filePath, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if linkInfo.Mode()&os.ModeSymlink == 0 {
+		return filePath, nil
+	}
+
+	targetPath, err := filepath.EvalSymlinks(filePath)
+	if err == nil {
+		return targetPath, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	// NOTE: The dangling link is resolved by a single step, the link target is created next to the link if it is relative
+	linkTarget, err := os.Readlink(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	if !filepath.IsAbs(linkTarget) {
+		linkTarget = filepath.Join(filepath.Dir(filePath), linkTarget)
+	}
+
+	return linkTarget, nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestWriteFileAtomicallyShouldCreateNewFile(t *testing.T) {
+	directoryPath := t.TempDir()
+	filePath := filepath.Join(directoryPath, "file.txt")
+
+	if err := WriteFileAtomically(filePath, "Hello"); err != nil {
+		t.FailNow()
+	}
+
+	fileData, err := os.ReadFile(filePath)
+	if err != nil || string(fileData) != "Hello" {
+		t.Fail()
+	}
+
+	if !saveTestDirectoryContains(t, directoryPath, "file.txt") {
+		t.Fail()
+	}
+}
+
+func TestWriteFileAtomicallyShouldApplyUmaskToNewFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the permission bits are not supported on Windows")
+	}
+
+	directoryPath := t.TempDir()
+
+	// NOTE: The mode of the file created by the os.Create function is the reference including the umask
+	referencePath := filepath.Join(directoryPath, "reference.txt")
+	referenceFile, err := os.Create(referencePath)
+	if err != nil {
+		t.FailNow()
+	}
+
+	referenceFile.Close()
+
+	filePath := filepath.Join(directoryPath, "file.txt")
+	if err := WriteFileAtomically(filePath, "Hello"); err != nil {
+		t.FailNow()
+	}
+
+	referenceInfo, err := os.Stat(referencePath)
+	if err != nil {
+		t.FailNow()
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		t.FailNow()
+	}
+
+	if fileInfo.Mode().Perm() != referenceInfo.Mode().Perm() || fileInfo.Mode().Perm() != getNewFileMode() {
+		t.Fail()
+	}
+}
+
+func TestWriteFileAtomicallyShouldPreserveFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the permission bits are not supported on Windows")
+	}
+
+	filePath := filepath.Join(t.TempDir(), "script.sh")
+	if err := os.WriteFile(filePath, []byte("echo"), 0700); err != nil {
+		t.FailNow()
+	}
+
+	if err := os.Chmod(filePath, 0750); err != nil {
+		t.FailNow()
+	}
+
+	if err := WriteFileAtomically(filePath, "echo Hello"); err != nil {
+		t.FailNow()
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		t.FailNow()
+	}
+
+	if fileInfo.Mode().Perm() != 0750 {
+		t.Fail()
+	}
+}
+
+func TestWriteFileAtomicallyShouldFollowSymbolicLink(t *testing.T) {
+	directoryPath := t.TempDir()
+	targetPath := filepath.Join(directoryPath, "target.txt")
+	linkPath := filepath.Join(directoryPath, "link.txt")
+
+	if err := os.WriteFile(targetPath, []byte("Hello"), 0644); err != nil {
+		t.FailNow()
+	}
+
+	if err := os.Symlink("target.txt", linkPath); err != nil {
+		t.Skip("the symbolic links are not supported")
+	}
+
+	if err := WriteFileAtomically(linkPath, "World"); err != nil {
+		t.FailNow()
+	}
+
+	linkInfo, err := os.Lstat(linkPath)
+	if err != nil || linkInfo.Mode()&os.ModeSymlink == 0 {
+		t.Fail()
+	}
+
+	fileData, err := os.ReadFile(targetPath)
+	if err != nil || string(fileData) != "World" {
+		t.Fail()
+	}
+}
+
+func TestWriteFileAtomicallyShouldCreateTargetOfDanglingSymbolicLink(t *testing.T) {
+	directoryPath := t.TempDir()
+	targetPath := filepath.Join(directoryPath, "target.txt")
+	linkPath := filepath.Join(directoryPath, "link.txt")
+
+	if err := os.Symlink("target.txt", linkPath); err != nil {
+		t.Skip("the symbolic links are not supported")
+	}
+
+	if err := WriteFileAtomically(linkPath, "Hello"); err != nil {
+		t.FailNow()
+	}
+
+	fileData, err := os.ReadFile(targetPath)
+	if err != nil || string(fileData) != "Hello" {
+		t.Fail()
+	}
+}
+
+func TestWriteFileAtomicallyShouldLeaveOriginalOnFailure(t *testing.T) {
+	directoryPath := t.TempDir()
+	filePath := filepath.Join(directoryPath, "file.txt")
+
+	if err := os.WriteFile(filePath, []byte("Hello"), 0644); err != nil {
+		t.FailNow()
+	}
+
+	if err := WriteFileAtomically(directoryPath, "World"); err == nil {
+		t.Fail()
+	}
+
+	if err := WriteFileAtomically(filepath.Join(filePath, "nested.txt"), "World"); err == nil {
+		t.Fail()
+	}
+
+	fileData, err := os.ReadFile(filePath)
+	if err != nil || string(fileData) != "Hello" {
+		t.Fail()
+	}
+
+	if !saveTestDirectoryContains(t, directoryPath, "file.txt") {
+		t.Fail()
+	}
+}
+
+func TestIsFileWritableShouldCheckFilePermissions(t *testing.T) {
+	filePath := createTestFile(t, "file.txt", "Hello")
+
+	if !isFileWritable(filePath) {
+		t.Fail()
+	}
+
+	if isFileWritable(filePath + ".missing") {
+		t.Fail()
+	}
+
+	if err := os.Chmod(filePath, 0444); err != nil {
+		t.FailNow()
+	}
+
+	// NOTE: The permissions are not restricting the privileged users
+	if runtime.GOOS != "windows" && os.Geteuid() == 0 {
+		return
+	}
+
+	if isFileWritable(filePath) {
+		t.Fail()
+	}
+}
+
+// NOTE: The helper is also verifying that no temporary files are left inside the directory
+func saveTestDirectoryContains(t *testing.T, directoryPath string, fileNames ...string) bool {
+	entries, err := os.ReadDir(directoryPath)
+	if err != nil {
+		t.FailNow()
+	}
+
+	if len(entries) != len(fileNames) {
+		return false
+	}
+
+	for index, entry := range entries {
+		if entry.Name() != fileNames[index] {
+			return false
+		}
+	}
+
+	return true
+}
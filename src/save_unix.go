@@ -0,0 +1,60 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// NOTE: The access(2) mode checking the write permission (W_OK), which is not exported by the syscall package
+const saveAccessWritable = 0x2
+
+// Helper function used to check if the current user has the write permission of the existing file. The file is not opened, so
+// the access time and the file watchers are not affected
+func isFileWritable(filePath string) bool {
+	return syscall.Access(filePath, saveAccessWritable) == nil
+}
+
+// Helper function used to return the permissions of the newly created file with the umask of the process applied
+func getNewFileMode() os.FileMode {
+	// NOTE: The umask can only be read by replacing it, so the original umask is restored immediately
+	umask := syscall.Umask(0)
+	syscall.Umask(umask)
+
+	return saveNewFileMode &^ os.FileMode(umask)
+}
+
+// Helper function used to apply the owner and group of the original file to the given file. The group is applied alone if
+// the owner can not be changed (e.g. the user is a member of the file group)
+func preserveFileOwner(file *os.File, info os.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	if int(stat.Uid) == os.Getuid() && int(stat.Gid) == os.Getgid() {
+		return nil
+	}
+
+	if err := file.Chown(int(stat.Uid), int(stat.Gid)); err == nil {
+		return nil
+	}
+
+	return file.Chown(-1, int(stat.Gid))
+}
+
+// Helper function used to flush the directory entries to the storage, so the renamed file survives a system crash
+func syncDirectory(directoryPath string) error {
+	directory, err := os.Open(directoryPath)
+	if err != nil {
+		return err
+	}
+
+	if err := directory.Sync(); err != nil {
+		directory.Close()
+		return err
+	}
+
+	return directory.Close()
+}
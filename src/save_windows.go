@@ -0,0 +1,32 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// Helper function used to check if the existing file is writable. The permissions are not checked on Windows, only the
+// read-only attribute of the file (presented as the missing owner write permission)
+func isFileWritable(filePath string) bool {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return false
+	}
+
+	return info.Mode().Perm()&0200 != 0
+}
+
+// Helper function used to return the permissions of the newly created file. There is no umask on Windows
+func getNewFileMode() os.FileMode {
+	return saveNewFileMode
+}
+
+// Helper function used to apply the owner of the original file. The ownership is not changed on Windows, the file inherits
+// the permissions of the directory
+func preserveFileOwner(file *os.File, info os.FileInfo) error {
+	return nil
+}
+
+// Helper function used to flush the directory entries. The directories can not be synced on Windows
+func syncDirectory(directoryPath string) error {
+	return nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"sort"
+)
+
+// Structure representing the options applied while searching the text
+type SearchOptions struct {
+	CaseSensitive     bool
+	WholeWord         bool
+	RegularExpression bool
+}
+
+// Structure representing a single occurrence of the searched pattern inside the text
+type SearchMatch struct {
+	XOffset int
+	YOffset int
+	Length  int
+}
+
+// Structure representing the state of the text search. It stores the pattern, options and the matches (sorted in text order)
+type Search struct {
+	pattern string
+	options SearchOptions
+	matches []SearchMatch
+	current int
+	text    *Text
+}
+
+// Search structure initialization function
+func (search *Search) Init(text *Text) error {
+	if text == nil {
+		return errors.New("search: invalid text struct reference")
+	}
+
+	search.text = text
+	search.pattern = ""
+	search.options = SearchOptions{
+		CaseSensitive:     false,
+		WholeWord:         false,
+		RegularExpression: false,
+	}
+
+	search.Clear()
+	return nil
+}
+
+// Set the pattern and options and find all matches inside the text. The current match selection is dropped
+func (search *Search) Update(pattern string, options SearchOptions) error {
+	search.pattern = pattern
+	search.options = options
+	search.current = -1
+
+	if len(pattern) == 0 {
+		search.matches = nil
+		return nil
+	}
+
+	matches, err := search.text.FindMatches(pattern, options)
+	if err != nil {
+		search.matches = nil
+		return err
+	}
+
+	search.matches = matches
+	return nil
+}
+
+// Drop all matches. The pattern and options are preserved, so the search can be repeated with Update
+func (search *Search) Clear() {
+	search.matches = nil
+	search.current = -1
+}
+
+// Return the latest searched pattern
+func (search *Search) GetPattern() string {
+	return search.pattern
+}
+
+// Return the latest search options
+func (search *Search) GetOptions() SearchOptions {
+	return search.options
+}
+
+// Return the count of matches
+func (search *Search) GetMatchCount() int {
+	return len(search.matches)
+}
+
+// Return the index of the currently selected match or -1 if no match is selected
+func (search *Search) GetCurrentMatchIndex() int {
+	return search.current
+}
+
+// Return the currently selected match
+func (search *Search) GetCurrentMatch() (SearchMatch, error) {
+	if search.current < 0 || search.current >= len(search.matches) {
+		return SearchMatch{}, errors.New("search: no match is currently selected")
+	}
+
+	return search.matches[search.current], nil
+}
+
+// Select the first match placed after the given offsets. If inclusive, a match starting at the offsets is also accepted.
+// The search is wrapping around to the start of the text. The function returns a bool value indicating if a match was selected
+func (search *Search) SelectNext(xOffset int, yOffset int, inclusive bool) bool {
+	if len(search.matches) == 0 {
+		return false
+	}
+
+	index := sort.Search(len(search.matches), func(index int) bool {
+		match := search.matches[index]
+		if match.YOffset != yOffset {
+			return match.YOffset > yOffset
+		}
+
+		if inclusive {
+			return match.XOffset >= xOffset
+		}
+
+		return match.XOffset > xOffset
+	})
+
+	if index == len(search.matches) {
+		index = 0
+	}
+
+	search.current = index
+	return true
+}
+
+// Return at most the given count of matches starting with the current match and wrapping around to the start of the text. An
+// empty slice is returned if no match is selected
+func (search *Search) GetMatchesFromCurrent(count int) []SearchMatch {
+	matches := make([]SearchMatch, 0)
+	if search.current < 0 || search.current >= len(search.matches) {
+		return matches
+	}
+
+	for index := 0; index < count && index < len(search.matches); index += 1 {
+		matches = append(matches, search.matches[(search.current+index)%len(search.matches)])
+	}
+
+	return matches
+}
+
+// Select the last match placed before the given offsets. The search is wrapping around to the end of the text.
+// The function returns a bool value indicating if a match was selected
+func (search *Search) SelectPrevious(xOffset int, yOffset int) bool {
+	if len(search.matches) == 0 {
+		return false
+	}
+
+	index := sort.Search(len(search.matches), func(index int) bool {
+		match := search.matches[index]
+		if match.YOffset != yOffset {
+			return match.YOffset > yOffset
+		}
+
+		return match.XOffset >= xOffset
+	}) - 1
+
+	if index < 0 {
+		index = len(search.matches) - 1
+	}
+
+	search.current = index
+	return true
+}
+
+// Return two bool values indicating if the character at the given offsets is part of any match and if it is part of the current match
+func (search *Search) MatchAt(xOffset int, yOffset int) (bool, bool) {
+	if len(search.matches) == 0 {
+		return false, false
+	}
+
+	// NOTE: Finding the first match which is ending after the given offsets
+	index := sort.Search(len(search.matches), func(index int) bool {
+		match := search.matches[index]
+		if match.YOffset != yOffset {
+			return match.YOffset > yOffset
+		}
+
+		return match.XOffset+match.Length > xOffset
+	})
+
+	if index == len(search.matches) {
+		return false, false
+	}
+
+	match := search.matches[index]
+	if match.YOffset != yOffset || match.XOffset > xOffset {
+		return false, false
+	}
+
+	return true, index == search.current
+}
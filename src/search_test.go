@@ -0,0 +1,188 @@
+package main
+
+import "testing"
+
+func TestSearchShouldInitializeForValidText(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(GetSearchTestTextMockup()); err != nil {
+		t.Fail()
+	}
+}
+
+func TestSearchShouldNotInitializeForInvalidText(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(nil); err == nil {
+		t.Fail()
+	}
+}
+
+func TestSearchShouldFindAllMatches(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(GetSearchTestTextMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := search.Update("line", SearchOptions{}); err != nil {
+		t.Fail()
+	}
+
+	if search.GetMatchCount() != 4 {
+		t.Fail()
+	}
+
+	if search.GetCurrentMatchIndex() != -1 {
+		t.Fail()
+	}
+
+	if _, err := search.GetCurrentMatch(); err == nil {
+		t.Fail()
+	}
+}
+
+func TestSearchShouldSelectNextMatchWithWrapping(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(GetSearchTestTextMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := search.Update("line", SearchOptions{}); err != nil {
+		t.Fail()
+	}
+
+	if !search.SelectNext(6, 0, true) || search.GetCurrentMatchIndex() != 0 {
+		t.Fail()
+	}
+
+	if !search.SelectNext(6, 0, false) || search.GetCurrentMatchIndex() != 1 {
+		t.Fail()
+	}
+
+	if !search.SelectNext(0, 3, false) || search.GetCurrentMatchIndex() != 0 {
+		t.Fail()
+	}
+
+	match, err := search.GetCurrentMatch()
+	if err != nil {
+		t.Fail()
+	}
+
+	if match.XOffset != 6 || match.YOffset != 0 || match.Length != 4 {
+		t.Fail()
+	}
+}
+
+func TestSearchShouldReturnMatchesFromCurrentWithWrapping(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(GetSearchTestTextMockup()); err != nil {
+		t.FailNow()
+	}
+
+	if err := search.Update("line", SearchOptions{}); err != nil {
+		t.FailNow()
+	}
+
+	if len(search.GetMatchesFromCurrent(10)) != 0 {
+		t.Fail()
+	}
+
+	if !search.SelectNext(6, 0, false) {
+		t.FailNow()
+	}
+
+	matches := search.GetMatchesFromCurrent(10)
+	if len(matches) != search.GetMatchCount() {
+		t.FailNow()
+	}
+
+	first, err := search.GetCurrentMatch()
+	if err != nil || matches[0] != first {
+		t.Fail()
+	}
+
+	if last := matches[len(matches)-1]; last.XOffset != 6 || last.YOffset != 0 {
+		t.Fail()
+	}
+
+	if len(search.GetMatchesFromCurrent(1)) != 1 {
+		t.Fail()
+	}
+}
+
+func TestSearchShouldSelectPreviousMatchWithWrapping(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(GetSearchTestTextMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := search.Update("line", SearchOptions{}); err != nil {
+		t.Fail()
+	}
+
+	if !search.SelectPrevious(8, 1) || search.GetCurrentMatchIndex() != 1 {
+		t.Fail()
+	}
+
+	if !search.SelectPrevious(6, 0) || search.GetCurrentMatchIndex() != 3 {
+		t.Fail()
+	}
+}
+
+func TestSearchShouldNotSelectWithoutMatches(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(GetSearchTestTextMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := search.Update("missing", SearchOptions{}); err != nil {
+		t.Fail()
+	}
+
+	if search.SelectNext(0, 0, true) || search.SelectPrevious(0, 0) {
+		t.Fail()
+	}
+}
+
+func TestSearchShouldIndicateMatchedCharacters(t *testing.T) {
+	search := new(Search)
+	if err := search.Init(GetSearchTestTextMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := search.Update("line", SearchOptions{}); err != nil {
+		t.Fail()
+	}
+
+	search.SelectNext(0, 1, true)
+
+	if isMatch, isCurrent := search.MatchAt(7, 1); !isMatch || !isCurrent {
+		t.Fail()
+	}
+
+	if isMatch, isCurrent := search.MatchAt(9, 0); !isMatch || isCurrent {
+		t.Fail()
+	}
+
+	if isMatch, _ := search.MatchAt(5, 0); isMatch {
+		t.Fail()
+	}
+
+	search.Clear()
+
+	if isMatch, _ := search.MatchAt(7, 1); isMatch {
+		t.Fail()
+	}
+
+	if search.GetPattern() != "line" {
+		t.Fail()
+	}
+}
+
+// Test helper function which is creating a text mockup
+func GetSearchTestTextMockup() *Text {
+	text := new(Text)
+	if err := text.Init("First line\nSecond line\nThird line, last line", false, nil); err != nil {
+		panic(err)
+	}
+
+	return text
+}
@@ -0,0 +1,85 @@
+package main
+
+import "errors"
+
+// Structure representing the text selection. The selection is spanning between the anchor position and the current cursor position
+type Selection struct {
+	xAnchor int
+	yAnchor int
+	started bool
+	cursor  *Cursor
+}
+
+// Selection structure initialization function
+func (selection *Selection) Init(cursor *Cursor) error {
+	if cursor == nil {
+		return errors.New("selection: invalid cursor struct reference")
+	}
+
+	selection.cursor = cursor
+	selection.xAnchor = 0
+	selection.yAnchor = 0
+	selection.started = false
+
+	return nil
+}
+
+// Start the selection by placing the anchor at the current cursor position. The anchor is not moved if the selection is already started
+func (selection *Selection) Start() {
+	if selection.started {
+		return
+	}
+
+	selection.xAnchor = selection.cursor.GetOffsetX()
+	selection.yAnchor = selection.cursor.GetOffsetY()
+	selection.started = true
+}
+
+// Drop the current selection
+func (selection *Selection) Clear() {
+	selection.started = false
+}
+
+// Return a bool value indicating if the selection is started and is not empty
+func (selection *Selection) IsActive() bool {
+	if !selection.started {
+		return false
+	}
+
+	return selection.xAnchor != selection.cursor.GetOffsetX() || selection.yAnchor != selection.cursor.GetOffsetY()
+}
+
+// Return the x (horizontal) and y (vertical) offsets of the selection start (inclusive) and end (exclusive) in text order
+func (selection *Selection) GetRange() (int, int, int, int) {
+	xCursor := selection.cursor.GetOffsetX()
+	yCursor := selection.cursor.GetOffsetY()
+
+	if selection.yAnchor < yCursor || (selection.yAnchor == yCursor && selection.xAnchor < xCursor) {
+		return selection.xAnchor, selection.yAnchor, xCursor, yCursor
+	}
+
+	return xCursor, yCursor, selection.xAnchor, selection.yAnchor
+}
+
+// Return a bool value indicating if the character at the given offsets is selected
+func (selection *Selection) Contains(xOffset int, yOffset int) bool {
+	if !selection.IsActive() {
+		return false
+	}
+
+	xStart, yStart, xEnd, yEnd := selection.GetRange()
+
+	if yOffset < yStart || yOffset > yEnd {
+		return false
+	}
+
+	if yOffset == yStart && xOffset < xStart {
+		return false
+	}
+
+	if yOffset == yEnd && xOffset >= xEnd {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,103 @@
+package main
+
+import "testing"
+
+func TestSelectionShouldInitializeForValidCursor(t *testing.T) {
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	selection := new(Selection)
+	if err := selection.Init(cursor); err != nil {
+		t.Fail()
+	}
+}
+
+func TestSelectionShouldNotInitializeForInvalidCursor(t *testing.T) {
+	selection := new(Selection)
+	if err := selection.Init(nil); err == nil {
+		t.Fail()
+	}
+}
+
+func TestSelectionShouldNotBeActiveWhenEmpty(t *testing.T) {
+	cursor := new(Cursor)
+	if err := cursor.Init(2, 3, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	selection := new(Selection)
+	if err := selection.Init(cursor); err != nil {
+		t.Fail()
+	}
+
+	if selection.IsActive() {
+		t.Fail()
+	}
+
+	selection.Start()
+
+	if selection.IsActive() {
+		t.Fail()
+	}
+}
+
+func TestSelectionShouldReturnOrderedRange(t *testing.T) {
+	cursor := new(Cursor)
+	if err := cursor.Init(4, 2, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	selection := new(Selection)
+	if err := selection.Init(cursor); err != nil {
+		t.Fail()
+	}
+
+	selection.Start()
+
+	if err := cursor.SetOffsets(1, 0); err != nil {
+		t.Fail()
+	}
+
+	if !selection.IsActive() {
+		t.Fail()
+	}
+
+	xStart, yStart, xEnd, yEnd := selection.GetRange()
+	if xStart != 1 || yStart != 0 || xEnd != 4 || yEnd != 2 {
+		t.Fail()
+	}
+}
+
+func TestSelectionShouldIndicateContainedCharacters(t *testing.T) {
+	cursor := new(Cursor)
+	if err := cursor.Init(3, 1, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	selection := new(Selection)
+	if err := selection.Init(cursor); err != nil {
+		t.Fail()
+	}
+
+	selection.Start()
+
+	if err := cursor.SetOffsets(2, 3); err != nil {
+		t.Fail()
+	}
+
+	if !selection.Contains(3, 1) || !selection.Contains(0, 2) || !selection.Contains(1, 3) {
+		t.Fail()
+	}
+
+	if selection.Contains(2, 1) || selection.Contains(2, 3) || selection.Contains(0, 4) {
+		t.Fail()
+	}
+
+	selection.Clear()
+
+	if selection.Contains(0, 2) {
+		t.Fail()
+	}
+}
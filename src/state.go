@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	stateFileName        = "termpad-state.json"
+	stateDirectoryName   = "termpad"
+	stateRecentFileLimit = 10
+)
+
+// Structure representing the program state persisted between the program runs inside the termpad-state.json file (e.g. the
+// recently opened files). Unlike the configuration, the state is modified by the program itself, so the file is stored inside
+// the user configuration directory instead of the working directory (see GetStateFilePath)
+type State struct {
+	RecentFiles []string `json:"recent-files"`
+	filePath    string
+}
+
+// State structure initialization function. The state is retrieved from the given file, an empty state is used if the file
+// does not exist. The file is not created until the state is saved. If the file can not be read or is corrupted, the error is
+// returned, but the state is still initialized as empty, so the caller can continue using it
+func (state *State) Init(filePath string) error {
+	state.filePath = filePath
+	state.RecentFiles = make([]string, 0)
+
+	if len(filePath) <= 0 {
+		return errors.New("state: invalid state file path")
+	}
+
+	stateFileData, err := os.ReadFile(state.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return errors.New("state: can not determine if the state file is accesable")
+	}
+
+	if err := json.Unmarshal(stateFileData, state); err != nil {
+		state.RecentFiles = make([]string, 0)
+		return fmt.Errorf("state: the state file %s is corrupted (%s)", state.filePath, err)
+	}
+
+	if state.RecentFiles == nil {
+		state.RecentFiles = make([]string, 0)
+	}
+
+	return nil
+}
+
+// Return the path of the state file inside the termpad directory of the user configuration directory (e.g. ~/.config/termpad
+// on Linux or %AppData%\termpad on Windows)
+func GetStateFilePath() (string, error) {
+	configDirectory, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDirectory, stateDirectoryName, stateFileName), nil
+}
+
+// Return the recently opened files, starting with the latest one
+func (state *State) GetRecentFiles() []string {
+	return state.RecentFiles
+}
+
+// Add the given file to the recently opened files. The path is stored as an absolute path and the previous occurrence of the
+// file is removed. Only the latest files are kept
+func (state *State) AddRecentFile(path string) error {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	recentFiles := make([]string, 0, len(state.RecentFiles)+1)
+	recentFiles = append(recentFiles, absolutePath)
+
+	for _, recentFile := range state.RecentFiles {
+		if recentFile != absolutePath && len(recentFiles) < stateRecentFileLimit {
+			recentFiles = append(recentFiles, recentFile)
+		}
+	}
+
+	state.RecentFiles = recentFiles
+	return nil
+}
+
+// Write the state to the state file. The file and the parent directory are created if needed, the existing file is truncated
+func (state *State) Save() error {
+	if len(state.filePath) <= 0 {
+		return errors.New("state: invalid state file path")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(state.filePath), 0755); err != nil {
+		return err
+	}
+
+	jsonState, err := json.MarshalIndent(state, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(state.filePath, jsonState, 0644)
+}
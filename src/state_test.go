@@ -0,0 +1,131 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStateShouldInitWithoutStateFile(t *testing.T) {
+	state := new(State)
+	if err := state.Init(filepath.Join(t.TempDir(), "state.json")); err != nil {
+		t.FailNow()
+	}
+
+	if len(state.GetRecentFiles()) != 0 {
+		t.Fail()
+	}
+}
+
+func TestStateShouldNotInitForInvalidPath(t *testing.T) {
+	state := new(State)
+	if err := state.Init(""); err == nil {
+		t.Fail()
+	}
+}
+
+func TestStateShouldAddRecentFilesWithoutDuplicates(t *testing.T) {
+	directory := t.TempDir()
+
+	state := new(State)
+	if err := state.Init(filepath.Join(directory, "state.json")); err != nil {
+		t.FailNow()
+	}
+
+	firstPath := filepath.Join(directory, "first.txt")
+	secondPath := filepath.Join(directory, "second.txt")
+
+	for _, path := range []string{firstPath, secondPath, firstPath} {
+		if err := state.AddRecentFile(path); err != nil {
+			t.FailNow()
+		}
+	}
+
+	recentFiles := state.GetRecentFiles()
+	if len(recentFiles) != 2 || recentFiles[0] != firstPath || recentFiles[1] != secondPath {
+		t.Fail()
+	}
+}
+
+func TestStateShouldLimitRecentFiles(t *testing.T) {
+	directory := t.TempDir()
+
+	state := new(State)
+	if err := state.Init(filepath.Join(directory, "state.json")); err != nil {
+		t.FailNow()
+	}
+
+	for index := 0; index < stateRecentFileLimit+5; index += 1 {
+		if err := state.AddRecentFile(filepath.Join(directory, string(rune('a'+index)))); err != nil {
+			t.FailNow()
+		}
+	}
+
+	if len(state.GetRecentFiles()) != stateRecentFileLimit {
+		t.Fail()
+	}
+}
+
+func TestStateShouldSaveAndLoadRecentFiles(t *testing.T) {
+	directory := t.TempDir()
+	statePath := filepath.Join(directory, "state.json")
+
+	state := new(State)
+	if err := state.Init(statePath); err != nil {
+		t.FailNow()
+	}
+
+	filePath := filepath.Join(directory, "file.txt")
+	if err := state.AddRecentFile(filePath); err != nil {
+		t.FailNow()
+	}
+
+	if err := state.Save(); err != nil {
+		t.FailNow()
+	}
+
+	loadedState := new(State)
+	if err := loadedState.Init(statePath); err != nil {
+		t.FailNow()
+	}
+
+	recentFiles := loadedState.GetRecentFiles()
+	if len(recentFiles) != 1 || recentFiles[0] != filePath {
+		t.Fail()
+	}
+}
+
+func TestStateShouldInitEmptyForCorruptedStateFile(t *testing.T) {
+	statePath := createTestFile(t, "state.json", "{\"recent-files\": [\"file.txt\"")
+
+	state := new(State)
+	if err := state.Init(statePath); err == nil {
+		t.Fail()
+	}
+
+	if state.GetRecentFiles() == nil || len(state.GetRecentFiles()) != 0 {
+		t.Fail()
+	}
+}
+
+func TestStateShouldCreateStateDirectoryOnSave(t *testing.T) {
+	directory := t.TempDir()
+	statePath := filepath.Join(directory, stateDirectoryName, stateFileName)
+
+	state := new(State)
+	if err := state.Init(statePath); err != nil {
+		t.FailNow()
+	}
+
+	if err := state.AddRecentFile(filepath.Join(directory, "file.txt")); err != nil {
+		t.FailNow()
+	}
+
+	if err := state.Save(); err != nil {
+		t.FailNow()
+	}
+
+	loadedState := new(State)
+	if err := loadedState.Init(statePath); err != nil || len(loadedState.GetRecentFiles()) != 1 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,30 @@
+//go:build !windows
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Helper function used to stop the process (and its process group) with the SIGTSTP signal, like the shell job control does.
+// The function is blocking until the process is continued with the SIGCONT signal
+func suspendProcess() error {
+	// NOTE: The ignored signal would not stop the process and the function would wait for the SIGCONT signal forever
+	if signal.Ignored(syscall.SIGTSTP) {
+		return errors.New("console: the SIGTSTP signal is ignored by the process")
+	}
+
+	continued := make(chan os.Signal, 1)
+	signal.Notify(continued, syscall.SIGCONT)
+	defer signal.Stop(continued)
+
+	if err := syscall.Kill(0, syscall.SIGTSTP); err != nil {
+		return err
+	}
+
+	<-continued
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+// Helper function used to stop the process. The job control signals are not available on Windows
+func suspendProcess() error {
+	return ErrSuspendNotSupported
+}
@@ -2,18 +2,34 @@ package main
 
 import (
 	"errors"
+	"regexp"
 	"runtime"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-// TODO: Implement function that will insert multiple characters as array. This function
-// would be very usefull for implementing clipboard pasting.
+// NOTE: The count of the latest changes remembered to report the first changed line (see GetFirstChangedOffset)
+const textChangeLimit = 64
 
-// A structure representing the text, which is a container for the List structures
+// Structure representing a single change of the text, the revision of the text after the change, the y (vertical) offset
+// of the first line affected by the change and the count of lines inserted (positive) or removed (negative) by the change
+type textChange struct {
+	revision  int
+	yOffset   int
+	lineDelta int
+}
+
+// A structure representing the text, which is a container for the Line structures. The lines are stored inside the line rope,
+// so the lines can be accessed, inserted and removed without shifting all following lines
 type Text struct {
-	lines             []*Line
+	lines             *LineRope
 	modified          bool
+	revision          int
+	changes           []textChange
+	lineCount         int
 	endOfLineSequence string
+	history           *History
 	config            *TextConfig
 }
 
@@ -26,11 +42,8 @@ func (text *Text) Init(textString string, newFile bool, textConfig *TextConfig)
 		text.config = textConfig
 	}
 
-	if strings.Contains(textString, "\r\n") {
-		text.endOfLineSequence = "CRLF"
-	} else {
-		text.endOfLineSequence = "LF"
-	}
+	text.endOfLineSequence = "LF"
+	text.DetectEndOfLineSequence(textString)
 
 	// NOTE: Removing the 0x0D CR (Carriage Return)
 	textString = strings.Replace(textString, "\r", "", -1)
@@ -40,33 +53,28 @@ func (text *Text) Init(textString string, newFile bool, textConfig *TextConfig)
 
 	text.modified = newFile
 
-	if len(textStringLines) == 0 {
-		line := new(Line)
-		if err := line.Init(""); err != nil {
-			return err
-		}
-
-		text.lines = make([]*Line, 1)
-		text.lines[0] = line
-		return nil
-	}
-
-	text.lines = make([]*Line, len(textStringLines))
+	lines := make([]*Line, len(textStringLines))
 	for textStringLineIndex, textStringLineValue := range textStringLines {
 		line := new(Line)
 		if err := line.Init(textStringLineValue); err != nil {
 			return err
 		}
 
-		text.lines[textStringLineIndex] = line
+		lines[textStringLineIndex] = line
 	}
 
+	text.lines = new(LineRope)
+	if err := text.lines.Init(lines); err != nil {
+		return err
+	}
+
+	text.lineCount = text.lines.GetLength()
 	return nil
 }
 
 // Return the count of lines (height)
 func (text *Text) GetLineCount() int {
-	return len(text.lines)
+	return text.lines.GetLength()
 }
 
 // Return the length of the line based on given y (vertical) offset
@@ -75,14 +83,36 @@ func (text *Text) GetLineLengthByOffset(yOffset int) (int, error) {
 		return 0, errors.New("text: invalid y (vertical) negative offset requested to get")
 	}
 
-	if yOffset >= len(text.lines) {
+	if yOffset >= text.lines.GetLength() {
 		return 0, errors.New("text: invalid y (vertical) out of bound offset requested to get")
 	}
 
-	targetLine := text.lines[yOffset]
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return 0, err
+	}
+
 	return targetLine.GetBufferLength(), nil
 }
 
+// Return the characters of the line based on given y (vertical) offset. The returned slice should not be modified
+func (text *Text) GetLineBufferByOffset(yOffset int) ([]rune, error) {
+	if yOffset < 0 {
+		return nil, errors.New("text: invalid y (vertical) negative offset requested to get")
+	}
+
+	if yOffset >= text.lines.GetLength() {
+		return nil, errors.New("text: invalid y (vertical) out of bound offset requested to get")
+	}
+
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	return targetLine.GetBufferAsSlice(), nil
+}
+
 // Return the length of the line based on given cursor position
 func (text *Text) GetLineLengthByCursor(cursor *Cursor) (int, error) {
 	return text.GetLineLengthByOffset(cursor.GetOffsetY())
@@ -96,14 +126,22 @@ func (text *Text) InsertCharacter(char rune, cursor *Cursor) error {
 		return errors.New("text: invalid y (vertical) negative offset requested to insert")
 	}
 
-	if yOffset > len(text.lines) {
+	if yOffset >= text.lines.GetLength() {
 		return errors.New("text: invalid y (vertical) out of bound offset requested to insert")
 	}
 
-	text.modified = true
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return err
+	}
+
+	if err := targetLine.InsertBufferCharacter(char, cursor); err != nil {
+		return err
+	}
+
+	text.markModified(yOffset)
 
-	targetLine := text.lines[yOffset]
-	return targetLine.InsertBufferCharacter(char, cursor)
+	return text.recordEdit(HistoryEditInsert, cursor.GetOffsetX(), yOffset, []rune{char})
 }
 
 // Remove a character at specific line at specific position before the position given by the offset of the given cursor
@@ -114,14 +152,29 @@ func (text *Text) RemoveCharacterHead(cursor *Cursor) error {
 		return errors.New("text: invalid y (vertical) negative offset requested to remove")
 	}
 
-	if yOffset > len(text.lines) {
+	if yOffset >= text.lines.GetLength() {
 		return errors.New("text: invalid y (vertical) out of bound offset requested to remove")
 	}
 
-	text.modified = true
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return err
+	}
 
-	targetLine := text.lines[yOffset]
-	return targetLine.RemoveBufferCharacterHead(cursor)
+	xOffset := cursor.GetOffsetX()
+
+	char, err := targetLine.GetBufferCharacterByOffset(xOffset - 1)
+	if err != nil {
+		return err
+	}
+
+	if err := targetLine.RemoveBufferCharacterHead(cursor); err != nil {
+		return err
+	}
+
+	text.markModified(yOffset)
+
+	return text.recordEdit(HistoryEditRemove, xOffset-1, yOffset, []rune{char})
 }
 
 // Remove a character at specific line at specific position behind the position given by the offset of the given cursor
@@ -132,14 +185,29 @@ func (text *Text) RemoveCharacterTail(cursor *Cursor) error {
 		return errors.New("text: invalid y (vertical) negative offset requested to remove")
 	}
 
-	if yOffset > len(text.lines) {
+	if yOffset >= text.lines.GetLength() {
 		return errors.New("text: invalid y (vertical) out of bound offset requested to remove")
 	}
 
-	text.modified = true
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return err
+	}
+
+	xOffset := cursor.GetOffsetX()
+
+	char, err := targetLine.GetBufferCharacterByOffset(xOffset)
+	if err != nil {
+		return err
+	}
+
+	if err := targetLine.RemoveBufferCharacterTail(cursor); err != nil {
+		return err
+	}
 
-	targetLine := text.lines[yOffset]
-	return targetLine.RemoveBufferCharacterTail(cursor)
+	text.markModified(yOffset)
+
+	return text.recordEdit(HistoryEditRemove, xOffset, yOffset, []rune{char})
 }
 
 // Handle line inserting and line breaking
@@ -151,43 +219,47 @@ func (text *Text) InsertLine(cursor *Cursor) error {
 		return errors.New("text: invalid y (vertical) negative offset requested to split")
 	}
 
-	if yOffset > len(text.lines) {
+	if yOffset >= text.lines.GetLength() {
 		return errors.New("text: invalid y (vertical) out of bound offset requested to split")
 	}
 
-	text.modified = true
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return err
+	}
 
-	targetLine := text.lines[yOffset]
+	if xOffset < 0 || xOffset > targetLine.GetBufferLength() {
+		return errors.New("text: invalid x (horizontal) offset requested to split")
+	}
 
-	// NOTE: Breaking the line at the start of the line
-	if xOffset == 0 {
-		line := new(Line)
-		if err := line.Init(""); err != nil {
-			return err
-		}
+	if err := text.splitLine(xOffset, yOffset); err != nil {
+		return err
+	}
 
-		if err := text.appendLinesAtIndex(line, yOffset); err != nil {
-			return err
-		}
+	text.markModified(yOffset)
 
-		return nil
+	return text.recordEdit(HistoryEditInsert, xOffset, yOffset, []rune{'\n'})
+}
+
+// Helper function used to break the line specified by the y (vertical) offset at the given x (horizontal) offset
+func (text *Text) splitLine(xOffset int, yOffset int) error {
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return err
 	}
 
-	// NOTE: B the line at the end of the line
-	if xOffset == targetLine.GetBufferLength() {
+	// NOTE: Breaking the line at the start or the end of the line is keeping the line unchanged and inserting an empty line
+	if xOffset == 0 || xOffset == targetLine.GetBufferLength() {
 		line := new(Line)
 		if err := line.Init(""); err != nil {
 			return err
 		}
 
-		targetLine := text.lines[yOffset]
-
-		if err := text.appendLinesAtIndex(targetLine, yOffset); err != nil {
-			return err
+		if xOffset == 0 {
+			return text.lines.Insert(yOffset, line)
 		}
 
-		text.lines[yOffset+1] = line
-		return nil
+		return text.lines.Insert(yOffset+1, line)
 	}
 
 	// NOTE: Breaking the line in middle of the line
@@ -203,12 +275,7 @@ func (text *Text) InsertLine(cursor *Cursor) error {
 		return err
 	}
 
-	if err := text.appendLinesAtIndex(targetLineHead, yOffset); err != nil {
-		return err
-	}
-
-	text.lines[yOffset+1] = targetLineTail
-	return nil
+	return text.replaceLines(yOffset, yOffset, []*Line{targetLineHead, targetLineTail})
 }
 
 // Handle combining two lines into one. The current line specified by the cursors yOffset will be appended
@@ -225,78 +292,590 @@ func (text *Text) CombineLine(cursor *Cursor, lineStepDown bool) error {
 		return errors.New("text: invalid y (vertical) negative or out of bound offset requested to combine")
 	}
 
-	if yOffset > len(text.lines) {
+	if yOffset >= text.lines.GetLength() {
 		return errors.New("text: invalid y (vertical) out of bound offset requested to combine")
 	}
 
-	text.modified = true
+	targetLine, err := text.lines.Get(yOffset - 1)
+	if err != nil {
+		return err
+	}
 
-	currentLineBuffer := text.lines[yOffset].GetBufferAsSlice()
-	targetLineBuffer := text.lines[yOffset-1].GetBufferAsSlice()
-	combinedLineBuffer := append(targetLineBuffer, currentLineBuffer...)
+	xOffset := targetLine.GetBufferLength()
 
-	combinedLine, err := text.bufferToLine(combinedLineBuffer)
+	if err := text.joinLine(yOffset); err != nil {
+		return err
+	}
+
+	text.markModified(yOffset - 1)
+
+	return text.recordEdit(HistoryEditRemove, xOffset, yOffset-1, []rune{'\n'})
+}
+
+// Helper function used to append the line specified by the y (vertical) offset to the end of the line above
+func (text *Text) joinLine(yOffset int) error {
+	currentLine, err := text.lines.Get(yOffset)
 	if err != nil {
 		return err
 	}
 
-	text.lines[yOffset-1] = combinedLine
+	targetLine, err := text.lines.Get(yOffset - 1)
+	if err != nil {
+		return err
+	}
 
-	linesHead := text.lines[:yOffset]
-	linesTail := text.lines[yOffset+1:]
-	text.lines = append(linesHead, linesTail...)
+	currentLineBuffer := currentLine.GetBufferAsSlice()
+	targetLineBuffer := targetLine.GetBufferAsSlice()
 
-	return nil
+	combinedLineBuffer := make([]rune, 0, len(targetLineBuffer)+len(currentLineBuffer))
+	combinedLineBuffer = append(combinedLineBuffer, targetLineBuffer...)
+	combinedLineBuffer = append(combinedLineBuffer, currentLineBuffer...)
+
+	combinedLine, err := text.bufferToLine(combinedLineBuffer)
+	if err != nil {
+		return err
+	}
+
+	return text.replaceLines(yOffset-1, yOffset, []*Line{combinedLine})
 }
 
 // Helper function to for creating line structures from line buffers
 func (text *Text) bufferToLine(lineBuffer []rune) (*Line, error) {
-	builder := strings.Builder{}
+	line := new(Line)
+	if err := line.InitBuffer(lineBuffer); err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// Insert the given text at the position specified by the cursor. The text can contain multiple lines separated by
+// LF or CRLF sequences. The function returns the x (horizontal) and y (vertical) offsets directly after the inserted text
+func (text *Text) InsertText(textString string, cursor *Cursor) (int, int, error) {
+	xOffset := cursor.GetOffsetX()
+	yOffset := cursor.GetOffsetY()
+
+	// NOTE: Removing the 0x0D CR (Carriage Return)
+	content := []rune(strings.Replace(textString, "\r", "", -1))
+
+	if len(content) == 0 {
+		return xOffset, yOffset, nil
+	}
+
+	xEnd, yEnd, err := text.insertContent(xOffset, yOffset, content)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	text.markModified(yOffset)
+
+	if err := text.recordEdit(HistoryEditInsert, xOffset, yOffset, content); err != nil {
+		return 0, 0, err
+	}
+
+	return xEnd, yEnd, nil
+}
+
+// Remove the text between the given start (inclusive) and end (exclusive) offsets. The range can span multiple lines
+func (text *Text) RemoveRange(xStart int, yStart int, xEnd int, yEnd int) error {
+	content, err := text.getContent(xStart, yStart, xEnd, yEnd)
+	if err != nil {
+		return err
+	}
+
+	if len(content) == 0 {
+		return nil
+	}
+
+	if err := text.removeContent(xStart, yStart, xEnd, yEnd); err != nil {
+		return err
+	}
+
+	text.markModified(yStart)
+
+	return text.recordEdit(HistoryEditRemove, xStart, yStart, content)
+}
+
+// Return the text between the given start (inclusive) and end (exclusive) offsets. Lines are separated by the LF (0x0A) character
+func (text *Text) GetRangeAsString(xStart int, yStart int, xEnd int, yEnd int) (*string, error) {
+	content, err := text.getContent(xStart, yStart, xEnd, yEnd)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, char := range lineBuffer {
-		if _, err := builder.WriteRune(char); err != nil {
-			return nil, err
+	rangeString := string(content)
+	return &rangeString, nil
+}
+
+// Return all occurrences of the given pattern inside the text, sorted in the text order. Matches are not spanning
+// multiple lines and are not overlapping. Empty matches of regular expressions are skipped
+func (text *Text) FindMatches(pattern string, options SearchOptions) ([]SearchMatch, error) {
+	patternBuffer := []rune(pattern)
+	if len(patternBuffer) == 0 {
+		return nil, errors.New("text: invalid empty search pattern")
+	}
+
+	if options.RegularExpression {
+		return text.findExpressionMatches(pattern, options)
+	}
+
+	if !options.CaseSensitive {
+		for index, char := range patternBuffer {
+			patternBuffer[index] = unicode.ToLower(char)
 		}
 	}
 
-	line := new(Line)
-	if err := line.Init(builder.String()); err != nil {
+	matches := make([]SearchMatch, 0)
+
+	text.lines.ForEach(0, func(yIndex int, line *Line) bool {
+		lineBuffer := line.GetBufferAsSlice()
+
+		for xIndex := 0; xIndex+len(patternBuffer) <= len(lineBuffer); xIndex += 1 {
+			if !matchBufferAtOffset(lineBuffer, patternBuffer, xIndex, options.CaseSensitive) {
+				continue
+			}
+
+			if options.WholeWord {
+				if xIndex > 0 && isWordCharacter(lineBuffer[xIndex-1]) {
+					continue
+				}
+
+				xEnd := xIndex + len(patternBuffer)
+				if xEnd < len(lineBuffer) && isWordCharacter(lineBuffer[xEnd]) {
+					continue
+				}
+			}
+
+			matches = append(matches, SearchMatch{
+				XOffset: xIndex,
+				YOffset: yIndex,
+				Length:  len(patternBuffer),
+			})
+
+			xIndex += len(patternBuffer) - 1
+		}
+
+		return true
+	})
+
+	return matches, nil
+}
+
+// Return the replacement for the given match. In the regular expression mode the $1 or ${name} capture group
+// references inside the replacement are expanded, otherwise the replacement is returned unchanged
+func (text *Text) ExpandReplacement(match SearchMatch, pattern string, replacement string, options SearchOptions) (string, error) {
+	if !options.RegularExpression {
+		return replacement, nil
+	}
+
+	if match.YOffset < 0 || match.YOffset >= text.lines.GetLength() {
+		return "", errors.New("text: invalid y (vertical) offset of the match to expand")
+	}
+
+	expression, err := compileSearchExpression(pattern, options)
+	if err != nil {
+		return "", err
+	}
+
+	line, err := text.lines.Get(match.YOffset)
+	if err != nil {
+		return "", err
+	}
+
+	lineBuffer := line.GetBufferAsSlice()
+	if match.XOffset < 0 || match.XOffset > len(lineBuffer) {
+		return "", errors.New("text: invalid x (horizontal) offset of the match to expand")
+	}
+
+	lineString := string(lineBuffer)
+	matchByteOffset := len(string(lineBuffer[:match.XOffset]))
+
+	for _, submatches := range expression.FindAllStringSubmatchIndex(lineString, -1) {
+		if submatches[0] != matchByteOffset || submatches[0] == submatches[1] {
+			continue
+		}
+
+		return string(expression.ExpandString(nil, replacement, lineString, submatches)), nil
+	}
+
+	return "", errors.New("text: the match is not present at the given offsets")
+}
+
+// Helper function used to find all matches of the regular expression inside the text
+func (text *Text) findExpressionMatches(pattern string, options SearchOptions) ([]SearchMatch, error) {
+	expression, err := compileSearchExpression(pattern, options)
+	if err != nil {
 		return nil, err
 	}
 
-	return line, nil
+	matches := make([]SearchMatch, 0)
+
+	text.lines.ForEach(0, func(yIndex int, line *Line) bool {
+		lineString := *line.GetBufferAsString()
+
+		for _, location := range expression.FindAllStringIndex(lineString, -1) {
+			if location[0] == location[1] {
+				continue
+			}
+
+			matches = append(matches, SearchMatch{
+				XOffset: utf8.RuneCountInString(lineString[:location[0]]),
+				YOffset: yIndex,
+				Length:  utf8.RuneCountInString(lineString[location[0]:location[1]]),
+			})
+		}
+
+		return true
+	})
+
+	return matches, nil
 }
 
-// Helper funcation to insert lines to line container at given index
-func (text *Text) appendLinesAtIndex(line *Line, index int) error {
-	if index < 0 || index > len(text.lines) {
-		return errors.New("text: invalid index to append lines container")
+// Helper function used to compile the regular expression according to the search options
+func compileSearchExpression(pattern string, options SearchOptions) (*regexp.Regexp, error) {
+	if options.WholeWord {
+		pattern = `\b(?:` + pattern + `)\b`
 	}
 
-	if index == len(text.lines) {
-		text.lines = append(text.lines, line)
+	if !options.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	expression, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.New("text: invalid regular expression search pattern")
+	}
+
+	return expression, nil
+}
+
+// Helper function used to check if the pattern is present in the buffer at the given offset
+func matchBufferAtOffset(buffer []rune, pattern []rune, offset int, caseSensitive bool) bool {
+	for index, patternChar := range pattern {
+		char := buffer[offset+index]
+		if !caseSensitive {
+			char = unicode.ToLower(char)
+		}
+
+		if char != patternChar {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Helper function used to check if the character can be a part of a word
+func isWordCharacter(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_'
+}
+
+// Attach the history structure which will be used to record all text edits in order to make them revertable
+func (text *Text) AttachHistory(history *History) error {
+	if history == nil {
+		return errors.New("text: invalid history reference")
+	}
+
+	text.history = history
+	return nil
+}
+
+// Revert the latest step of edits recorded by the attached history. The function returns the x (horizontal) and
+// y (vertical) offsets at which the reverted edit took place, which can be used to restore the cursor position
+func (text *Text) Undo() (int, int, error) {
+	if text.history == nil {
+		return 0, 0, errors.New("text: no history attached to revert the edits")
+	}
+
+	edits, err := text.history.PopUndo()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	xOffset, yOffset := 0, 0
+	yFirst := text.lines.GetLength()
+
+	// NOTE: The inverse edits must be applied in the reversed order
+	for index := len(edits) - 1; index >= 0; index -= 1 {
+		edit := edits[index]
+		if edit.YOffset < yFirst {
+			yFirst = edit.YOffset
+		}
+
+		switch edit.Type {
+		case HistoryEditInsert:
+			{
+				xEnd, yEnd := calculateContentEndOffsets(edit.XOffset, edit.YOffset, edit.Content)
+				if err := text.removeContent(edit.XOffset, edit.YOffset, xEnd, yEnd); err != nil {
+					return 0, 0, err
+				}
+
+				xOffset, yOffset = edit.XOffset, edit.YOffset
+			}
+		case HistoryEditRemove:
+			{
+				xOffset, yOffset, err = text.insertContent(edit.XOffset, edit.YOffset, edit.Content)
+				if err != nil {
+					return 0, 0, err
+				}
+			}
+		default:
+			return 0, 0, errors.New("text: invalid history edit type")
+		}
+	}
+
+	text.markModified(yFirst)
+	return xOffset, yOffset, nil
+}
+
+// Reapply the latest step of edits reverted by the attached history. The function returns the x (horizontal) and
+// y (vertical) offsets at which the reapplied edit took place, which can be used to restore the cursor position
+func (text *Text) Redo() (int, int, error) {
+	if text.history == nil {
+		return 0, 0, errors.New("text: no history attached to reapply the edits")
+	}
+
+	edits, err := text.history.PopRedo()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	xOffset, yOffset := 0, 0
+	yFirst := text.lines.GetLength()
+
+	for _, edit := range edits {
+		if edit.YOffset < yFirst {
+			yFirst = edit.YOffset
+		}
+
+		switch edit.Type {
+		case HistoryEditInsert:
+			{
+				xOffset, yOffset, err = text.insertContent(edit.XOffset, edit.YOffset, edit.Content)
+				if err != nil {
+					return 0, 0, err
+				}
+			}
+		case HistoryEditRemove:
+			{
+				xEnd, yEnd := calculateContentEndOffsets(edit.XOffset, edit.YOffset, edit.Content)
+				if err := text.removeContent(edit.XOffset, edit.YOffset, xEnd, yEnd); err != nil {
+					return 0, 0, err
+				}
+
+				xOffset, yOffset = edit.XOffset, edit.YOffset
+			}
+		default:
+			return 0, 0, errors.New("text: invalid history edit type")
+		}
+	}
+
+	text.markModified(yFirst)
+	return xOffset, yOffset, nil
+}
+
+// Helper function used to record the edit on the attached history. The edit is ignored if no history is attached
+func (text *Text) recordEdit(editType HistoryEditType, xOffset int, yOffset int, content []rune) error {
+	if text.history == nil {
 		return nil
 	}
 
-	linesHead := text.lines[:index+1]
-	linesTail := text.lines[index:]
+	return text.history.Push(HistoryEdit{
+		Type:    editType,
+		XOffset: xOffset,
+		YOffset: yOffset,
+		Content: content,
+	})
+}
 
-	text.lines = append(linesHead, linesTail...)
-	text.lines[index] = line
+// Helper function used to insert the content (which can contain LF line separators) at the given offsets. The function
+// returns the x (horizontal) and y (vertical) offsets of the position directly after the inserted content
+func (text *Text) insertContent(xOffset int, yOffset int, content []rune) (int, int, error) {
+	if yOffset < 0 || yOffset >= text.lines.GetLength() {
+		return 0, 0, errors.New("text: invalid y (vertical) offset requested to insert content")
+	}
+
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	targetLineBuffer := targetLine.GetBufferAsSlice()
+	if xOffset < 0 || xOffset > len(targetLineBuffer) {
+		return 0, 0, errors.New("text: invalid x (horizontal) offset requested to insert content")
+	}
+
+	segments := make([][]rune, 1)
+	for _, char := range content {
+		if char == '\n' {
+			segments = append(segments, []rune{})
+			continue
+		}
+
+		segments[len(segments)-1] = append(segments[len(segments)-1], char)
+	}
+
+	lines := make([]*Line, 0, len(segments))
+	for index, segment := range segments {
+		lineBuffer := make([]rune, 0, len(segment))
+
+		if index == 0 {
+			lineBuffer = append(lineBuffer, targetLineBuffer[:xOffset]...)
+		}
+
+		lineBuffer = append(lineBuffer, segment...)
+
+		if index == len(segments)-1 {
+			lineBuffer = append(lineBuffer, targetLineBuffer[xOffset:]...)
+		}
+
+		line, err := text.bufferToLine(lineBuffer)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := text.replaceLines(yOffset, yOffset, lines); err != nil {
+		return 0, 0, err
+	}
+
+	xEnd, yEnd := calculateContentEndOffsets(xOffset, yOffset, content)
+	return xEnd, yEnd, nil
+}
+
+// Helper function used to remove the content between the given start (inclusive) and end (exclusive) offsets
+func (text *Text) removeContent(xStart int, yStart int, xEnd int, yEnd int) error {
+	if yStart < 0 || yEnd >= text.lines.GetLength() || yStart > yEnd {
+		return errors.New("text: invalid y (vertical) offsets requested to remove content")
+	}
+
+	startLineBuffer, err := text.GetLineBufferByOffset(yStart)
+	if err != nil {
+		return err
+	}
+
+	endLineBuffer, err := text.GetLineBufferByOffset(yEnd)
+	if err != nil {
+		return err
+	}
+
+	if xStart < 0 || xStart > len(startLineBuffer) || xEnd < 0 || xEnd > len(endLineBuffer) {
+		return errors.New("text: invalid x (horizontal) offsets requested to remove content")
+	}
+
+	if yStart == yEnd && xStart > xEnd {
+		return errors.New("text: invalid x (horizontal) offsets requested to remove content")
+	}
+
+	lineBuffer := make([]rune, 0, xStart+len(endLineBuffer)-xEnd)
+	lineBuffer = append(lineBuffer, startLineBuffer[:xStart]...)
+	lineBuffer = append(lineBuffer, endLineBuffer[xEnd:]...)
+
+	line, err := text.bufferToLine(lineBuffer)
+	if err != nil {
+		return err
+	}
+
+	return text.replaceLines(yStart, yEnd, []*Line{line})
+}
+
+// Helper function used to retrieve the content between the given start (inclusive) and end (exclusive) offsets
+func (text *Text) getContent(xStart int, yStart int, xEnd int, yEnd int) ([]rune, error) {
+	if yStart < 0 || yEnd >= text.lines.GetLength() || yStart > yEnd {
+		return nil, errors.New("text: invalid y (vertical) offsets requested to get content")
+	}
+
+	startLineBuffer, err := text.GetLineBufferByOffset(yStart)
+	if err != nil {
+		return nil, err
+	}
+
+	endLineBuffer, err := text.GetLineBufferByOffset(yEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	if xStart < 0 || xStart > len(startLineBuffer) || xEnd < 0 || xEnd > len(endLineBuffer) {
+		return nil, errors.New("text: invalid x (horizontal) offsets requested to get content")
+	}
+
+	if yStart == yEnd {
+		if xStart > xEnd {
+			return nil, errors.New("text: invalid x (horizontal) offsets requested to get content")
+		}
+
+		return append([]rune{}, startLineBuffer[xStart:xEnd]...), nil
+	}
+
+	content := append([]rune{}, startLineBuffer[xStart:]...)
+	text.lines.ForEach(yStart+1, func(yIndex int, line *Line) bool {
+		if yIndex >= yEnd {
+			return false
+		}
+
+		content = append(content, '\n')
+		content = append(content, line.GetBufferAsSlice()...)
+		return true
+	})
+
+	content = append(content, '\n')
+	content = append(content, endLineBuffer[:xEnd]...)
+
+	return content, nil
+}
+
+// Append the given lines (e.g. loaded in the background) to the end of the text. The change is not recorded by the history
+// and the text is not marked as modified, because the lines are a part of the persistent text
+func (text *Text) AppendLines(lines []*Line) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	lineCount := text.lines.GetLength()
+	if err := text.replaceLines(lineCount, lineCount-1, lines); err != nil {
+		return err
+	}
+
+	text.recordChange(lineCount)
 	return nil
 }
 
+// Helper function used to replace the lines between the start and end (both inclusive) indexes with the given lines
+func (text *Text) replaceLines(yStart int, yEnd int, lines []*Line) error {
+	return text.lines.Replace(yStart, yEnd, lines)
+}
+
+// Helper function used to calculate the x (horizontal) and y (vertical) offsets of the position directly after
+// the given content, which would be placed at the given offsets
+func calculateContentEndOffsets(xOffset int, yOffset int, content []rune) (int, int) {
+	for _, char := range content {
+		if char == '\n' {
+			xOffset = 0
+			yOffset += 1
+		} else {
+			xOffset += 1
+		}
+	}
+
+	return xOffset, yOffset
+}
+
 // Return a character based on the given x (horizontal) and y (vertical) offsets
 func (text *Text) GetCharacterByOffsets(xOffset int, yOffset int) (rune, error) {
 	if yOffset < 0 {
 		return 0, errors.New("text: invalid y (vertical) negative offset requested to get")
 	}
 
-	if yOffset > len(text.lines) {
+	if yOffset >= text.lines.GetLength() {
 		return 0, errors.New("text: invalid y (vertical) out of bound offset requested to get")
 	}
 
-	targetLine := text.lines[yOffset]
+	targetLine, err := text.lines.Get(yOffset)
+	if err != nil {
+		return 0, err
+	}
 
 	char, err := targetLine.GetBufferCharacterByOffset(xOffset)
 	if err != nil {
@@ -311,6 +890,57 @@ func (text *Text) GetCharacterByCursor(cursor *Cursor) (rune, error) {
 	return text.GetCharacterByOffsets(cursor.GetOffsetX(), cursor.GetOffsetY())
 }
 
+// Return the start (inclusive) and end (exclusive) x (horizontal) offsets of the word containing the character at the given offsets.
+// The line end is treated as the last character of the line. The whitespaces and other characters are grouped into separate runs
+// of the same kind, so the range is empty only for the empty line
+func (text *Text) GetWordRangeByOffsets(xOffset int, yOffset int) (int, int, error) {
+	lineBuffer, err := text.GetLineBufferByOffset(yOffset)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if xOffset < 0 || xOffset > len(lineBuffer) {
+		return 0, 0, errors.New("text: invalid x (horizontal) out of bound offset requested to get")
+	}
+
+	if len(lineBuffer) == 0 {
+		return 0, 0, nil
+	}
+
+	if xOffset == len(lineBuffer) {
+		xOffset -= 1
+	}
+
+	// NOTE: The characters are grouped into words, whitespaces and single other characters (e.g. punctuation)
+	kindOf := func(char rune) int {
+		switch {
+		case isWordCharacter(char):
+			return 1
+		case unicode.IsSpace(char):
+			return 2
+		default:
+			return 0
+		}
+	}
+
+	kind := kindOf(lineBuffer[xOffset])
+	if kind == 0 {
+		return xOffset, xOffset + 1, nil
+	}
+
+	xStart := xOffset
+	for xStart > 0 && kindOf(lineBuffer[xStart-1]) == kind {
+		xStart -= 1
+	}
+
+	xEnd := xOffset + 1
+	for xEnd < len(lineBuffer) && kindOf(lineBuffer[xEnd]) == kind {
+		xEnd += 1
+	}
+
+	return xStart, xEnd, nil
+}
+
 // Return the text in form of single string
 func (text *Text) GetTextAsString() (*string, error) {
 	builder := strings.Builder{}
@@ -330,22 +960,121 @@ func (text *Text) GetTextAsString() (*string, error) {
 		}
 	}
 
-	for index, line := range text.lines {
-		if _, err := builder.WriteString(*line.GetBufferAsString()); err != nil {
-			return nil, err
-		}
+	lineCount := text.lines.GetLength()
 
-		if index+1 < len(text.lines) {
-			if _, err := builder.WriteString(lineSeparator); err != nil {
-				return nil, err
-			}
+	// NOTE: The strings.Builder is not returning any write errors
+	text.lines.ForEach(0, func(index int, line *Line) bool {
+		builder.WriteString(*line.GetBufferAsString())
+
+		if index+1 < lineCount {
+			builder.WriteString(lineSeparator)
 		}
-	}
+
+		return true
+	})
 
 	builderText := builder.String()
 	return &builderText, nil
 }
 
+// Decode all lines referencing the line source (see LineSource), so the file of the source is no longer used by the text. The
+// content and the revision of the text are not changed
+func (text *Text) MaterializeLines() {
+	text.lines.ForEach(0, func(index int, line *Line) bool {
+		line.materialize()
+		return true
+	})
+}
+
+// Return the revision of the text. The revision is incremented on every change of the text
+func (text *Text) GetRevision() int {
+	return text.revision
+}
+
+// Return the y (vertical) offset of the first line changed after the given revision of the text. The lines above the offset
+// are unchanged and were not moved. The bool value is false if the changes are not known (only the latest changes are kept)
+func (text *Text) GetFirstChangedOffset(revision int) (int, bool) {
+	yFirst := text.lines.GetLength()
+	if revision == text.revision {
+		return yFirst, true
+	}
+
+	// NOTE: Every change is incrementing the revision, so the kept changes must start directly after the given revision
+	if revision > text.revision || len(text.changes) == 0 || text.changes[0].revision > revision+1 {
+		return 0, false
+	}
+
+	for _, change := range text.changes {
+		if change.revision > revision && change.yOffset < yFirst {
+			yFirst = change.yOffset
+		}
+	}
+
+	return yFirst, true
+}
+
+// Return the y (vertical) offset of the line presented at the given offset in the given revision of the text, moved by the
+// lines inserted or removed above it since the revision. The offset of a removed line is moved to the line which replaced it.
+// The bool value is false if the changes are not known (only the latest changes are kept)
+func (text *Text) GetShiftedOffset(revision int, yOffset int) (int, bool) {
+	if revision == text.revision {
+		return yOffset, true
+	}
+
+	// NOTE: Every change is incrementing the revision, so the kept changes must start directly after the given revision
+	if revision > text.revision || len(text.changes) == 0 || text.changes[0].revision > revision+1 {
+		return 0, false
+	}
+
+	for _, change := range text.changes {
+		if change.revision <= revision || yOffset <= change.yOffset {
+			continue
+		}
+
+		yOffset += change.lineDelta
+		if yOffset < change.yOffset {
+			yOffset = change.yOffset
+		}
+	}
+
+	return yOffset, true
+}
+
+// Helper function used to mark the text as modified starting from the line specified by the y (vertical) offset and increment
+// the text revision
+func (text *Text) markModified(yOffset int) {
+	text.modified = true
+	text.recordChange(yOffset)
+}
+
+// Helper function used to increment the text revision and remember the first line affected by the change together with the
+// count of lines inserted or removed by the change
+func (text *Text) recordChange(yOffset int) {
+	text.revision += 1
+
+	lineDelta := text.lines.GetLength() - text.lineCount
+	text.lineCount = text.lines.GetLength()
+
+	if len(text.changes) >= textChangeLimit {
+		text.changes = text.changes[1:]
+	}
+
+	text.changes = append(text.changes, textChange{
+		revision:  text.revision,
+		yOffset:   yOffset,
+		lineDelta: lineDelta,
+	})
+}
+
+// Helper function used to return the line structure based on given y (vertical) offset
+func (text *Text) getLineByOffset(yOffset int) (*Line, error) {
+	if yOffset < 0 || yOffset >= text.lines.GetLength() {
+		return nil, errors.New("text: invalid y (vertical) offset requested to get")
+	}
+
+	return text.lines.Get(yOffset)
+}
+
 // Return a bool value indicating if the current text differs from the persistent text
 func (text *Text) IsModified() bool {
 	return text.modified
@@ -357,6 +1086,16 @@ func (text *Text) ResetModificationState() error {
 	return nil
 }
 
+// Detect the end-of-line sequence of the given text (e.g. the content written to a different file). The current sequence is
+// kept if the text does not contain any line break
+func (text *Text) DetectEndOfLineSequence(textString string) {
+	if strings.Contains(textString, "\r\n") {
+		text.endOfLineSequence = "CRLF"
+	} else if strings.Contains(textString, "\n") {
+		text.endOfLineSequence = "LF"
+	}
+}
+
 // Return the end-of-line sequence name (CRLF/LF)
 func (text *Text) GetEndOfLineSequenceName() string {
 	return text.endOfLineSequence
@@ -365,11 +1104,13 @@ func (text *Text) GetEndOfLineSequenceName() string {
 // A structure containing the configuration for the text structure
 type TextConfig struct {
 	UsePlatformSpecificEndOfLineSequence bool `json:"use-platform-specific-eol-sequence"`
+	LargeFileThresholdMegabytes          int  `json:"large-file-threshold-mb"`
 }
 
 // Return a new isntance of the text configuration with default values
 func CreateDefaultTextConfig() TextConfig {
 	return TextConfig{
 		UsePlatformSpecificEndOfLineSequence: true,
+		LargeFileThresholdMegabytes:          64,
 	}
 }
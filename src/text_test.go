@@ -1,6 +1,9 @@
 package main
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestTextShouldInitializeValidCrLf(t *testing.T) {
 	textContent := "First line\r\nSecond line\r\nThird line"
@@ -504,8 +507,644 @@ func TestTextShouldReturnCorrectEolForCRLFText(t *testing.T) {
 	}
 }
 
+func TestTextShouldUndoAndRedoCharacterInsertion(t *testing.T) {
+	textContent := "First line\nSecond line\nThird line"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	history := new(History)
+	if err := history.Init(nil); err != nil {
+		t.Fail()
+	}
+
+	if err := text.AttachHistory(history); err != nil {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(6, 1, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	for _, char := range "ary" {
+		if err := text.InsertCharacter(char, cursor); err != nil {
+			t.Fail()
+		}
+
+		if err := cursor.SetOffsetX(cursor.GetOffsetX() + 1); err != nil {
+			t.Fail()
+		}
+	}
+
+	xOffset, yOffset, err := text.Undo()
+	if err != nil {
+		t.Fail()
+	}
+
+	if xOffset != 6 || yOffset != 1 {
+		t.Fail()
+	}
+
+	result, err := text.GetTextAsString()
+	if err != nil || *result != textContent {
+		t.Fail()
+	}
+
+	xOffset, yOffset, err = text.Redo()
+	if err != nil {
+		t.Fail()
+	}
+
+	if xOffset != 9 || yOffset != 1 {
+		t.Fail()
+	}
+
+	result, err = text.GetTextAsString()
+	if err != nil || *result != "First line\nSecondary line\nThird line" {
+		t.Fail()
+	}
+}
+
+func TestTextShouldUndoLineBreakingAndCombining(t *testing.T) {
+	textContent := "First line\nSecond line\nThird line"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	history := new(History)
+	if err := history.Init(nil); err != nil {
+		t.Fail()
+	}
+
+	if err := text.AttachHistory(history); err != nil {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(5, 1, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	if err := text.InsertLine(cursor); err != nil {
+		t.Fail()
+	}
+
+	if err := cursor.SetOffsets(0, 3); err != nil {
+		t.Fail()
+	}
+
+	if err := text.CombineLine(cursor, false); err != nil {
+		t.Fail()
+	}
+
+	result, err := text.GetTextAsString()
+	if err != nil || *result != "First line\nSecon\nd lineThird line" {
+		t.Fail()
+	}
+
+	xOffset, yOffset, err := text.Undo()
+	if err != nil || xOffset != 0 || yOffset != 3 {
+		t.Fail()
+	}
+
+	xOffset, yOffset, err = text.Undo()
+	if err != nil || xOffset != 5 || yOffset != 1 {
+		t.Fail()
+	}
+
+	result, err = text.GetTextAsString()
+	if err != nil || *result != textContent {
+		t.Fail()
+	}
+
+	if _, _, err := text.Undo(); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldNotUndoWithoutHistory(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("First line", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if _, _, err := text.Undo(); err == nil {
+		t.Fail()
+	}
+
+	if _, _, err := text.Redo(); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldReturnMultiLineRange(t *testing.T) {
+	textContent := "First line\nSecond line\nThird line"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	result, err := text.GetRangeAsString(6, 0, 5, 2)
+	if err != nil {
+		t.Fail()
+	}
+
+	if *result != "line\nSecond line\nThird" {
+		t.Fail()
+	}
+
+	if _, err := text.GetRangeAsString(6, 1, 2, 1); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldRemoveMultiLineRange(t *testing.T) {
+	textContent := "First line\nSecond line\nThird line"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	history := new(History)
+	if err := history.Init(nil); err != nil {
+		t.Fail()
+	}
+
+	if err := text.AttachHistory(history); err != nil {
+		t.Fail()
+	}
+
+	if err := text.RemoveRange(6, 0, 5, 2); err != nil {
+		t.Fail()
+	}
+
+	result, err := text.GetTextAsString()
+	if err != nil || *result != "First  line" {
+		t.Fail()
+	}
+
+	if text.GetLineCount() != 1 || !text.IsModified() {
+		t.Fail()
+	}
+
+	if _, _, err := text.Undo(); err != nil {
+		t.Fail()
+	}
+
+	result, err = text.GetTextAsString()
+	if err != nil || *result != textContent {
+		t.Fail()
+	}
+}
+
+func TestTextShouldNotRemoveRangeAtInvalidPosition(t *testing.T) {
+	textContent := "First line\nSecond line\nThird line"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if err := text.RemoveRange(0, 1, 0, 5); err == nil {
+		t.Fail()
+	}
+
+	if err := text.RemoveRange(20, 0, 0, 1); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldInsertMultiLineText(t *testing.T) {
+	textContent := "First line\nSecond line\nThird line"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(6, 1, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	xOffset, yOffset, err := text.InsertText("pasted\r\nmulti-line\r\n", cursor)
+	if err != nil {
+		t.Fail()
+	}
+
+	if xOffset != 0 || yOffset != 3 {
+		t.Fail()
+	}
+
+	result, err := text.GetTextAsString()
+	if err != nil || *result != "First line\nSecondpasted\nmulti-line\n line\nThird line" {
+		t.Fail()
+	}
+
+	if text.GetLineCount() != 5 {
+		t.Fail()
+	}
+}
+
+func TestTextShouldNotInsertTextAtInvalidPosition(t *testing.T) {
+	textContent := "First line\nSecond line\nThird line"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(20, 1, CreateConsoleMockup(), nil); err != nil {
+		t.Fail()
+	}
+
+	if _, _, err := text.InsertText("Hello", cursor); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldFindMatchesCaseInsensitive(t *testing.T) {
+	textContent := "First line\nSecond LINE\nThird lines"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	matches, err := text.FindMatches("line", SearchOptions{CaseSensitive: false, WholeWord: false})
+	if err != nil {
+		t.Fail()
+	}
+
+	if len(matches) != 3 {
+		t.FailNow()
+	}
+
+	if matches[1].XOffset != 7 || matches[1].YOffset != 1 || matches[1].Length != 4 {
+		t.Fail()
+	}
+}
+
+func TestTextShouldFindMatchesCaseSensitiveWholeWord(t *testing.T) {
+	textContent := "First line\nSecond LINE\nThird lines"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	matches, err := text.FindMatches("line", SearchOptions{CaseSensitive: true, WholeWord: true})
+	if err != nil {
+		t.Fail()
+	}
+
+	if len(matches) != 1 || matches[0].YOffset != 0 {
+		t.Fail()
+	}
+}
+
+func TestTextShouldNotFindMatchesForEmptyPattern(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("First line", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if _, err := text.FindMatches("", SearchOptions{}); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldFindMatchesRegularExpression(t *testing.T) {
+	textContent := "key = 12\nother = 7\nno value"
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	matches, err := text.FindMatches(`(\w+) = (\d+)`, SearchOptions{CaseSensitive: true, RegularExpression: true})
+	if err != nil {
+		t.Fail()
+	}
+
+	if len(matches) != 2 {
+		t.FailNow()
+	}
+
+	if matches[1].XOffset != 0 || matches[1].YOffset != 1 || matches[1].Length != 9 {
+		t.Fail()
+	}
+}
+
+func TestTextShouldNotFindMatchesForInvalidRegularExpression(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("First line", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	if _, err := text.FindMatches("(line", SearchOptions{RegularExpression: true}); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldExpandReplacementWithCaptureGroups(t *testing.T) {
+	textContent := "key = 12\nother = 7"
+	pattern := `(\w+) = (\d+)`
+	options := SearchOptions{CaseSensitive: true, RegularExpression: true}
+
+	text := new(Text)
+	if err := text.Init(textContent, false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	matches, err := text.FindMatches(pattern, options)
+	if err != nil || len(matches) != 2 {
+		t.FailNow()
+	}
+
+	replacement, err := text.ExpandReplacement(matches[1], pattern, "$2: ${1}", options)
+	if err != nil {
+		t.Fail()
+	}
+
+	if replacement != "7: other" {
+		t.Fail()
+	}
+}
+
+func TestTextShouldNotExpandReplacementForLiteralSearch(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("First $1 line", false, GetTextTestTextConfigMockup()); err != nil {
+		t.Fail()
+	}
+
+	matches, err := text.FindMatches("line", SearchOptions{})
+	if err != nil || len(matches) != 1 {
+		t.FailNow()
+	}
+
+	replacement, err := text.ExpandReplacement(matches[0], "line", "$1", SearchOptions{})
+	if err != nil {
+		t.Fail()
+	}
+
+	if replacement != "$1" {
+		t.Fail()
+	}
+}
+
+func TestTextShouldReturnWordRange(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("foo_bar  baz.\n", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	cases := []struct {
+		xOffset int
+		xStart  int
+		xEnd    int
+	}{
+		{2, 0, 7},
+		{6, 0, 7},
+		{7, 7, 9},
+		{9, 9, 12},
+		{12, 12, 13},
+		{13, 12, 13},
+	}
+
+	for _, c := range cases {
+		xStart, xEnd, err := text.GetWordRangeByOffsets(c.xOffset, 0)
+		if err != nil {
+			t.FailNow()
+		}
+
+		if xStart != c.xStart || xEnd != c.xEnd {
+			t.Fail()
+		}
+	}
+
+	if xStart, xEnd, err := text.GetWordRangeByOffsets(0, 1); err != nil || xStart != 0 || xEnd != 0 {
+		t.Fail()
+	}
+
+	if _, _, err := text.GetWordRangeByOffsets(14, 0); err == nil {
+		t.Fail()
+	}
+
+	if _, _, err := text.GetWordRangeByOffsets(0, 2); err == nil {
+		t.Fail()
+	}
+}
+
+func TestTextShouldKeepSplitLinesIndependent(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("HeadTail", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(4, 0, CreateConsoleMockup(), nil); err != nil {
+		t.FailNow()
+	}
+
+	if err := text.InsertLine(cursor); err != nil {
+		t.FailNow()
+	}
+
+	if err := text.InsertCharacter('!', cursor); err != nil {
+		t.FailNow()
+	}
+
+	if textString, err := text.GetTextAsString(); err != nil || *textString != "Head!\nTail" {
+		t.Fail()
+	}
+}
+
+func TestTextShouldAppendLinesWithoutModification(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("Hello", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	line := new(Line)
+	if err := line.Init("World"); err != nil {
+		t.FailNow()
+	}
+
+	revision := text.GetRevision()
+	if err := text.AppendLines([]*Line{line}); err != nil {
+		t.FailNow()
+	}
+
+	if text.IsModified() || text.GetRevision() == revision {
+		t.Fail()
+	}
+
+	textContent, err := text.GetTextAsString()
+	if err != nil || *textContent != "Hello\nWorld" {
+		t.Fail()
+	}
+}
+
+func BenchmarkTextInit(b *testing.B) {
+	content := strings.Repeat("The quick brown fox jumps over the lazy dog\n", 200000)
+
+	b.ResetTimer()
+	for index := 0; index < b.N; index += 1 {
+		text := new(Text)
+		if err := text.Init(content, false, GetTextTestTextConfigMockup()); err != nil {
+			b.FailNow()
+		}
+	}
+}
+
+func BenchmarkTextInsertAndCombineLine(b *testing.B) {
+	text := new(Text)
+	if err := text.Init(strings.Repeat("The quick brown fox jumps over the lazy dog\n", 200000), false, GetTextTestTextConfigMockup()); err != nil {
+		b.FailNow()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(10, 100000, CreateConsoleMockup(), nil); err != nil {
+		b.FailNow()
+	}
+
+	b.ResetTimer()
+	for index := 0; index < b.N; index += 1 {
+		if err := text.InsertLine(cursor); err != nil {
+			b.FailNow()
+		}
+
+		if err := text.CombineLine(cursor, true); err != nil {
+			b.FailNow()
+		}
+	}
+}
+
+func BenchmarkTextGetCharacterByOffsets(b *testing.B) {
+	text := new(Text)
+	if err := text.Init(strings.Repeat("The quick brown fox jumps over the lazy dog\n", 200000), false, GetTextTestTextConfigMockup()); err != nil {
+		b.FailNow()
+	}
+
+	b.ResetTimer()
+	for index := 0; index < b.N; index += 1 {
+		if _, err := text.GetCharacterByOffsets(index%40, index%200000); err != nil {
+			b.FailNow()
+		}
+	}
+}
+
 func GetTextTestTextConfigMockup() *TextConfig {
 	return &TextConfig{
 		UsePlatformSpecificEndOfLineSequence: false,
 	}
 }
+
+func TestTextShouldReturnFirstChangedOffset(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("First line\nSecond line\nThird line", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	revision := text.GetRevision()
+
+	if yOffset, ok := text.GetFirstChangedOffset(revision); !ok || yOffset != 3 {
+		t.Fail()
+	}
+
+	if err := text.RemoveRange(0, 2, 5, 2); err != nil {
+		t.FailNow()
+	}
+
+	if err := text.RemoveRange(0, 1, 6, 1); err != nil {
+		t.FailNow()
+	}
+
+	if yOffset, ok := text.GetFirstChangedOffset(revision); !ok || yOffset != 1 {
+		t.Fail()
+	}
+
+	if yOffset, ok := text.GetFirstChangedOffset(revision + 1); !ok || yOffset != 1 {
+		t.Fail()
+	}
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 2, CreateConsoleMockup(), nil); err != nil {
+		t.FailNow()
+	}
+
+	for index := 0; index < textChangeLimit; index += 1 {
+		if err := text.InsertCharacter('x', cursor); err != nil {
+			t.FailNow()
+		}
+	}
+
+	if _, ok := text.GetFirstChangedOffset(revision); ok {
+		t.Fail()
+	}
+
+	if yOffset, ok := text.GetFirstChangedOffset(revision + 2); !ok || yOffset != 2 {
+		t.Fail()
+	}
+}
+
+func TestTextShouldReturnShiftedOffset(t *testing.T) {
+	text := new(Text)
+	if err := text.Init("First line\nSecond line\nThird line\nFourth line", false, GetTextTestTextConfigMockup()); err != nil {
+		t.FailNow()
+	}
+
+	revision := text.GetRevision()
+
+	cursor := new(Cursor)
+	if err := cursor.Init(0, 0, CreateConsoleMockup(), nil); err != nil {
+		t.FailNow()
+	}
+
+	if _, _, err := text.InsertText("New line\nOther line\n", cursor); err != nil {
+		t.FailNow()
+	}
+
+	if yOffset, ok := text.GetShiftedOffset(revision, 3); !ok || yOffset != 5 {
+		t.Fail()
+	}
+
+	if yOffset, ok := text.GetShiftedOffset(revision, 0); !ok || yOffset != 0 {
+		t.Fail()
+	}
+
+	if err := text.RemoveRange(5, 2, 5, 4); err != nil {
+		t.FailNow()
+	}
+
+	if yOffset, ok := text.GetShiftedOffset(revision, 3); !ok || yOffset != 3 {
+		t.Fail()
+	}
+
+	if yOffset, ok := text.GetShiftedOffset(revision, 1); !ok || yOffset != 2 {
+		t.Fail()
+	}
+
+	if yOffset, ok := text.GetShiftedOffset(revision, 2); !ok || yOffset != 2 {
+		t.Fail()
+	}
+
+	for index := 0; index < textChangeLimit; index += 1 {
+		if err := text.InsertCharacter('x', cursor); err != nil {
+			t.FailNow()
+		}
+	}
+
+	if _, ok := text.GetShiftedOffset(revision, 3); ok {
+		t.Fail()
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"sort"
+)
+
+// Structure representing the color theme of the editor. The styles of the text elements (gutter, selection, search matches
+// and syntax tokens) are applied on top of the text style, so the unspecified colors are inherited from the text style
+type Theme struct {
+	Text               CharacterStyle `json:"text"`
+	Menu               CharacterStyle `json:"menu"`
+	Gutter             CharacterStyle `json:"gutter"`
+	GutterCurrentLine  CharacterStyle `json:"gutter-current-line"`
+	Selection          CharacterStyle `json:"selection"`
+	SearchMatch        CharacterStyle `json:"search-match"`
+	SearchCurrentMatch CharacterStyle `json:"search-current-match"`
+	SyntaxKeyword      CharacterStyle `json:"syntax-keyword"`
+	SyntaxBuiltin      CharacterStyle `json:"syntax-builtin"`
+	SyntaxString       CharacterStyle `json:"syntax-string"`
+	SyntaxComment      CharacterStyle `json:"syntax-comment"`
+	SyntaxNumber       CharacterStyle `json:"syntax-number"`
+	SyntaxSpecial      CharacterStyle `json:"syntax-special"`
+}
+
+// Return the style of the given syntax highlighting token type, applied on top of the text style
+func (theme *Theme) GetTokenStyle(token TokenType) CharacterStyle {
+	switch token {
+	case TokenKeyword:
+		return theme.Text.Merge(theme.SyntaxKeyword)
+	case TokenBuiltin:
+		return theme.Text.Merge(theme.SyntaxBuiltin)
+	case TokenString:
+		return theme.Text.Merge(theme.SyntaxString)
+	case TokenComment:
+		return theme.Text.Merge(theme.SyntaxComment)
+	case TokenNumber:
+		return theme.Text.Merge(theme.SyntaxNumber)
+	case TokenSpecial:
+		return theme.Text.Merge(theme.SyntaxSpecial)
+	default:
+		return theme.Text
+	}
+}
+
+// Helper function used to check if all colors of the theme are valid
+func (theme *Theme) validate() error {
+	styles := []CharacterStyle{
+		theme.Text, theme.Menu, theme.Gutter, theme.GutterCurrentLine, theme.Selection, theme.SearchMatch, theme.SearchCurrentMatch,
+		theme.SyntaxKeyword, theme.SyntaxBuiltin, theme.SyntaxString, theme.SyntaxComment, theme.SyntaxNumber, theme.SyntaxSpecial,
+	}
+
+	for _, style := range styles {
+		if _, err := ParseColor(style.Foreground); err != nil {
+			return errors.New("theme: invalid foreground color specified in the theme")
+		}
+
+		if _, err := ParseColor(style.Background); err != nil {
+			return errors.New("theme: invalid background color specified in the theme")
+		}
+	}
+
+	return nil
+}
+
+// Structure representing the collection of the available (built-in and custom) themes and the currently selected theme
+type Themes struct {
+	themes  map[string]*Theme
+	current string
+	config  *ThemeConfig
+}
+
+// Themes structure initialization function. The custom themes from the configuration are overriding the built-in themes with the same name
+func (themes *Themes) Init(themeConfig *ThemeConfig) error {
+	if themeConfig == nil {
+		defaultConfig := CreateDefaultThemeConfig()
+		themes.config = &defaultConfig
+	} else {
+		themes.config = themeConfig
+	}
+
+	themes.themes = make(map[string]*Theme)
+	for name, theme := range CreateBuiltInThemes() {
+		themes.themes[name] = theme
+	}
+
+	for name, theme := range themes.config.CustomThemes {
+		customTheme := theme
+		if err := customTheme.validate(); err != nil {
+			return err
+		}
+
+		themes.themes[name] = &customTheme
+	}
+
+	if err := themes.Select(themes.config.Theme); err != nil {
+		return errors.New("theme: unknown theme selected in the configuration")
+	}
+
+	return nil
+}
+
+// Select the theme with the given name as the current theme
+func (themes *Themes) Select(name string) error {
+	if _, ok := themes.themes[name]; !ok {
+		return errors.New("theme: theme with the given name does not exist")
+	}
+
+	themes.current = name
+	return nil
+}
+
+// Return the currently selected theme
+func (themes *Themes) GetCurrent() *Theme {
+	return themes.themes[themes.current]
+}
+
+// Return the name of the currently selected theme
+func (themes *Themes) GetCurrentName() string {
+	return themes.current
+}
+
+// Return the sorted names of all available themes
+func (themes *Themes) GetNames() []string {
+	names := make([]string, 0, len(themes.themes))
+	for name := range themes.themes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Return a new instance of the built-in themes collection, indexed by the theme name
+func CreateBuiltInThemes() map[string]*Theme {
+	defaultTheme := CreateDefaultTheme()
+
+	return map[string]*Theme{
+		"default": &defaultTheme,
+		"dark": {
+			Text:               CharacterStyle{Foreground: "#d4d4d4", Background: "#1e1e1e"},
+			Menu:               CharacterStyle{Foreground: "#ffffff", Background: "#007acc", Bold: true},
+			Gutter:             CharacterStyle{Foreground: "#858585"},
+			GutterCurrentLine:  CharacterStyle{Foreground: "#c6c6c6", Bold: true},
+			Selection:          CharacterStyle{Background: "#264f78"},
+			SearchMatch:        CharacterStyle{Background: "#613214"},
+			SearchCurrentMatch: CharacterStyle{Foreground: "#000000", Background: "#d7ba7d", Bold: true},
+			SyntaxKeyword:      CharacterStyle{Foreground: "#569cd6", Bold: true},
+			SyntaxBuiltin:      CharacterStyle{Foreground: "#4ec9b0"},
+			SyntaxString:       CharacterStyle{Foreground: "#ce9178"},
+			SyntaxComment:      CharacterStyle{Foreground: "#6a9955", Italic: true},
+			SyntaxNumber:       CharacterStyle{Foreground: "#b5cea8"},
+			SyntaxSpecial:      CharacterStyle{Foreground: "#9cdcfe"},
+		},
+		"light": {
+			Text:               CharacterStyle{Foreground: "#1f1f1f", Background: "#ffffff"},
+			Menu:               CharacterStyle{Foreground: "#000000", Background: "#dddddd", Bold: true},
+			Gutter:             CharacterStyle{Foreground: "#9e9e9e"},
+			GutterCurrentLine:  CharacterStyle{Foreground: "#0b216f", Bold: true},
+			Selection:          CharacterStyle{Background: "#add6ff"},
+			SearchMatch:        CharacterStyle{Background: "#f8dcc4"},
+			SearchCurrentMatch: CharacterStyle{Background: "#ffd33d", Bold: true},
+			SyntaxKeyword:      CharacterStyle{Foreground: "#0000ff", Bold: true},
+			SyntaxBuiltin:      CharacterStyle{Foreground: "#267f99"},
+			SyntaxString:       CharacterStyle{Foreground: "#a31515"},
+			SyntaxComment:      CharacterStyle{Foreground: "#008000", Italic: true},
+			SyntaxNumber:       CharacterStyle{Foreground: "#098658"},
+			SyntaxSpecial:      CharacterStyle{Foreground: "#001080"},
+		},
+		"high-contrast": {
+			Text:               CharacterStyle{Foreground: "white", Background: "black"},
+			Menu:               CharacterStyle{Foreground: "black", Background: "yellow", Bold: true},
+			Gutter:             CharacterStyle{Foreground: "silver"},
+			GutterCurrentLine:  CharacterStyle{Foreground: "yellow", Bold: true},
+			Selection:          CharacterStyle{Foreground: "black", Background: "aqua"},
+			SearchMatch:        CharacterStyle{Foreground: "black", Background: "lime"},
+			SearchCurrentMatch: CharacterStyle{Foreground: "black", Background: "yellow", Bold: true, Underline: true},
+			SyntaxKeyword:      CharacterStyle{Foreground: "aqua", Bold: true},
+			SyntaxBuiltin:      CharacterStyle{Foreground: "lime"},
+			SyntaxString:       CharacterStyle{Foreground: "yellow"},
+			SyntaxComment:      CharacterStyle{Foreground: "silver", Italic: true},
+			SyntaxNumber:       CharacterStyle{Foreground: "fuchsia"},
+			SyntaxSpecial:      CharacterStyle{Foreground: "red"},
+		},
+	}
+}
+
+// Return a new instance of the default theme, which is using the terminal default colors for the text
+func CreateDefaultTheme() Theme {
+	return Theme{
+		Text:               CharacterStyle{},
+		Menu:               CharacterStyle{Foreground: "black", Background: "white", Bold: true},
+		Gutter:             CharacterStyle{Foreground: "gray"},
+		GutterCurrentLine:  CharacterStyle{Foreground: "yellow", Bold: true},
+		Selection:          CharacterStyle{Foreground: "black", Background: "silver"},
+		SearchMatch:        CharacterStyle{Foreground: "black", Background: "olive"},
+		SearchCurrentMatch: CharacterStyle{Foreground: "black", Background: "yellow", Bold: true},
+		SyntaxKeyword:      CharacterStyle{Foreground: "blue", Bold: true},
+		SyntaxBuiltin:      CharacterStyle{Foreground: "teal"},
+		SyntaxString:       CharacterStyle{Foreground: "green"},
+		SyntaxComment:      CharacterStyle{Foreground: "gray", Italic: true},
+		SyntaxNumber:       CharacterStyle{Foreground: "purple"},
+		SyntaxSpecial:      CharacterStyle{Foreground: "aqua"},
+	}
+}
+
+// A structure containing the configuration for the themes structure
+type ThemeConfig struct {
+	Theme        string           `json:"theme"`
+	CustomThemes map[string]Theme `json:"custom-themes"`
+}
+
+// Return a new instance of the theme configuration with default values
+func CreateDefaultThemeConfig() ThemeConfig {
+	return ThemeConfig{
+		Theme:        "default",
+		CustomThemes: map[string]Theme{},
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+func TestThemesShouldInitializeWithBuiltInThemes(t *testing.T) {
+	themes := new(Themes)
+	if err := themes.Init(nil); err != nil {
+		t.FailNow()
+	}
+
+	if themes.GetCurrentName() != "default" || themes.GetCurrent() == nil {
+		t.Fail()
+	}
+
+	for _, name := range themes.GetNames() {
+		if err := themes.Select(name); err != nil {
+			t.Fail()
+		}
+
+		if err := themes.GetCurrent().validate(); err != nil {
+			t.Fail()
+		}
+	}
+}
+
+func TestThemesShouldSelectCustomTheme(t *testing.T) {
+	config := CreateDefaultThemeConfig()
+	config.Theme = "custom"
+	config.CustomThemes["custom"] = Theme{
+		Text: CharacterStyle{Foreground: "#101010", Background: "color236"},
+	}
+
+	themes := new(Themes)
+	if err := themes.Init(&config); err != nil {
+		t.FailNow()
+	}
+
+	if themes.GetCurrent().Text.Background != "color236" {
+		t.Fail()
+	}
+}
+
+func TestThemesShouldNotInitializeForUnknownTheme(t *testing.T) {
+	config := CreateDefaultThemeConfig()
+	config.Theme = "unknown"
+
+	themes := new(Themes)
+	if err := themes.Init(&config); err == nil {
+		t.Fail()
+	}
+}
+
+func TestThemesShouldNotInitializeForInvalidColor(t *testing.T) {
+	config := CreateDefaultThemeConfig()
+	config.CustomThemes["custom"] = Theme{
+		Menu: CharacterStyle{Foreground: "#12"},
+	}
+
+	themes := new(Themes)
+	if err := themes.Init(&config); err == nil {
+		t.Fail()
+	}
+}
+
+func TestThemeShouldApplyTokenStyleOnTopOfTextStyle(t *testing.T) {
+	theme := Theme{
+		Text:          CharacterStyle{Foreground: "white", Background: "black"},
+		SyntaxKeyword: CharacterStyle{Foreground: "blue", Bold: true},
+	}
+
+	style := theme.GetTokenStyle(TokenKeyword)
+	if style.Foreground != "blue" || style.Background != "black" || !style.Bold {
+		t.Fail()
+	}
+
+	if theme.GetTokenStyle(TokenNone) != theme.Text {
+		t.Fail()
+	}
+}
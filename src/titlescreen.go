@@ -0,0 +1,449 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Type representing the currently presented part of the title screen
+type titleScreenMode int16
+
+const (
+	titleScreenStart titleScreenMode = iota
+	titleScreenBrowser
+	titleScreenCreate
+)
+
+// Structure representing the title screen displayed when the program is started without a file. The screen lists the recently
+// opened files and provides a directory browser, which allows to open an existing file or create a new one
+type TitleScreen struct {
+	console      Console
+	state        *State
+	theme        *Theme
+	browser      *FileBrowser
+	list         *Popup
+	mode         titleScreenMode
+	input        []rune
+	notification string
+}
+
+// Title screen structure initialization function
+func (titleScreen *TitleScreen) Init(console Console, state *State, theme *Theme) error {
+	if console == nil {
+		return errors.New("titlescreen: invalid internal console api contract implementation")
+	}
+
+	if state == nil {
+		return errors.New("titlescreen: invalid state struct reference")
+	}
+
+	if theme == nil {
+		return errors.New("titlescreen: invalid theme struct reference")
+	}
+
+	titleScreen.console = console
+	titleScreen.state = state
+	titleScreen.theme = theme
+	titleScreen.browser = nil
+	titleScreen.notification = ""
+
+	titleScreen.list = new(Popup)
+	if err := titleScreen.list.Init("Recent files", nil); err != nil {
+		return err
+	}
+
+	titleScreen.setMode(titleScreenStart)
+	return nil
+}
+
+// Start the title screen loop. The function returns the path of the file selected to open (or create) and a bool value
+// indicating if a file was selected. The false value indicates that the program should be closed
+func (titleScreen *TitleScreen) Start() (string, bool, error) {
+	if err := titleScreen.render(); err != nil {
+		return "", false, err
+	}
+
+	for {
+		ev := titleScreen.console.WatchConsoleEvent()
+		switch event := ev.(type) {
+
+		case ConsoleEventKeyPress:
+			{
+				titleScreen.notification = ""
+
+				filePath, done, err := titleScreen.handleKeyPress(event)
+				if err != nil || done {
+					return filePath, len(filePath) > 0, err
+				}
+			}
+
+		case ConsoleEventPaste:
+			{
+				if titleScreen.mode != titleScreenStart {
+					for _, char := range event.Text {
+						if char == '\n' {
+							break
+						}
+
+						titleScreen.insertInputCharacter(char)
+					}
+				}
+			}
+		}
+
+		if err := titleScreen.render(); err != nil {
+			return "", false, err
+		}
+	}
+}
+
+// Helper function used to handle the key press in the current mode. The function returns the path of the selected file and a bool
+// value indicating if the title screen loop should be broken
+func (titleScreen *TitleScreen) handleKeyPress(event ConsoleEventKeyPress) (string, bool, error) {
+	if event.Modifier == ModifierCtrl && event.Key == KeyPrintable && (event.Char == 'c' || event.Char == 'q') {
+		return "", true, nil
+	}
+
+	switch event.Key {
+	case KeyUp:
+		titleScreen.list.SelectPrevious()
+		return "", false, nil
+	case KeyDown:
+		titleScreen.list.SelectNext()
+		return "", false, nil
+	}
+
+	switch titleScreen.mode {
+	case titleScreenStart:
+		return titleScreen.handleStartKeyPress(event)
+	case titleScreenBrowser:
+		return titleScreen.handleBrowserKeyPress(event)
+	case titleScreenCreate:
+		return titleScreen.handleCreateKeyPress(event)
+	default:
+		return "", false, errors.New("titlescreen: invalid title screen mode")
+	}
+}
+
+// Helper function used to handle the key press on the start screen (recent files list)
+func (titleScreen *TitleScreen) handleStartKeyPress(event ConsoleEventKeyPress) (string, bool, error) {
+	if event.Modifier != ModifierNone && event.Modifier != ModifierShift {
+		return "", false, nil
+	}
+
+	switch event.Key {
+	case KeyEnter:
+		{
+			index := titleScreen.list.GetSelectedIndex()
+			if index < 0 {
+				return "", false, nil
+			}
+
+			return titleScreen.state.GetRecentFiles()[index], true, nil
+		}
+	case KeyEscape:
+		return "", true, nil
+	case KeyTab:
+		return "", false, titleScreen.openBrowser(titleScreenBrowser)
+	case KeyPrintable:
+		{
+			switch event.Char {
+			case 'o', 'O':
+				return "", false, titleScreen.openBrowser(titleScreenBrowser)
+			case 'n', 'N':
+				return "", false, titleScreen.openBrowser(titleScreenCreate)
+			case 'q', 'Q':
+				return "", true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+// Helper function used to handle the key press inside the directory browser. The typed characters are filtering the entries
+func (titleScreen *TitleScreen) handleBrowserKeyPress(event ConsoleEventKeyPress) (string, bool, error) {
+	if event.Modifier == ModifierCtrl && event.Key == KeyPrintable && event.Char == 'n' {
+		return titleScreen.createFile()
+	}
+
+	if event.Modifier != ModifierNone && event.Modifier != ModifierShift {
+		return "", false, nil
+	}
+
+	switch event.Key {
+	case KeyPrintable:
+		titleScreen.insertInputCharacter(event.Char)
+	case KeyBackspace:
+		{
+			if len(titleScreen.input) > 0 {
+				titleScreen.input = titleScreen.input[:len(titleScreen.input)-1]
+				titleScreen.applyFilter()
+				return "", false, nil
+			}
+
+			titleScreen.changeDirectory(titleScreen.browser.ChangeToParentDirectory)
+		}
+	case KeyEscape:
+		{
+			if len(titleScreen.input) > 0 {
+				titleScreen.input = nil
+				titleScreen.applyFilter()
+				return "", false, nil
+			}
+
+			titleScreen.setMode(titleScreenStart)
+		}
+	case KeyEnter:
+		{
+			index := titleScreen.list.GetSelectedIndex()
+			if index < 0 {
+				return "", false, nil
+			}
+
+			var filePath string = ""
+			var opened bool = false
+
+			titleScreen.changeDirectory(func() error {
+				var err error = nil
+				filePath, opened, err = titleScreen.browser.Open(index)
+				return err
+			})
+
+			if opened {
+				return filePath, true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+// Helper function used to handle the key press inside the new file name prompt
+func (titleScreen *TitleScreen) handleCreateKeyPress(event ConsoleEventKeyPress) (string, bool, error) {
+	if event.Modifier != ModifierNone && event.Modifier != ModifierShift {
+		return "", false, nil
+	}
+
+	switch event.Key {
+	case KeyPrintable:
+		titleScreen.insertInputCharacter(event.Char)
+	case KeyBackspace:
+		{
+			if len(titleScreen.input) > 0 {
+				titleScreen.input = titleScreen.input[:len(titleScreen.input)-1]
+			}
+		}
+	case KeyEscape:
+		titleScreen.setMode(titleScreenStart)
+	case KeyEnter:
+		return titleScreen.createFile()
+	}
+
+	return "", false, nil
+}
+
+// Helper function used to return the path of the file named by the input inside the browsed directory. The file is created by
+// the editor on the first save
+func (titleScreen *TitleScreen) createFile() (string, bool, error) {
+	filePath, err := titleScreen.browser.CreateFilePath(string(titleScreen.input))
+	if err != nil {
+		titleScreen.notification = "Invalid file name."
+		return "", false, nil
+	}
+
+	return filePath, true, nil
+}
+
+// Helper function used to start the directory browser (or the new file prompt) in the current working directory. The previously
+// browsed directory is kept
+func (titleScreen *TitleScreen) openBrowser(mode titleScreenMode) error {
+	if titleScreen.browser == nil {
+		workingDirectory, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		titleScreen.browser = new(FileBrowser)
+		if err := titleScreen.browser.Init(workingDirectory); err != nil {
+			return err
+		}
+	}
+
+	titleScreen.setMode(mode)
+	return nil
+}
+
+// Helper function used to execute the given directory change. The failure (e.g. missing permissions) is presented as a notification
+func (titleScreen *TitleScreen) changeDirectory(change func() error) {
+	if err := change(); err != nil {
+		titleScreen.notification = "Can not open the directory."
+		return
+	}
+
+	titleScreen.input = nil
+	titleScreen.applyFilter()
+}
+
+// Helper function used to switch the title screen mode. The input is reset and the listed items are replaced
+func (titleScreen *TitleScreen) setMode(mode titleScreenMode) {
+	titleScreen.mode = mode
+	titleScreen.input = nil
+
+	switch mode {
+	case titleScreenStart:
+		titleScreen.list.SetItems(titleScreen.state.GetRecentFiles())
+	case titleScreenBrowser:
+		titleScreen.applyFilter()
+	case titleScreenCreate:
+		titleScreen.list.SetItems(nil)
+	}
+}
+
+// Helper function used to insert the given character to the input. The filter is applied inside the directory browser
+func (titleScreen *TitleScreen) insertInputCharacter(char rune) {
+	titleScreen.input = append(titleScreen.input, char)
+
+	if titleScreen.mode == titleScreenBrowser {
+		titleScreen.applyFilter()
+	}
+}
+
+// Helper function used to filter the directory browser entries by the input
+func (titleScreen *TitleScreen) applyFilter() {
+	titleScreen.browser.SetFilter(string(titleScreen.input))
+	titleScreen.list.SetItems(titleScreen.browser.GetEntryNames())
+}
+
+// Helper function used to redraw the whole title screen. The header and the hints are placed in the first and last rows, the
+// remaining rows are containing the description, the input and the list of items
+func (titleScreen *TitleScreen) render() error {
+	width, height := titleScreen.console.GetSize()
+	textStyle := titleScreen.theme.Text
+	barStyle := titleScreen.theme.Menu
+	dimmedStyle := titleScreen.theme.Text.Merge(titleScreen.theme.Gutter)
+	selectedStyle := titleScreen.theme.Text.Merge(titleScreen.theme.Selection)
+
+	var description string
+	var inputLabel string
+	var hints string
+
+	switch titleScreen.mode {
+	case titleScreenStart:
+		description = "Recent files"
+		hints = "[Enter] Open  [O] Browse  [N] New file  [Q] Quit"
+	case titleScreenBrowser:
+		description = fmt.Sprintf("Directory: %s", titleScreen.browser.GetDirectory())
+		inputLabel = "Filter: "
+		hints = "[Enter] Open  [Backspace] Parent  [Ctrl+N] Create file named as filter  [Esc] Back"
+	case titleScreenCreate:
+		description = fmt.Sprintf("New file in: %s", titleScreen.browser.GetDirectory())
+		inputLabel = "Name: "
+		hints = "[Enter] Create  [Esc] Back"
+	}
+
+	if len(titleScreen.notification) > 0 {
+		hints = fmt.Sprintf("%s  %s", titleScreen.notification, hints)
+	}
+
+	rows := make([]string, height)
+	styles := make([]CharacterStyle, height)
+	for yIndex := range rows {
+		styles[yIndex] = textStyle
+	}
+
+	rows[0] = " Termpad - lightweight, minimalist terminal text editor"
+	styles[0] = barStyle
+	styles[0].Bold = true
+
+	if height > 2 {
+		rows[2] = fmt.Sprintf(" %s", description)
+		styles[2] = dimmedStyle
+	}
+
+	listStart := 4
+	if len(inputLabel) > 0 {
+		if height > 3 {
+			rows[3] = fmt.Sprintf(" %s%s", inputLabel, string(titleScreen.input))
+		}
+
+		listStart = 5
+	}
+
+	listRows := height - listStart - 1
+	itemStart, itemEnd := titleScreen.list.GetVisibleRange(listRows)
+	items := titleScreen.list.GetItems()
+
+	if len(items) == 0 && titleScreen.mode != titleScreenCreate && listRows > 0 {
+		rows[listStart] = "   (empty)"
+		styles[listStart] = dimmedStyle
+	}
+
+	for itemIndex := itemStart; itemIndex < itemEnd; itemIndex += 1 {
+		yIndex := listStart + itemIndex - itemStart
+		rows[yIndex] = fmt.Sprintf("   %s", titleScreen.shortenPath(items[itemIndex]))
+
+		if itemIndex == titleScreen.list.GetSelectedIndex() {
+			rows[yIndex] = fmt.Sprintf(" > %s", titleScreen.shortenPath(items[itemIndex]))
+			styles[yIndex] = selectedStyle
+		}
+	}
+
+	if height > 1 {
+		rows[height-1] = fmt.Sprintf(" %s", hints)
+		styles[height-1] = barStyle
+	}
+
+	for yIndex, row := range rows {
+		runes := []rune(row)
+		for xIndex := 0; xIndex < width; xIndex += 1 {
+			char := ' '
+			if xIndex < len(runes) {
+				char = runes[xIndex]
+			}
+
+			if err := titleScreen.console.InsertCharacterWithStyle(xIndex, yIndex, char, styles[yIndex]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// NOTE: The console cursor is only displayed at the end of the input
+	if len(inputLabel) > 0 && height > 3 {
+		xCursor := len([]rune(inputLabel)) + len(titleScreen.input) + 1
+		if xCursor >= width {
+			xCursor = width - 1
+		}
+
+		if err := titleScreen.console.SetCursorPosition(xCursor, 3); err != nil {
+			return err
+		}
+	} else {
+		if err := titleScreen.console.SetCursorPosition(-1, -1); err != nil {
+			return err
+		}
+	}
+
+	return titleScreen.console.Commit()
+}
+
+// Helper function used to replace the home directory prefix of the path with the tilde on the start screen
+func (titleScreen *TitleScreen) shortenPath(path string) string {
+	if titleScreen.mode != titleScreenStart {
+		return path
+	}
+
+	homeDirectory, err := os.UserHomeDir()
+	if err != nil || len(homeDirectory) == 0 {
+		return path
+	}
+
+	if strings.HasPrefix(path, homeDirectory+string(filepath.Separator)) {
+		return "~" + strings.TrimPrefix(path, homeDirectory)
+	}
+
+	return path
+}
@@ -0,0 +1,49 @@
+package main
+
+// Calculate the start offsets of the visual rows (segments) of a single line wrapped at the given width. A line always has
+// at least one segment. If the word boundary wrapping is enabled, the line is broken after the last whitespace that fits
+// into the row and only words longer than the width are broken in the middle
+func CalculateWrapSegments(buffer []rune, width int, wordBoundary bool) []int {
+	segments := []int{0}
+	if width <= 0 {
+		return segments
+	}
+
+	segmentStart := 0
+	for len(buffer)-segmentStart > width {
+		segmentEnd := segmentStart + width
+
+		if wordBoundary {
+			for xIndex := segmentEnd; xIndex > segmentStart; xIndex -= 1 {
+				if isWrapWhitespace(buffer[xIndex-1]) {
+					segmentEnd = xIndex
+					break
+				}
+			}
+		}
+
+		segments = append(segments, segmentEnd)
+		segmentStart = segmentEnd
+	}
+
+	return segments
+}
+
+// Return the index of the segment containing the given x (horizontal) offset
+func GetWrapSegmentIndex(segments []int, xOffset int) int {
+	segmentIndex := 0
+	for index, segmentStart := range segments {
+		if segmentStart > xOffset {
+			break
+		}
+
+		segmentIndex = index
+	}
+
+	return segmentIndex
+}
+
+// Helper function used to check if the line can be wrapped after the given character
+func isWrapWhitespace(char rune) bool {
+	return char == ' ' || char == '\t'
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+func TestWrapShouldCalculateSingleSegmentForShortLine(t *testing.T) {
+	segments := CalculateWrapSegments([]rune("short"), 10, false)
+
+	if len(segments) != 1 || segments[0] != 0 {
+		t.Fail()
+	}
+}
+
+func TestWrapShouldCalculateSingleSegmentForEmptyLine(t *testing.T) {
+	segments := CalculateWrapSegments([]rune{}, 10, true)
+
+	if len(segments) != 1 || segments[0] != 0 {
+		t.Fail()
+	}
+}
+
+func TestWrapShouldCalculateSegmentsAtWidth(t *testing.T) {
+	segments := CalculateWrapSegments([]rune("first second third"), 5, false)
+
+	expected := []int{0, 5, 10, 15}
+	if len(segments) != len(expected) {
+		t.FailNow()
+	}
+
+	for index := range expected {
+		if segments[index] != expected[index] {
+			t.Fail()
+		}
+	}
+}
+
+func TestWrapShouldCalculateSegmentsAtWordBoundary(t *testing.T) {
+	segments := CalculateWrapSegments([]rune("first second third"), 8, true)
+
+	expected := []int{0, 6, 13}
+	if len(segments) != len(expected) {
+		t.FailNow()
+	}
+
+	for index := range expected {
+		if segments[index] != expected[index] {
+			t.Fail()
+		}
+	}
+}
+
+func TestWrapShouldBreakLongWordAtWidth(t *testing.T) {
+	segments := CalculateWrapSegments([]rune("abcdefghij kl"), 4, true)
+
+	expected := []int{0, 4, 8, 11}
+	if len(segments) != len(expected) {
+		t.FailNow()
+	}
+
+	for index := range expected {
+		if segments[index] != expected[index] {
+			t.Fail()
+		}
+	}
+}
+
+func TestWrapShouldReturnSegmentIndexForOffset(t *testing.T) {
+	segments := []int{0, 5, 10}
+
+	if GetWrapSegmentIndex(segments, 0) != 0 {
+		t.Fail()
+	}
+
+	if GetWrapSegmentIndex(segments, 7) != 1 {
+		t.Fail()
+	}
+
+	if GetWrapSegmentIndex(segments, 12) != 2 {
+		t.Fail()
+	}
+}